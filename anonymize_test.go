@@ -0,0 +1,114 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAnonymize(t *testing.T) {
+	file := "id,name,email,phone,ssn,city\n" +
+		"1,Jane Doe,jane@corp.com,212-555-0101,123-45-6789,Boston\n" +
+		"2,John Roe,,212-555-0102,987-65-4321,Denver\n" +
+		"1,Jane Doe,jane@corp.com,212-555-0101,123-45-6789,Boston\n"
+
+	rules := AnonymizeRules{
+		Columns: map[string]FakeKind{
+			"id":    FakeToken,
+			"name":  FakeName,
+			"email": FakeEmail,
+			"phone": FakePhone,
+			"ssn":   FakeFormat,
+		},
+		Secret: "test",
+	}
+
+	var buf bytes.Buffer
+	err := Anonymize(strings.NewReader(file), &buf, rules)
+	if err != nil {
+		t.Fatalf("encountered error anonymizing csv data: %v", err)
+	}
+
+	p := NewParser(&buf, ParserOptions{})
+	var records []map[string]string
+	for {
+		record, err := p.ReadRecordMap()
+		if err != nil {
+			break
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records but got %d", len(records))
+	}
+
+	patterns := map[string]*regexp.Regexp{
+		"id":    regexp.MustCompile(`^[0-9a-f]{16}$`),
+		"name":  regexp.MustCompile(`^[A-Z][a-z]+ [A-Z][a-z]+$`),
+		"email": regexp.MustCompile(`^[a-z]+\.[a-z]+[0-9]{1,2}@example\.com$`),
+		"phone": regexp.MustCompile(`^555-[0-9]{3}-[0-9]{4}$`),
+		"ssn":   regexp.MustCompile(`^[0-9]{3}-[0-9]{2}-[0-9]{4}$`),
+	}
+	for column, pattern := range patterns {
+		if !pattern.MatchString(records[0][column]) {
+			t.Errorf("improperly faked %s. Got '%s'", column, records[0][column])
+		}
+	}
+
+	if records[0]["ssn"] == "123-45-6789" || records[0]["name"] == "Jane Doe" {
+		t.Errorf("expected values to be rewritten but got %v", records[0])
+	}
+	if records[0]["city"] != "Boston" {
+		t.Errorf("expected unlisted column to be copied but got '%s'", records[0]["city"])
+	}
+	if records[1]["email"] != "" {
+		t.Errorf("expected empty value to be left empty but got '%s'", records[1]["email"])
+	}
+	for column := range rules.Columns {
+		if records[0][column] != records[2][column] {
+			t.Errorf("expected the same fake for the same %s but got '%s' and '%s'", column, records[0][column], records[2][column])
+		}
+	}
+	if records[0]["id"] == records[1]["id"] {
+		t.Errorf("expected different fakes for different ids but got '%s' for both", records[0]["id"])
+	}
+}
+
+func TestAnonymizeSecret(t *testing.T) {
+	file := "ssn\n123-45-6789\n"
+
+	fakes := make(map[string]string)
+	for _, secret := range []string{"a", "b", "a"} {
+		var buf bytes.Buffer
+		err := Anonymize(strings.NewReader(file), &buf, AnonymizeRules{Columns: map[string]FakeKind{"ssn": FakeToken}, Secret: secret})
+		if err != nil {
+			t.Fatalf("encountered error anonymizing csv data: %v", err)
+		}
+
+		if previous, ok := fakes[secret]; ok && previous != buf.String() {
+			t.Errorf("expected the same fake for the same secret but got '%s' and '%s'", previous, buf.String())
+		}
+		fakes[secret] = buf.String()
+	}
+
+	if fakes["a"] == fakes["b"] {
+		t.Errorf("expected different fakes for different secrets but got '%s' for both", fakes["a"])
+	}
+}
+
+func TestAnonymizeErrors(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := Anonymize(strings.NewReader("name\nJane\n"), &buf, AnonymizeRules{Columns: map[string]FakeKind{"name": FakeKind(99)}})
+	if !errors.Is(err, ErrorInvalidFakeKind) {
+		t.Errorf("expected ErrorInvalidFakeKind but got %v", err)
+	}
+
+	err = Anonymize(strings.NewReader("name\nJane\n"), &buf, AnonymizeRules{Columns: map[string]FakeKind{"email": FakeEmail}})
+	if !errors.Is(err, ErrorFieldNotFound) {
+		t.Errorf("expected ErrorFieldNotFound but got %v", err)
+	}
+}
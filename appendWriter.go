@@ -0,0 +1,118 @@
+package csv
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// NewAppendWriter creates a new csv writer that appends records to the existing csv file f, as described by the csv decorator tags defined on structPointer.
+// The header of the file is read, and each field is written to the column with its header name, so the struct's fields may be in a different order than the file's columns.
+// Fields bound only by index are written to that column, and columns that no field is bound to are left empty. Every field bound only by header must be found in the file's header,
+// and fields with both attributes fall back to their index when their header name isn't found.
+// If f is empty, the header is written as it would be by WriteHeader. Compressed files can't be appended to. The header is never written twice, so WriteHeader should not be called on the returned writer, and the Columns option is ignored.
+func NewAppendWriter(f io.ReadWriteSeeker, structPointer interface{}, options WriterOptions) (w Writer, err error) {
+	if options.Compression != CompressionNone {
+		return w, OptionError{
+			Option: "Compression",
+			Err:    ErrorCompressedAppend,
+		}
+	}
+
+	// The columns are laid out by the file's header, or by the struct when the file is empty, so that appending to a new file writes the same layout as appending to one already written.
+	options.Columns = nil
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return w, err
+	}
+
+	reader := csv.NewReader(f)
+	if legalDelimiter(options.Delimiter) {
+		reader.Comma = options.Delimiter
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		w = NewWriter(f, options)
+		return w, w.WriteHeader(structPointer)
+	}
+	if err != nil {
+		return w, err
+	}
+
+	err = seekToAppend(f, options.UseCRLF)
+	if err != nil {
+		return w, err
+	}
+
+	w = NewWriter(f, options)
+
+	err = w.bind(structPointer)
+	if err != nil {
+		return w, err
+	}
+
+	columns := make([]string, len(header))
+	for _, fieldName := range w.fieldOrder {
+		attrs := w.csvAttrs[fieldName]
+		if attrs.shared {
+			continue
+		}
+
+		idx := attrs.columnIndex
+		if attrs.hasHeader {
+			headerIdx, foundIdx := findHeaderIndex(header, attrs.headerName)
+			if foundIdx {
+				idx = headerIdx
+			} else if !attrs.hasIndex {
+				return w, FieldNotFoundError{
+					FieldName:  fieldName,
+					HeaderName: attrs.headerName,
+					Err:        ErrorFieldNotFound,
+				}
+			}
+		}
+
+		for len(columns) <= idx {
+			columns = append(columns, "")
+		}
+		if columns[idx] != "" {
+			return w, CsvTagDefError{
+				CsvTag:    attrs.tag,
+				FieldName: fieldName,
+				Err:       ErrorDuplicateIndex,
+			}
+		}
+		columns[idx] = fieldName
+	}
+
+	w.setColumns(columns)
+
+	return w, nil
+}
+
+// seekToAppend moves to the end of f, and ends the last line of f if it isn't already, so that the next record starts on a line of its own.
+func seekToAppend(f io.ReadWriteSeeker, useCRLF bool) (err error) {
+	_, err = f.Seek(-1, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	last := make([]byte, 1)
+	_, err = io.ReadFull(f, last)
+	if err != nil {
+		return err
+	}
+
+	if last[0] == '\n' {
+		return nil
+	}
+
+	lineEnding := "\n"
+	if useCRLF {
+		lineEnding = "\r\n"
+	}
+	_, err = io.WriteString(f, lineEnding)
+
+	return err
+}
@@ -0,0 +1,94 @@
+package csv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type appendWriterTest struct {
+	Name  string `csv:"header:name"`
+	Count int    `csv:"header:count"`
+	Note  string `csv:"index:3"`
+}
+
+func TestAppendWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.csv")
+	err := os.WriteFile(path, []byte("count,extra,name,note\n1,x,widget,old"), 0644)
+	if err != nil {
+		t.Errorf("encountered error creating csv file: %v", err)
+		return
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Errorf("encountered error opening csv file: %v", err)
+		return
+	}
+	defer f.Close()
+
+	w, err := NewAppendWriter(f, &appendWriterTest{}, WriterOptions{})
+	if err != nil {
+		t.Errorf("encountered error creating append writer: %v", err)
+	}
+
+	err = w.WriteRecord(&appendWriterTest{Name: "gizmo", Count: 2, Note: "new"})
+	if err != nil {
+		t.Errorf("encountered error appending csv record: %v", err)
+	}
+	w.Flush()
+
+	contents, _ := os.ReadFile(path)
+	expected := "count,extra,name,note\n1,x,widget,old\n2,,gizmo,new\n"
+	if string(contents) != expected {
+		t.Errorf("improperly appended csv record. Got '%s' but expected '%s'", contents, expected)
+	}
+}
+
+func TestAppendWriterEmptyFile(t *testing.T) {
+	// Columns is ignored whether or not the file is empty, so both options write the same layout.
+	for _, options := range []WriterOptions{{}, {Columns: []string{"count"}}} {
+		f, err := os.Create(filepath.Join(t.TempDir(), "export.csv"))
+		if err != nil {
+			t.Errorf("encountered error creating csv file: %v", err)
+			return
+		}
+		defer f.Close()
+
+		w, err := NewAppendWriter(f, &appendWriterTest{}, options)
+		if err != nil {
+			t.Errorf("encountered error creating append writer: %v", err)
+		}
+
+		w.WriteRecord(&appendWriterTest{Name: "gizmo", Count: 2})
+		w.Flush()
+
+		contents, _ := os.ReadFile(f.Name())
+		expected := "name,count,,\ngizmo,2,,\n"
+		if string(contents) != expected {
+			t.Errorf("improperly written csv to empty file with columns %v. Got '%s' but expected '%s'", options.Columns, contents, expected)
+		}
+	}
+}
+
+func TestAppendWriterFieldNotFoundError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.csv")
+	err := os.WriteFile(path, []byte("name,total\nwidget,1\n"), 0644)
+	if err != nil {
+		t.Errorf("encountered error creating csv file: %v", err)
+		return
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Errorf("encountered error opening csv file: %v", err)
+		return
+	}
+	defer f.Close()
+
+	_, err = NewAppendWriter(f, &appendWriterTest{}, WriterOptions{})
+	if !errors.Is(err, ErrorFieldNotFound) {
+		t.Errorf("expected to encounter Field Not Found error, but got %v", err)
+	}
+}
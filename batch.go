@@ -0,0 +1,62 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+var (
+	ErrorNotSlicePointer = fmt.Errorf("batch must be a pointer to a slice of structs or struct pointers")
+)
+
+// ReadBatch reads up to n records into the slice that slicePointer points to, which should be a []T or []*T where *T has csv decorator tags applied.
+// The slice is truncated before reading, so its capacity is reused from one call to the next, and it holds only the records of this batch.
+// It returns the number of records read. At the end of the file, a short batch is returned without error, and the following call returns io.EOF.
+// If a record can't be read, the records before it are kept in the slice and the error is returned.
+func (p *Parser) ReadBatch(slicePointer interface{}, n int) (read int, err error) {
+	if n <= 0 {
+		return 0, ErrorInvalidBatchSize
+	}
+
+	sliceValue := reflect.ValueOf(slicePointer)
+	if sliceValue.Kind() != reflect.Pointer || sliceValue.Elem().Kind() != reflect.Slice {
+		return 0, ErrorNotSlicePointer
+	}
+	slice := sliceValue.Elem()
+
+	elemType := slice.Type().Elem()
+	isPointer := elemType.Kind() == reflect.Pointer
+	structType := elemType
+	if isPointer {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return 0, ErrorNotSlicePointer
+	}
+
+	slice.SetLen(0)
+	for read < n {
+		var record reflect.Value
+		if isPointer {
+			record = reflect.New(structType)
+			slice.Set(reflect.Append(slice, record))
+		} else {
+			slice.Set(reflect.Append(slice, reflect.Zero(structType)))
+			record = slice.Index(read).Addr()
+		}
+
+		err = p.ReadRecord(record.Interface())
+		if err != nil {
+			slice.SetLen(read)
+			if err == io.EOF && read > 0 {
+				return read, nil
+			}
+			return read, err
+		}
+
+		read++
+	}
+
+	return read, nil
+}
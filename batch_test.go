@@ -0,0 +1,67 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReadBatch(t *testing.T) {
+	p := NewParser(strings.NewReader("a,1\nb,2\nc,3\nd,4\ne,5"), ParserOptions{})
+
+	var batch []peekTest
+	expected := []int{2, 2, 1}
+	for _, want := range expected {
+		read, err := p.ReadBatch(&batch, 2)
+		if err != nil {
+			t.Errorf("encountered error reading batch: %v", err)
+		}
+		if read != want || len(batch) != want {
+			t.Errorf("improperly sized batch. Read %d records into %d elements, but expected %d", read, len(batch), want)
+		}
+	}
+	if batch[0].Section != "e" || batch[0].Count != 5 {
+		t.Errorf("improperly read final batch. Got '%+v'", batch)
+	}
+
+	read, err := p.ReadBatch(&batch, 2)
+	if err != io.EOF || read != 0 || len(batch) != 0 {
+		t.Errorf("expected to reach the end of the file with an empty batch, but read %d and got %v", read, err)
+	}
+}
+
+func TestReadBatchPointers(t *testing.T) {
+	p := NewParser(strings.NewReader("a,1\nb,x\nc,3"), ParserOptions{})
+
+	var batch []*peekTest
+	read, err := p.ReadBatch(&batch, 3)
+
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 2 {
+		t.Errorf("expected a SetValueError on line 2, but got %v", err)
+	}
+	if read != 1 || len(batch) != 1 || batch[0].Section != "a" {
+		t.Errorf("expected the records before the error to be kept, but read %d into '%v'", read, batch)
+	}
+}
+
+func TestReadBatchArgumentErrors(t *testing.T) {
+	p := NewParser(strings.NewReader("a,1"), ParserOptions{})
+
+	var batch []peekTest
+	_, err := p.ReadBatch(batch, 1)
+	if !errors.Is(err, ErrorNotSlicePointer) {
+		t.Errorf("expected to encounter Not Slice Pointer error, but got %v", err)
+	}
+
+	_, err = p.ReadBatch(&[]string{}, 1)
+	if !errors.Is(err, ErrorNotSlicePointer) {
+		t.Errorf("expected to encounter Not Slice Pointer error, but got %v", err)
+	}
+
+	_, err = p.ReadBatch(&batch, 0)
+	if !errors.Is(err, ErrorInvalidBatchSize) {
+		t.Errorf("expected to encounter Invalid Batch Size error, but got %v", err)
+	}
+}
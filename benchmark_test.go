@@ -0,0 +1,235 @@
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type benchmarkNarrow struct {
+	ID     int     `csv:"index:0"`
+	Name   string  `csv:"index:1"`
+	Price  float64 `csv:"index:2"`
+	Active bool    `csv:"index:3"`
+}
+
+type benchmarkWide struct {
+	C00 int     `csv:"index:0"`
+	C01 int     `csv:"index:1"`
+	C02 int     `csv:"index:2"`
+	C03 int     `csv:"index:3"`
+	C04 int     `csv:"index:4"`
+	C05 float64 `csv:"index:5"`
+	C06 float64 `csv:"index:6"`
+	C07 float64 `csv:"index:7"`
+	C08 float64 `csv:"index:8"`
+	C09 float64 `csv:"index:9"`
+	C10 string  `csv:"index:10"`
+	C11 string  `csv:"index:11"`
+	C12 string  `csv:"index:12"`
+	C13 string  `csv:"index:13"`
+	C14 string  `csv:"index:14"`
+	C15 uint    `csv:"index:15"`
+	C16 uint    `csv:"index:16"`
+	C17 uint    `csv:"index:17"`
+	C18 uint    `csv:"index:18"`
+	C19 uint    `csv:"index:19"`
+	C20 bool    `csv:"index:20"`
+	C21 bool    `csv:"index:21"`
+	C22 bool    `csv:"index:22"`
+	C23 bool    `csv:"index:23"`
+	C24 bool    `csv:"index:24"`
+}
+
+type benchmarkCustom struct {
+	ID   int    `csv:"index:0"`
+	Name string `csv:"index:1;useCustomSetter"`
+}
+
+func (bc *benchmarkCustom) CustomSetter(fieldName string, value string) (err error) {
+	bc.Name = value
+	return nil
+}
+
+func benchmarkNarrowData(rows int) string {
+	var data strings.Builder
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&data, "%d,name %d,%d.25,%t\n", i, i, i, i%2 == 0)
+	}
+	return data.String()
+}
+
+func benchmarkWideData(rows int) string {
+	var data strings.Builder
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&data, "%d,%d,%d,%d,%d,", i, i, i, i, i)
+		fmt.Fprintf(&data, "%d.5,%d.5,%d.5,%d.5,%d.5,", i, i, i, i, i)
+		fmt.Fprintf(&data, "a%d,b%d,c%d,d%d,e%d,", i, i, i, i, i)
+		fmt.Fprintf(&data, "%d,%d,%d,%d,%d,", i, i, i, i, i)
+		data.WriteString("true,false,true,false,true\n")
+	}
+	return data.String()
+}
+
+// benchmarkRecords reads every record of data into the struct made by newRecord, reporting the time and allocations per record.
+func benchmarkRecords(b *testing.B, data string, rows int, options ParserOptions, newRecord func() interface{}) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	record := newRecord()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		p := NewParser(strings.NewReader(data), options)
+		for {
+			err := p.ReadRecord(record)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Errorf("encountered error reading csv record: %v", err)
+				return
+			}
+		}
+	}
+
+	b.ReportMetric(float64(time.Since(start).Nanoseconds())/float64(b.N*rows), "ns/record")
+}
+
+func BenchmarkReadRecordNarrow(b *testing.B) {
+	data := benchmarkNarrowData(1000)
+	benchmarkRecords(b, data, 1000, ParserOptions{}, func() interface{} { return &benchmarkNarrow{} })
+}
+
+func BenchmarkReadRecordNarrowReuseRecord(b *testing.B) {
+	data := benchmarkNarrowData(1000)
+	benchmarkRecords(b, data, 1000, ParserOptions{ReuseRecord: true}, func() interface{} { return &benchmarkNarrow{} })
+}
+
+func BenchmarkReadRecordWide(b *testing.B) {
+	data := benchmarkWideData(1000)
+	benchmarkRecords(b, data, 1000, ParserOptions{}, func() interface{} { return &benchmarkWide{} })
+}
+
+func BenchmarkReadRecordWideReuseRecord(b *testing.B) {
+	data := benchmarkWideData(1000)
+	benchmarkRecords(b, data, 1000, ParserOptions{ReuseRecord: true}, func() interface{} { return &benchmarkWide{} })
+}
+
+func BenchmarkReadRecordManyRows(b *testing.B) {
+	data := benchmarkNarrowData(100000)
+	benchmarkRecords(b, data, 100000, ParserOptions{ReuseRecord: true}, func() interface{} { return &benchmarkNarrow{} })
+}
+
+func BenchmarkReadRecordCustomSetter(b *testing.B) {
+	data := benchmarkNarrowData(1000)
+	benchmarkRecords(b, data, 1000, ParserOptions{ReuseRecord: true}, func() interface{} { return &benchmarkCustom{} })
+}
+
+// BenchmarkManualNarrow reads the same data as BenchmarkReadRecordNarrowReuseRecord with encoding/csv and hand written conversions, as a baseline.
+func BenchmarkManualNarrow(b *testing.B) {
+	data := benchmarkNarrowData(1000)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	var record benchmarkNarrow
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		r := csv.NewReader(strings.NewReader(data))
+		r.ReuseRecord = true
+		for {
+			fields, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Errorf("encountered error reading csv record: %v", err)
+				return
+			}
+
+			record.ID, err = strconv.Atoi(fields[0])
+			if err == nil {
+				record.Name = fields[1]
+				record.Price, err = strconv.ParseFloat(fields[2], 64)
+			}
+			if err == nil {
+				record.Active, err = strconv.ParseBool(fields[3])
+			}
+			if err != nil {
+				b.Errorf("encountered error converting csv record: %v", err)
+				return
+			}
+		}
+	}
+
+	b.ReportMetric(float64(time.Since(start).Nanoseconds())/float64(b.N*1000), "ns/record")
+}
+
+func TestReadRecordAllocations(t *testing.T) {
+	p := NewParser(strings.NewReader(benchmarkWideData(200)), ParserOptions{ReuseRecord: true})
+	var record benchmarkWide
+
+	allocs := testing.AllocsPerRun(100, func() {
+		err := p.ReadRecord(&record)
+		if err != nil {
+			t.Errorf("encountered error reading csv record: %v", err)
+		}
+	})
+
+	// encoding/csv makes one allocation per record for the string backing its values, and ReadRecord should add none of its own
+	if allocs > 1 {
+		t.Errorf("expected at most 1 allocation per record, but got %v", allocs)
+	}
+}
+
+func TestCloneStringsAllocations(t *testing.T) {
+	p := NewParser(strings.NewReader(benchmarkNarrowData(200)), ParserOptions{ReuseRecord: true, CloneStrings: true})
+	var record benchmarkNarrow
+
+	allocs := testing.AllocsPerRun(100, func() {
+		err := p.ReadRecord(&record)
+		if err != nil {
+			t.Errorf("encountered error reading csv record: %v", err)
+		}
+	})
+
+	// benchmarkNarrow has one string field, so only it should be copied
+	if allocs > 2 {
+		t.Errorf("expected at most 2 allocations per record, but got %v", allocs)
+	}
+}
+
+func BenchmarkReadRecordWideUnsafeFastPath(b *testing.B) {
+	data := benchmarkWideData(1000)
+	benchmarkRecords(b, data, 1000, ParserOptions{ReuseRecord: true, UnsafeFastPath: true}, func() interface{} { return &benchmarkWide{} })
+}
+
+// BenchmarkSmallFilesNewParser and BenchmarkSmallFilesParserPool parse many small files, as a service handling uploads would.
+func BenchmarkSmallFilesNewParser(b *testing.B) {
+	data := benchmarkNarrowData(10)
+	b.ReportAllocs()
+
+	var record benchmarkNarrow
+	for i := 0; i < b.N; i++ {
+		p := NewParser(strings.NewReader(data), ParserOptions{ReuseRecord: true})
+		for p.ReadRecord(&record) == nil {
+		}
+	}
+}
+
+func BenchmarkSmallFilesParserPool(b *testing.B) {
+	data := benchmarkNarrowData(10)
+	pool := NewParserPool(ParserOptions{ReuseRecord: true})
+	b.ReportAllocs()
+
+	var record benchmarkNarrow
+	for i := 0; i < b.N; i++ {
+		p := pool.AcquireParser(strings.NewReader(data))
+		for p.ReadRecord(&record) == nil {
+		}
+		pool.ReleaseParser(p)
+	}
+}
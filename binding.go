@@ -0,0 +1,93 @@
+package csv
+
+import (
+	"reflect"
+)
+
+// Binding is the result of reading the csv tags of a struct type for a parser, as returned by Parser.BindHeader. It holds no header, so it can be passed to the
+// ParseHeader or BindHeader of other parsers created with the same options to bind the same type without reading its tags again. A Binding is safe for concurrent use.
+type Binding struct {
+	structType  reflect.Type
+	csvAttrs    map[string]csvAttributes
+	fieldOrder  []string
+	useDecoder  bool
+	needsHeader bool
+}
+
+// Type returns the struct type the binding was made for.
+func (b *Binding) Type() reflect.Type {
+	return b.structType.Elem()
+}
+
+// Fields returns the names of the bound fields, in the order they are defined on the struct.
+func (b *Binding) Fields() (fieldNames []string) {
+	return append(fieldNames, b.fieldOrder...)
+}
+
+// structPointerFor returns a pointer to a new value of the struct type described by structType, which may be a struct, a pointer to a struct, even a nil one,
+// or the reflect.Type of either. It returns an ArgumentError for anything else.
+func structPointerFor(structType interface{}) (structPointer interface{}, err error) {
+	t, isType := structType.(reflect.Type)
+	if !isType {
+		t = reflect.TypeOf(structType)
+	}
+
+	received := "nil"
+	if t != nil {
+		received = t.String()
+	}
+
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ArgumentError{
+			Received: received,
+			Err:      ErrorInvalidArgument,
+		}
+	}
+
+	return reflect.New(t).Interface(), nil
+}
+
+// keepBinding keeps a copy of the binding p has just made for the type of structPointer, before any header is resolved.
+func (p *Parser) keepBinding(structPointer interface{}) {
+	p.binding = &Binding{
+		structType:  reflect.TypeOf(structPointer),
+		csvAttrs:    make(map[string]csvAttributes, len(p.csvAttrs)),
+		fieldOrder:  p.fieldOrder,
+		useDecoder:  p.useDecoder,
+		needsHeader: p.needsHeader,
+	}
+	for fieldName, attrs := range p.csvAttrs {
+		p.binding.csvAttrs[fieldName] = attrs
+	}
+}
+
+// restoreBinding copies binding to p, so that p only reads into the type it was made for.
+func (p *Parser) restoreBinding(binding *Binding) {
+	if p.csvAttrs == nil {
+		p.csvAttrs = make(map[string]csvAttributes, len(binding.csvAttrs))
+	}
+	for fieldName, attrs := range binding.csvAttrs {
+		p.csvAttrs[fieldName] = attrs
+	}
+	p.fieldOrder = binding.fieldOrder
+	p.useDecoder = binding.useDecoder
+	p.needsHeader = binding.needsHeader
+	p.binding = binding
+	p.boundType = binding.structType
+}
+
+// BindHeader reads the header as ParseHeader does, and returns the binding of structType, so that other parsers can bind the same type without reading its tags again.
+// The binding returned is the one the parser was already bound with if it has read records before, and is nil if that was done by a FixedWidthParser.
+// Parsers bound with a binding reject pointers to any other type than the binding's with ErrorBoundTypeMismatch, as they would if they had read its tags themselves.
+func (p *Parser) BindHeader(structType interface{}) (binding *Binding, err error) {
+	err = p.ParseHeader(structType)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.binding, nil
+}
@@ -0,0 +1,152 @@
+package csv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseHeaderStructType(t *testing.T) {
+	for _, structType := range []interface{}{
+		headerTest{},
+		(*headerTest)(nil),
+		&headerTest{},
+		reflect.TypeOf(headerTest{}),
+		reflect.TypeOf(&headerTest{}),
+	} {
+		p := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+
+		err := p.ParseHeader(structType)
+		if err != nil {
+			t.Fatalf("encountered error parsing csv header with %T: %v", structType, err)
+		}
+
+		var record headerTest
+		err = p.ReadRecord(&record)
+		if err != nil {
+			t.Fatalf("encountered error reading csv record: %v", err)
+		}
+
+		expected := headerTest{Field1: "String", Field2: 12, Field3: 123456}
+		if record != expected {
+			t.Errorf("improperly read record after parsing the header with %T. Got %+v but expected %+v", structType, record, expected)
+		}
+	}
+}
+
+func TestParseHeaderInvalidType(t *testing.T) {
+	for _, arg := range []struct {
+		structType interface{}
+		received   string
+	}{
+		{structType: reflect.TypeOf(0), received: "int"},
+		{structType: reflect.TypeOf(new(int)), received: "*int"},
+		{structType: reflect.TypeOf([]string{}), received: "[]string"},
+		{structType: (*Binding)(nil), received: "nil *csv.Binding"},
+		{structType: []headerTest{}, received: "[]csv.headerTest"},
+		{structType: "header:field1", received: "string"},
+	} {
+		p := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+
+		err := p.ParseHeader(arg.structType)
+		var argumentErr ArgumentError
+		if !errors.As(err, &argumentErr) || argumentErr.Received != arg.received || !errors.Is(err, ErrorInvalidArgument) {
+			t.Errorf("expected an ArgumentError for %s but got %v", arg.received, err)
+		}
+	}
+}
+
+func TestBindHeader(t *testing.T) {
+	p := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+
+	binding, err := p.BindHeader(headerTest{})
+	if err != nil {
+		t.Fatalf("encountered error binding csv header: %v", err)
+	}
+
+	if binding.Type() != reflect.TypeOf(headerTest{}) {
+		t.Errorf("expected binding for headerTest but got %v", binding.Type())
+	}
+	expectedFields := []string{"Field1", "Field2", "Field3"}
+	if !reflect.DeepEqual(binding.Fields(), expectedFields) {
+		t.Errorf("improperly bound fields. Got %v but expected %v", binding.Fields(), expectedFields)
+	}
+
+	// Another file with the columns in a different order is bound from the binding, with the header of its own.
+	other := NewParser(strings.NewReader("Field3,fieldTwo,field1\n7,8,nine\n"), ParserOptions{})
+	err = other.ParseHeader(binding)
+	if err != nil {
+		t.Fatalf("encountered error parsing csv header with a binding: %v", err)
+	}
+
+	var record headerTest
+	err = other.ReadRecord(&record)
+	if err != nil {
+		t.Fatalf("encountered error reading csv record: %v", err)
+	}
+
+	expected := headerTest{Field1: "nine", Field2: 8, Field3: 7}
+	if record != expected {
+		t.Errorf("improperly read record with a binding. Got %+v but expected %+v", record, expected)
+	}
+
+	// The first parser still reads its own columns.
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Fatalf("encountered error reading csv record: %v", err)
+	}
+
+	expected = headerTest{Field1: "String", Field2: 12, Field3: 123456}
+	if record != expected {
+		t.Errorf("improperly read record after sharing its binding. Got %+v but expected %+v", record, expected)
+	}
+
+	missing := NewParser(strings.NewReader("field1\nvalue\n"), ParserOptions{})
+	_, err = missing.BindHeader(binding)
+	if !errors.Is(err, ErrorFieldNotFound) {
+		t.Errorf("expected ErrorFieldNotFound binding a header without the bound columns but got %v", err)
+	}
+}
+
+func TestBindingOtherStructType(t *testing.T) {
+	p := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+
+	binding, err := p.BindHeader(headerTest{})
+	if err != nil {
+		t.Fatalf("encountered error binding csv header: %v", err)
+	}
+
+	other := NewParser(strings.NewReader("Field3,fieldTwo,field1\n7,8,nine\n"), ParserOptions{UnsafeFastPath: true})
+	err = other.ParseHeader(binding)
+	if err != nil {
+		t.Fatalf("encountered error parsing csv header with a binding: %v", err)
+	}
+
+	var wrongType unsafeOtherTypeTest
+	err = other.ReadRecord(&wrongType)
+	if !errors.Is(err, ErrorBoundTypeMismatch) {
+		t.Errorf("expected ErrorBoundTypeMismatch reading into another type than the binding's but got %v", err)
+	}
+	if wrongType.P != nil || wrongType.Q != 0 {
+		t.Errorf("expected a struct of another type to be left unchanged, but got %+v", wrongType)
+	}
+
+	var record headerTest
+	err = other.ReadRecord(&record)
+	if err != nil {
+		t.Fatalf("encountered error reading csv record: %v", err)
+	}
+
+	// A parser already bound to another type doesn't accept the binding either.
+	bound := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+	err = bound.ParseHeader(unsafeBoundTest{})
+	if err != nil {
+		t.Fatalf("encountered error parsing csv header: %v", err)
+	}
+
+	_, err = bound.BindHeader(binding)
+	if !errors.Is(err, ErrorBoundTypeMismatch) {
+		t.Errorf("expected ErrorBoundTypeMismatch binding a parser bound to another type but got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorCheckpointHeader = fmt.Errorf("checkpoint has no header to resume from")
+)
+
+// Checkpoint records how far a parser has read through its file, so that a later run can resume from the same record with ResumeParser.
+// It can be persisted as JSON between runs.
+type Checkpoint struct {
+	// Line is the number of records read so far.
+	Line int `json:"line"`
+	// ByteOffset is the offset of the start of the next record in the file.
+	ByteOffset int64 `json:"byteOffset"`
+	// HasHeader is set when the parser had parsed a header, so that it is parsed again on resume.
+	HasHeader bool `json:"hasHeader"`
+	// Header holds the parsed header, so that ResumeParserFrom can resume a stream that doesn't include it.
+	Header []string `json:"header,omitempty"`
+}
+
+// inputOffsetReader is implemented by record readers that can report how many bytes of input they have consumed, such as *csv.Reader.
+type inputOffsetReader interface {
+	InputOffset() int64
+}
+
+// Checkpoint returns the parser's current position. Take a checkpoint after a record has been processed successfully, and save it wherever the import's progress is tracked.
+// It returns ErrorNotSeekable if the parser's reader can't report its position, which is the case for parsers created with NewRecordParser unless the reader implements InputOffset() int64.
+func (p *Parser) Checkpoint() (checkpoint Checkpoint, err error) {
+	offset, err := p.ByteOffset()
+	if err != nil {
+		return checkpoint, err
+	}
+
+	return Checkpoint{
+		Line:       p.line,
+		ByteOffset: offset,
+		HasHeader:  p.header != nil,
+		Header:     p.header,
+	}, nil
+}
+
+// ByteOffset returns the offset in the parser's file of the start of the next record, counting from the start of the file even for a parser created with ResumeParserFrom.
+// It returns ErrorNotSeekable if the parser's reader can't report its position, as described by Checkpoint.
+func (p *Parser) ByteOffset() (offset int64, err error) {
+	offset, ok := p.readerOffset()
+	if !ok {
+		return 0, ErrorNotSeekable
+	}
+
+	if p.hasPeeked {
+		offset = p.peekOffset
+	}
+	if p.recordPeek != nil {
+		offset = p.recordPeek.offset
+	}
+
+	return p.offset + offset, nil
+}
+
+// ResumeParser creates a new csv parser for file that continues from checkpoint. If a header had been parsed when the checkpoint was taken,
+// it is read again from the start of file, and header bindings are resolved against it the first time ReadRecord is called, so ParseHeader should not be called.
+// Line numbers continue from those of the checkpoint.
+func ResumeParser(file io.ReadSeeker, checkpoint Checkpoint, options ParserOptions) (p Parser, err error) {
+	p = NewParser(file, options)
+
+	if checkpoint.HasHeader {
+		_, err = file.Seek(0, io.SeekStart)
+		if err != nil {
+			return p, err
+		}
+
+		_, err = p.readHeader()
+		if err != nil {
+			return p, err
+		}
+	}
+
+	err = p.SeekToOffset(checkpoint.ByteOffset)
+	if err != nil {
+		return p, err
+	}
+
+	p.line = checkpoint.Line
+
+	return p, nil
+}
+
+// ResumeParserFrom creates a new csv parser that continues from checkpoint, for a file that has been reopened at the checkpoint's ByteOffset,
+// such as an object storage download requested with a byte range. Unlike ResumeParser, it needs no seeking, so it suits sources that can only be read forward.
+// The header is taken from the checkpoint rather than read again, and bindings are resolved against it the first time ReadRecord is called, so ParseHeader should not be called.
+// It returns ErrorCheckpointHeader for a checkpoint that had a header but doesn't hold it, such as one saved before headers were recorded. Line numbers and byte offsets continue from those of the checkpoint.
+func ResumeParserFrom(file io.Reader, checkpoint Checkpoint, options ParserOptions) (p Parser, err error) {
+	p = NewParser(file, options)
+
+	if checkpoint.HasHeader {
+		if checkpoint.Header == nil {
+			return p, ErrorCheckpointHeader
+		}
+
+		p.header = make([]string, len(checkpoint.Header))
+		copy(p.header, checkpoint.Header)
+	}
+
+	p.offset = checkpoint.ByteOffset
+	p.line = checkpoint.Line
+
+	return p, nil
+}
@@ -0,0 +1,139 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	data := "field1,fieldTwo,Field3\n\"a\nb\",1,2\nc,3,4\nd,5,x\n"
+
+	p := NewParser(strings.NewReader(data), ParserOptions{})
+	err := p.ParseHeader(&headerTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	var record headerTest
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading csv record: %v", err)
+	}
+
+	checkpoint, err := p.Checkpoint()
+	if err != nil {
+		t.Errorf("encountered error taking checkpoint: %v", err)
+	}
+	if checkpoint.Line != 1 || checkpoint.ByteOffset != int64(strings.Index(data, "c,")) || !checkpoint.HasHeader {
+		t.Errorf("improper checkpoint. Got '%+v'", checkpoint)
+	}
+
+	resumed, err := ResumeParser(strings.NewReader(data), checkpoint, ParserOptions{})
+	if err != nil {
+		t.Errorf("encountered error resuming parser: %v", err)
+	}
+
+	err = resumed.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading csv record after resuming: %v", err)
+	}
+	if record.Field1 != "c" || record.Field2 != 3 || record.Field3 != 4 {
+		t.Errorf("improperly read record after resuming. Got '%+v'", record)
+	}
+
+	err = resumed.ReadRecord(&record)
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 3 {
+		t.Errorf("expected a SetValueError on line 3, but got %v", err)
+	}
+
+	err = resumed.ReadRecord(&record)
+	if err != io.EOF {
+		t.Errorf("expected to reach the end of the file, but got %v", err)
+	}
+}
+
+func TestCheckpointNotSeekableError(t *testing.T) {
+	p := NewRecordParser(&multiCharReader{}, ParserOptions{})
+
+	_, err := p.Checkpoint()
+	if !errors.Is(err, ErrorNotSeekable) {
+		t.Errorf("expected to encounter Not Seekable error, but got %v", err)
+	}
+}
+
+// interruptedReader returns an error once the first n bytes of its reader have been read, like a download whose connection drops.
+type interruptedReader struct {
+	reader io.Reader
+	n      int
+}
+
+func (r *interruptedReader) Read(b []byte) (n int, err error) {
+	if r.n == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if len(b) > r.n {
+		b = b[:r.n]
+	}
+
+	n, err = r.reader.Read(b)
+	r.n -= n
+
+	return n, err
+}
+
+func TestResumeParserFrom(t *testing.T) {
+	data := "field1,fieldTwo,Field3\n\"a\nb\",1,2\nc,3,4\nd,5,6\n"
+
+	p := NewParser(&interruptedReader{reader: strings.NewReader(data), n: strings.Index(data, "d,") + 2}, ParserOptions{})
+	err := p.ParseHeader(&headerTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	var record headerTest
+	var checkpoint Checkpoint
+	for {
+		err = p.ReadRecord(&record)
+		if err != nil {
+			break
+		}
+
+		checkpoint, err = p.Checkpoint()
+		if err != nil {
+			t.Errorf("encountered error taking checkpoint: %v", err)
+		}
+	}
+
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected the download to be interrupted, but got %v", err)
+	}
+	if checkpoint.Line != 2 || checkpoint.ByteOffset != int64(strings.Index(data, "d,")) {
+		t.Errorf("improper checkpoint. Got '%+v'", checkpoint)
+	}
+
+	resumed, err := ResumeParserFrom(strings.NewReader(data[checkpoint.ByteOffset:]), checkpoint, ParserOptions{})
+	if err != nil {
+		t.Errorf("encountered error resuming parser: %v", err)
+	}
+
+	err = resumed.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading csv record after resuming: %v", err)
+	}
+	if record.Field1 != "d" || record.Field2 != 5 || record.Field3 != 6 || resumed.Line() != 3 {
+		t.Errorf("improperly read record after resuming. Got '%+v' on line %d", record, resumed.Line())
+	}
+
+	offset, err := resumed.ByteOffset()
+	if err != nil || offset != int64(len(data)) {
+		t.Errorf("expected byte offset %d after resuming, but got %d and error %v", len(data), offset, err)
+	}
+
+	_, err = ResumeParserFrom(strings.NewReader(""), Checkpoint{HasHeader: true}, ParserOptions{})
+	if !errors.Is(err, ErrorCheckpointHeader) {
+		t.Errorf("expected to encounter ErrorCheckpointHeader error, but got %v", err)
+	}
+}
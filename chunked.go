@@ -0,0 +1,320 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorInvalidChunkSize = fmt.Errorf("chunk sizes must not be negative")
+)
+
+// ChunkOptions sets when a ChunkedWriter rolls over to a new part. A part is rolled over before the record that would take it over either limit,
+// so every part holds at least one record. When both limits are zero, every record is written to one part.
+type ChunkOptions struct {
+	// MaxRecords is the most records written to each part, not counting the header.
+	MaxRecords int
+	// MaxBytes is the most bytes of csv data written to each part, including the header. A single record larger than MaxBytes is written to a part of its own.
+	// When the output is compressed, the limit applies to the csv data before it is compressed.
+	MaxBytes int64
+}
+
+// ChunkedWriter writes structs with csv decorator tags as csv records across a series of parts, rolling over to a new part every so many records or bytes,
+// as feeds with limits on the size of each file need. The header is repeated at the start of every part. A ChunkedWriter is not safe for concurrent use.
+type ChunkedWriter struct {
+	writer  Writer
+	pending bytes.Buffer
+	header  []byte
+	create  func(part int) (io.WriteCloser, error)
+	chunk   ChunkOptions
+	options WriterOptions
+	current *partFile
+	part    int
+	closed  bool
+	err     error
+}
+
+// NewChunkedWriter creates a csv writer that writes to the parts created by create, which is called with the number of each part, counting from 1, as it is needed.
+// Each part is closed once it is full, and the last part is closed by Close. Any compression set in options is applied to each part separately.
+func NewChunkedWriter(create func(part int) (io.WriteCloser, error), chunk ChunkOptions, options WriterOptions) (w *ChunkedWriter) {
+	w = &ChunkedWriter{
+		create:  create,
+		chunk:   chunk,
+		options: options,
+	}
+
+	w.err = options.Validate()
+	if w.err == nil && len(options.SortBy) > 0 {
+		w.err = OptionError{
+			Option: "SortBy",
+			Err:    ErrorSortNotSupported,
+		}
+	}
+	if w.err == nil && chunk.MaxRecords < 0 {
+		w.err = OptionError{
+			Option: "MaxRecords",
+			Err:    ErrorInvalidChunkSize,
+		}
+	}
+	if w.err == nil && chunk.MaxBytes < 0 {
+		w.err = OptionError{
+			Option: "MaxBytes",
+			Err:    ErrorInvalidChunkSize,
+		}
+	}
+
+	w.writer = NewWriter(&w.pending, renderOptions(options))
+
+	return w
+}
+
+// renderOptions returns options for a Writer that renders records into a buffer, to be copied to the parts of a writer that compresses each part itself.
+func renderOptions(options WriterOptions) WriterOptions {
+	options.Compression = CompressionNone
+	options.CompressionLevel = 0
+
+	return options
+}
+
+// WriteHeader sets the header written at the start of every part to the header names described by the csv decorator tags defined on structPointer, as written by Writer.WriteHeader.
+// It should be called before any records are written.
+func (w *ChunkedWriter) WriteHeader(structPointer interface{}) (err error) {
+	err = w.check()
+	if err != nil {
+		return err
+	}
+
+	err = w.writer.WriteHeader(structPointer)
+	if err != nil {
+		return err
+	}
+
+	return w.setHeader()
+}
+
+// WriteHeaderMap sets the header written at the start of every part to the Columns listed in the writer options, as written by Writer.WriteHeaderMap.
+func (w *ChunkedWriter) WriteHeaderMap() (err error) {
+	err = w.check()
+	if err != nil {
+		return err
+	}
+
+	err = w.writer.WriteHeaderMap()
+	if err != nil {
+		return err
+	}
+
+	return w.setHeader()
+}
+
+// WriteRecord writes the fields of structPointer to the current part as described by Writer.WriteRecord, rolling over to a new part first if the current one is full.
+func (w *ChunkedWriter) WriteRecord(structPointer interface{}) (err error) {
+	err = w.check()
+	if err != nil {
+		return err
+	}
+
+	err = w.writer.WriteRecord(structPointer)
+	if err != nil {
+		w.pending.Reset()
+		return err
+	}
+
+	return w.commit()
+}
+
+// WriteRecordMap writes the values of record to the current part as described by Writer.WriteRecordMap, rolling over to a new part first if the current one is full.
+func (w *ChunkedWriter) WriteRecordMap(record map[string]string) (err error) {
+	err = w.check()
+	if err != nil {
+		return err
+	}
+
+	err = w.writer.WriteRecordMap(record)
+	if err != nil {
+		w.pending.Reset()
+		return err
+	}
+
+	return w.commit()
+}
+
+// Parts returns the number of parts created so far.
+func (w *ChunkedWriter) Parts() int {
+	return w.part
+}
+
+// Flush writes any buffered data to the current part, and reports any error that occurred during a previous write or flush.
+func (w *ChunkedWriter) Flush() (err error) {
+	if w.err != nil || w.current == nil {
+		return w.err
+	}
+
+	w.err = w.current.flush()
+
+	return w.err
+}
+
+// Close finishes and closes the last part. If a header was set but no records were written, a part holding only the header is created, so that an empty feed still has a file.
+// Later writes return ErrorWriterClosed.
+func (w *ChunkedWriter) Close() (err error) {
+	if w.err != nil || w.closed {
+		return w.err
+	}
+	w.closed = true
+
+	if w.part == 0 && len(w.header) > 0 {
+		err = w.roll()
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.current != nil {
+		err = w.current.close()
+		w.current = nil
+	}
+
+	return err
+}
+
+// check returns the error every write should return, if the writer has failed or is closed.
+func (w *ChunkedWriter) check() (err error) {
+	if w.err != nil {
+		return w.err
+	}
+
+	if w.closed {
+		return ErrorWriterClosed
+	}
+
+	return nil
+}
+
+// setHeader keeps the header rendered by the last write as the header of every part.
+func (w *ChunkedWriter) setHeader() (err error) {
+	err = w.writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	w.header = append([]byte(nil), w.pending.Bytes()...)
+	w.pending.Reset()
+
+	return nil
+}
+
+// commit copies the record rendered by the last write to the current part, rolling over to a new part first if the record doesn't fit.
+func (w *ChunkedWriter) commit() (err error) {
+	err = w.writer.Flush()
+	if err != nil {
+		return err
+	}
+	defer w.pending.Reset()
+
+	full := w.current == nil ||
+		(w.chunk.MaxRecords > 0 && w.current.records >= w.chunk.MaxRecords) ||
+		(w.chunk.MaxBytes > 0 && w.current.records > 0 && w.current.size+int64(w.pending.Len()) > w.chunk.MaxBytes)
+	if full {
+		err = w.roll()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = w.current.writeRecord(w.pending.Bytes())
+	if err != nil {
+		w.err = err
+	}
+
+	return err
+}
+
+// roll closes the current part and creates the next one, starting it with the header.
+func (w *ChunkedWriter) roll() (err error) {
+	if w.current != nil {
+		err = w.current.close()
+		w.current = nil
+		if err != nil {
+			w.err = err
+			return err
+		}
+	}
+
+	w.part++
+	file, err := w.create(w.part)
+	if err == nil {
+		w.current, err = openPart(file, w.header, w.options)
+	}
+	if err != nil {
+		w.err = err
+	}
+
+	return err
+}
+
+// partFile is one of the files written by a writer that spreads its records across several files.
+type partFile struct {
+	file       io.WriteCloser
+	out        *bufio.Writer
+	compressor compressor
+	records    int
+	size       int64
+}
+
+// openPart starts writing to file, beginning with header. The file is compressed as described by options.
+func openPart(file io.WriteCloser, header []byte, options WriterOptions) (p *partFile, err error) {
+	p = &partFile{file: file}
+
+	var out io.Writer = file
+	p.compressor = newCompressor(file, options)
+	if p.compressor != nil {
+		out = p.compressor
+	}
+	p.out = bufio.NewWriter(out)
+
+	_, err = p.out.Write(header)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	p.size = int64(len(header))
+
+	return p, nil
+}
+
+// writeRecord writes a rendered record to the part.
+func (p *partFile) writeRecord(record []byte) (err error) {
+	_, err = p.out.Write(record)
+	p.records++
+	p.size += int64(len(record))
+
+	return err
+}
+
+// flush writes any buffered data to the part's file.
+func (p *partFile) flush() (err error) {
+	err = p.out.Flush()
+	if err != nil || p.compressor == nil {
+		return err
+	}
+
+	return p.compressor.Flush()
+}
+
+// close flushes any buffered data, finishes the compressed stream if there is one, and closes the part's file.
+func (p *partFile) close() (err error) {
+	err = p.out.Flush()
+	if err == nil && p.compressor != nil {
+		err = p.compressor.Close()
+	}
+
+	closeErr := p.file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -0,0 +1,148 @@
+package csv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+)
+
+// memoryParts collects the parts written by a ChunkedWriter.
+type memoryParts struct {
+	parts  []*memoryPart
+	closed int
+}
+
+type memoryPart struct {
+	bytes.Buffer
+	owner *memoryParts
+}
+
+func (p *memoryPart) Close() error {
+	p.owner.closed++
+	return nil
+}
+
+func (m *memoryParts) create(part int) (io.WriteCloser, error) {
+	p := &memoryPart{owner: m}
+	m.parts = append(m.parts, p)
+	return p, nil
+}
+
+func (m *memoryParts) strings() (parts []string) {
+	for _, p := range m.parts {
+		parts = append(parts, p.String())
+	}
+	return parts
+}
+
+func writeChunks(t *testing.T, w *ChunkedWriter, count int) {
+	t.Helper()
+
+	err := w.WriteHeader(&flushTest{})
+	if err != nil {
+		t.Fatalf("encountered error writing csv header: %v", err)
+	}
+
+	for i := 0; i < count; i++ {
+		err = w.WriteRecord(&flushTest{Name: "n", Count: i})
+		if err != nil {
+			t.Fatalf("encountered error writing csv record: %v", err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing chunked writer: %v", err)
+	}
+}
+
+func TestChunkedWriterRecords(t *testing.T) {
+	var parts memoryParts
+	w := NewChunkedWriter(parts.create, ChunkOptions{MaxRecords: 2}, WriterOptions{})
+	writeChunks(t, w, 5)
+
+	expected := []string{
+		"name,count\nn,0\nn,1\n",
+		"name,count\nn,2\nn,3\n",
+		"name,count\nn,4\n",
+	}
+	got := parts.strings()
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d parts, but got %d: %q", len(expected), len(got), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("improperly written part %d. Got '%s' but expected '%s'", i+1, got[i], expected[i])
+		}
+	}
+
+	if parts.closed != 3 || w.Parts() != 3 {
+		t.Errorf("expected every one of the 3 parts to be closed, but %d of %d were", parts.closed, w.Parts())
+	}
+}
+
+func TestChunkedWriterBytes(t *testing.T) {
+	var parts memoryParts
+	// The header is 11 bytes and each record 4, so two records fit in 20 bytes.
+	w := NewChunkedWriter(parts.create, ChunkOptions{MaxBytes: 20}, WriterOptions{})
+	writeChunks(t, w, 3)
+
+	for i, part := range parts.strings() {
+		if len(part) > 20 {
+			t.Errorf("expected part %d to hold at most 20 bytes, but got %d", i+1, len(part))
+		}
+	}
+
+	if len(parts.parts) != 2 {
+		t.Errorf("expected 2 parts, but got %q", parts.strings())
+	}
+}
+
+func TestChunkedWriterHeaderOnly(t *testing.T) {
+	var parts memoryParts
+	w := NewChunkedWriter(parts.create, ChunkOptions{MaxRecords: 2}, WriterOptions{})
+	writeChunks(t, w, 0)
+
+	got := parts.strings()
+	if len(got) != 1 || got[0] != "name,count\n" {
+		t.Errorf("expected a single part holding the header, but got %q", got)
+	}
+
+	err := w.WriteRecord(&flushTest{})
+	if !errors.Is(err, ErrorWriterClosed) {
+		t.Errorf("expected %v writing after close, but got %v", ErrorWriterClosed, err)
+	}
+}
+
+func TestChunkedWriterCompression(t *testing.T) {
+	var parts memoryParts
+	w := NewChunkedWriter(parts.create, ChunkOptions{MaxRecords: 1}, WriterOptions{Compression: CompressionGzip})
+	writeChunks(t, w, 2)
+
+	if len(parts.parts) != 2 {
+		t.Fatalf("expected 2 parts, but got %d", len(parts.parts))
+	}
+
+	reader, err := gzip.NewReader(&parts.parts[1].Buffer)
+	if err != nil {
+		t.Fatalf("expected each part to be a gzip stream, but got %v", err)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil || string(data) != "name,count\nn,1\n" {
+		t.Errorf("improperly compressed part. Got '%s' and %v", data, err)
+	}
+}
+
+func TestChunkedWriterOptionErrors(t *testing.T) {
+	var parts memoryParts
+	w := NewChunkedWriter(parts.create, ChunkOptions{MaxBytes: -1}, WriterOptions{})
+
+	var optionErr OptionError
+	err := w.WriteRecord(&flushTest{})
+	if !errors.As(err, &optionErr) || optionErr.Option != "MaxBytes" || !errors.Is(err, ErrorInvalidChunkSize) {
+		t.Errorf("expected %v for MaxBytes, but got %v", ErrorInvalidChunkSize, err)
+	}
+}
@@ -0,0 +1,109 @@
+// Command csvgen generates a go struct with csv tags from the header and inferred column types of a csv file.
+// With the -codecs flag, it instead reads a go file and generates reflection free DecodeCSVRecord and EncodeCSVRecord methods
+// for the comma separated list of struct types named by -type.
+//
+// It is intended to be used with go generate:
+//
+//	//go:generate csvgen -in feed.csv -type Feed -package feeds -out feed_csv.go
+//	//go:generate csvgen -codecs -in feed_csv.go -type Feed -out feed_codecs.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	csv "github.com/AidanJHMurphy/go-csv"
+)
+
+func main() {
+	in := flag.String("in", "", "csv file to read the header and sample records from")
+	out := flag.String("out", "", "go file to write the struct to (defaults to stdout)")
+	typeName := flag.String("type", "Record", "name of the generated struct")
+	packageName := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file")
+	sampleRows := flag.Int("sample", 100, "number of records to sample when inferring column types")
+	delimiter := flag.String("delimiter", ",", "field delimiter of the csv file")
+	codecs := flag.Bool("codecs", false, "generate codecs for the struct types in a go file instead of a struct from a csv file")
+	flag.Parse()
+
+	var err error
+	if *codecs {
+		err = runCodecs(*in, *out, *typeName)
+	} else {
+		err = run(*in, *out, *typeName, *packageName, *sampleRows, *delimiter)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "csvgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in string, out string, typeName string, packageName string, sampleRows int, delimiter string) (err error) {
+	if in == "" {
+		return fmt.Errorf("the -in flag is required")
+	}
+
+	if packageName == "" {
+		packageName = "main"
+	}
+
+	options := csv.ParserOptions{}
+	for _, d := range delimiter {
+		options.Delimiter = d
+		break
+	}
+
+	file, err := os.Open(in)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	schema, err := csv.InferSchema(file, sampleRows, options)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(out, func(w io.Writer) error {
+		return csv.GenerateStruct(w, packageName, typeName, schema)
+	})
+}
+
+func runCodecs(in string, out string, typeNames string) (err error) {
+	if in == "" {
+		return fmt.Errorf("the -in flag is required")
+	}
+
+	file, err := os.Open(in)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeOutput(out, func(w io.Writer) error {
+		return csv.GenerateCodecs(w, file, strings.Split(typeNames, ","))
+	})
+}
+
+// writeOutput calls generate with the output file, or stdout if out is empty.
+func writeOutput(out string, generate func(w io.Writer) error) (err error) {
+	if out == "" {
+		return generate(os.Stdout)
+	}
+
+	outFile, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+
+	err = generate(outFile)
+	if err != nil {
+		outFile.Close()
+		return err
+	}
+
+	return outFile.Close()
+}
@@ -0,0 +1,107 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrorCodecFieldCount = fmt.Errorf("codec returned the wrong number of values")
+)
+
+// RecordDecoder may be implemented by a struct to set its csv tagged fields without reflection. The csvgen command can generate it.
+// The record holds the values of the csv tagged fields in the order the fields are defined on the struct.
+// Errors should be returned as a SetValueError, and the parser will fill in the line number.
+type RecordDecoder interface {
+	DecodeCSVRecord(record []string) (err error)
+}
+
+// RecordEncoder may be implemented by a struct to format its csv tagged fields without reflection. The csvgen command can generate it.
+// The record should hold the values of the csv tagged fields in the order the fields are defined on the struct.
+// Errors should be returned as a GetValueError, and the writer will fill in the line number.
+type RecordEncoder interface {
+	EncodeCSVRecord() (record []string, err error)
+}
+
+// canUseCodec reports whether a generated codec would convert values exactly as reflection would for the bound fields.
+// Codecs only know the default conversions and are handed untrimmed values, so any field using a converter, trim or omitempty rules them out.
+func canUseCodec(csvAttrs map[string]csvAttributes) bool {
+	for _, attrs := range csvAttrs {
+		if attrs.converter != nil || attrs.trim || attrs.omitempty || attrs.headerPattern != nil || attrs.masker != nil || attrs.encrypted {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *Parser) canUseCodec() bool {
+	// Generated codecs are generated from csv tags outside of any profile, so they don't know about fields bound by other tags, profiles or derived headers.
+	return len(p.options.TagFallback) == 0 && p.tagOptions().key() == tagName && p.options.Profile == "" && !p.options.DeriveHeaders && !p.options.RejectNonFinite && !p.options.PartialRecords && !p.options.CloneStrings && p.options.CellTransform == nil && p.options.InvalidUTF8 == UTF8PassThrough && canUseCodec(p.csvAttrs)
+}
+
+func (p *Parser) decodeRecord(decoder RecordDecoder, readRecord []string) (err error) {
+	if cap(p.decodedRecord) < len(p.fieldOrder) {
+		p.decodedRecord = make([]string, len(p.fieldOrder))
+	}
+	p.decodedRecord = p.decodedRecord[:len(p.fieldOrder)]
+
+	for i, fieldName := range p.fieldOrder {
+		idx := p.csvAttrs[fieldName].columnIndex
+		if idx >= len(readRecord) {
+			return SetValueError{
+				Line:      p.line,
+				FieldName: fieldName,
+				Err:       ErrorColumnOutOfRange,
+			}
+		}
+		p.decodedRecord[i] = readRecord[idx]
+	}
+
+	err = decoder.DecodeCSVRecord(p.decodedRecord)
+	if err == nil {
+		return nil
+	}
+
+	var setValueErr SetValueError
+	if errors.As(err, &setValueErr) {
+		setValueErr.Line = p.line
+		return setValueErr
+	}
+
+	return RecordError{
+		Line: p.line,
+		Err:  err,
+	}
+}
+
+func (w *Writer) encodeRecord(encoder RecordEncoder, record []string) (err error) {
+	values, err := encoder.EncodeCSVRecord()
+	if err != nil {
+		var getValueErr GetValueError
+		if errors.As(err, &getValueErr) {
+			getValueErr.Line = w.line
+			return getValueErr
+		}
+
+		return RecordError{
+			Line: w.line,
+			Err:  err,
+		}
+	}
+
+	if len(values) != len(w.fieldOrder) {
+		return RecordError{
+			Line: w.line,
+			Err:  ErrorCodecFieldCount,
+		}
+	}
+
+	for i, fieldName := range w.fieldOrder {
+		if idx, ok := w.fieldColumns[fieldName]; ok {
+			record[idx] = values[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type codecTest struct {
+	Decoded bool
+	Name    string `csv:"header:name"`
+	Qty     int32  `csv:"header:qty;min:0"`
+}
+
+// DecodeCSVRecord is written as csvgen would generate it, except that it records that it was used.
+func (r *codecTest) DecodeCSVRecord(record []string) (err error) {
+	r.Decoded = true
+	r.Name = record[0]
+	{
+		v, err := strconv.ParseInt(record[1], 10, 32)
+		if err != nil {
+			return SetValueError{Value: record[1], FieldName: "Qty", Err: err}
+		}
+		r.Qty = int32(v)
+	}
+	return nil
+}
+
+func (r *codecTest) EncodeCSVRecord() (record []string, err error) {
+	record = make([]string, 2)
+	record[0] = strings.ToUpper(r.Name)
+	record[1] = strconv.FormatInt(int64(r.Qty), 10)
+	return record, nil
+}
+
+func TestRecordDecoder(t *testing.T) {
+	p := NewParser(strings.NewReader("qty,name\n3,widget\nmany,gadget\n-1,gizmo"), ParserOptions{})
+
+	err := p.ParseHeader(&codecTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	data := codecTest{}
+	err = p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with record decoder: %v", err)
+	}
+	if !data.Decoded || data.Name != "widget" || data.Qty != 3 {
+		t.Errorf("parser did not use the record decoder. Got '%v'", data)
+	}
+
+	err = p.ReadRecord(&data)
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 2 || setValueErr.FieldName != "Qty" {
+		t.Errorf("expected a SetValueError for Qty on line 2, but got %v", err)
+	}
+
+	err = p.ReadRecord(&data)
+	if !errors.Is(err, ErrorBelowMinimum) {
+		t.Errorf("expected validation attributes to apply to decoded records, but got %v", err)
+	}
+}
+
+func TestRecordDecoderNotUsedWithRejectNonFinite(t *testing.T) {
+	p := NewParser(strings.NewReader("qty,name\n3,widget"), ParserOptions{RejectNonFinite: true})
+
+	err := p.ParseHeader(&codecTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	data := codecTest{}
+	err = p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv: %v", err)
+	}
+	if data.Decoded {
+		t.Errorf("parser should not use a record decoder when options change how values are converted")
+	}
+}
+
+func TestRecordEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+
+	err := w.WriteRecord(&codecTest{Name: "widget", Qty: 3})
+	if err != nil {
+		t.Errorf("encountered error writing csv with record encoder: %v", err)
+	}
+	w.Flush()
+
+	if buf.String() != "WIDGET,3\n" {
+		t.Errorf("writer did not use the record encoder. Got '%s' but expected 'WIDGET,3'", buf.String())
+	}
+}
+
+func TestRecordDecoderNotUsedWithTagFallback(t *testing.T) {
+	p := NewParser(strings.NewReader("qty,name\n3,widget"), ParserOptions{TagFallback: []string{"csv", "json"}})
+
+	err := p.ParseHeader(&codecTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	data := codecTest{}
+	err = p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv: %v", err)
+	}
+	if data.Decoded {
+		t.Errorf("parser should not use a record decoder when fields may be bound by tags other than csv")
+	}
+}
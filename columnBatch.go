@@ -0,0 +1,78 @@
+package csv
+
+import (
+	"io"
+	"reflect"
+)
+
+// ColumnBatch holds records of a SchemaParser in columnar form, with the values of each schema column in one typed slice,
+// which is the layout columnar libraries such as Apache Arrow build their arrays from.
+type ColumnBatch struct {
+	// Len is the number of records in the batch.
+	Len     int
+	Columns []BatchColumn
+}
+
+// BatchColumn holds the values of one schema column for each record of a ColumnBatch.
+type BatchColumn struct {
+	Name string
+	Type ColumnType
+	// Values is a []string, []int64, []float64, []bool or []time.Time, as given by Type.
+	Values interface{}
+	// Valid is false for records where the column was empty and had no Default, so that the value can be stored as a null.
+	// The value itself is the zero value of the column's type.
+	Valid []bool
+}
+
+// ReadColumnBatch reads up to size records and returns them as a ColumnBatch, so that a file can be handed to a columnar library in batches
+// without converting it to a slice of structs first. It returns a partial batch at the end of the file, and io.EOF when there are no more records.
+// If a record can't be converted, the batch of the records before it is returned along with the error. A size less than 1 returns ErrorInvalidBatchSize.
+func (sp *SchemaParser) ReadColumnBatch(size int) (batch ColumnBatch, err error) {
+	if size <= 0 {
+		return batch, ErrorInvalidBatchSize
+	}
+
+	columns := make([]reflect.Value, len(sp.schema.Columns))
+	batch.Columns = make([]BatchColumn, len(sp.schema.Columns))
+	for i, column := range sp.schema.Columns {
+		goType, ok := columnTypes[column.Type]
+		if !ok {
+			return batch, SchemaError{
+				ColumnName: column.Name,
+				Err:        ErrorUnknownColumnType,
+			}
+		}
+
+		columns[i] = reflect.MakeSlice(reflect.SliceOf(goType), 0, size)
+		batch.Columns[i] = BatchColumn{
+			Name:  column.Name,
+			Type:  column.Type,
+			Valid: make([]bool, 0, size),
+		}
+	}
+
+	for batch.Len < size {
+		var values []interface{}
+		var present []bool
+		values, present, err = sp.readRecordValues()
+		if err != nil {
+			break
+		}
+
+		for i, value := range values {
+			columns[i] = reflect.Append(columns[i], reflect.ValueOf(value))
+			batch.Columns[i].Valid = append(batch.Columns[i].Valid, present[i])
+		}
+		batch.Len++
+	}
+
+	for i := range batch.Columns {
+		batch.Columns[i].Values = columns[i].Interface()
+	}
+
+	if err == io.EOF && batch.Len > 0 {
+		return batch, nil
+	}
+
+	return batch, err
+}
@@ -0,0 +1,67 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadColumnBatch(t *testing.T) {
+	schema := Schema{Columns: []SchemaColumn{
+		{Name: "name", Type: ColumnTypeString},
+		{Name: "quantity", Type: ColumnTypeInt},
+		{Name: "price", Type: ColumnTypeFloat, Default: "1.5"},
+	}}
+	sp := NewSchemaParser(strings.NewReader("name,quantity,price\nWidget,3,9.5\nGadget,,\nGizmo,7,2\n"), schema, ParserOptions{})
+
+	batch, err := sp.ReadColumnBatch(2)
+	if err != nil {
+		t.Errorf("encountered error reading column batch: %v", err)
+	}
+
+	expected := ColumnBatch{
+		Len: 2,
+		Columns: []BatchColumn{
+			{Name: "name", Type: ColumnTypeString, Values: []string{"Widget", "Gadget"}, Valid: []bool{true, true}},
+			{Name: "quantity", Type: ColumnTypeInt, Values: []int64{3, 0}, Valid: []bool{true, false}},
+			{Name: "price", Type: ColumnTypeFloat, Values: []float64{9.5, 1.5}, Valid: []bool{true, true}},
+		},
+	}
+	if !reflect.DeepEqual(batch, expected) {
+		t.Errorf("improperly read column batch. Got '%+v' but expected '%+v'", batch, expected)
+	}
+
+	batch, err = sp.ReadColumnBatch(2)
+	if err != nil || batch.Len != 1 || !reflect.DeepEqual(batch.Columns[1].Values, []int64{7}) {
+		t.Errorf("improperly read partial column batch. Got '%+v' and error %v", batch, err)
+	}
+
+	_, err = sp.ReadColumnBatch(2)
+	if err != io.EOF {
+		t.Errorf("expected io.EOF after the last batch, but got %v", err)
+	}
+}
+
+func TestReadColumnBatchError(t *testing.T) {
+	schema := Schema{Columns: []SchemaColumn{{Name: "quantity", Type: ColumnTypeInt}}}
+	sp := NewSchemaParser(strings.NewReader("quantity\n1\nx\n3\n"), schema, ParserOptions{})
+
+	batch, err := sp.ReadColumnBatch(10)
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 2 {
+		t.Errorf("expected a SetValueError on line 2, but got %v", err)
+	}
+
+	if batch.Len != 1 || !reflect.DeepEqual(batch.Columns[0].Values, []int64{1}) {
+		t.Errorf("expected the records before the error in the batch, but got '%+v'", batch)
+	}
+
+	for _, size := range []int{0, -1} {
+		_, err = sp.ReadColumnBatch(size)
+		if !errors.Is(err, ErrorInvalidBatchSize) {
+			t.Errorf("expected to encounter Invalid Batch Size error for a size of %d, but got %v", size, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package csv
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorInvalidCompression      = fmt.Errorf("compression must be CompressionNone or CompressionGzip")
+	ErrorInvalidCompressionLevel = fmt.Errorf("compression level must be between -2 and 9")
+	ErrorCompressedAppend        = fmt.Errorf("cannot append to a compressed file")
+)
+
+// Compression compresses the output of a Writer as it is written, such as for exports bound for object storage.
+// Zstandard isn't offered, as it isn't part of the standard library.
+type Compression int
+
+const (
+	// CompressionNone writes the csv data as it is.
+	CompressionNone Compression = iota
+	// CompressionGzip writes the csv data as a gzip stream, which must be finished with Close.
+	CompressionGzip
+)
+
+// compressor is the stream a Writer compresses its output with.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// newCompressor wraps file in the compression described by options, returning nil if the output isn't compressed, or the options are invalid.
+func newCompressor(file io.Writer, options WriterOptions) (c compressor) {
+	if options.Compression != CompressionGzip {
+		return nil
+	}
+
+	level := options.CompressionLevel
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	c, err := gzip.NewWriterLevel(file, level)
+	if err != nil {
+		return nil
+	}
+
+	return c
+}
+
+// validCompressionLevel reports whether level is a compression level gzip supports, where zero is its default level.
+func validCompressionLevel(level int) bool {
+	return level >= gzip.HuffmanOnly && level <= gzip.BestCompression
+}
@@ -0,0 +1,103 @@
+package csv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestGzipCompression(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{Compression: CompressionGzip, CompressionLevel: gzip.BestCompression})
+
+	err := w.WriteHeader(&flushTest{})
+	if err != nil {
+		t.Fatalf("encountered error writing csv header: %v", err)
+	}
+
+	err = w.WriteRecord(&flushTest{Name: "a", Count: 1})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing csv writer: %v", err)
+	}
+
+	reader, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("expected a gzip stream, but got %v", err)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("encountered error reading gzip stream: %v", err)
+	}
+
+	expected := "name,count\na,1\n"
+	if string(data) != expected {
+		t.Errorf("improperly compressed csv. Got '%s' but expected '%s'", data, expected)
+	}
+}
+
+func TestGzipFlush(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{Compression: CompressionGzip})
+
+	err := w.WriteRecord(&flushTest{Name: "a", Count: 1})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+
+	err = w.Flush()
+	if err != nil {
+		t.Fatalf("encountered error flushing csv writer: %v", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("expected a gzip stream, but got %v", err)
+	}
+
+	data := make([]byte, 4)
+	_, err = io.ReadFull(reader, data)
+	if err != nil || string(data) != "a,1\n" {
+		t.Errorf("expected the flushed record to be readable before the stream is finished, but got '%s' and %v", data, err)
+	}
+}
+
+func TestCompressionOptionErrors(t *testing.T) {
+	tests := []struct {
+		options WriterOptions
+		option  string
+		err     error
+	}{
+		{WriterOptions{Compression: Compression(5)}, "Compression", ErrorInvalidCompression},
+		{WriterOptions{Compression: CompressionGzip, CompressionLevel: 10}, "CompressionLevel", ErrorInvalidCompressionLevel},
+	}
+
+	for _, test := range tests {
+		var optionErr OptionError
+		err := test.options.Validate()
+		if !errors.As(err, &optionErr) || optionErr.Option != test.option || !errors.Is(err, test.err) {
+			t.Errorf("expected %v for option %s, but got %v", test.err, test.option, err)
+		}
+	}
+}
+
+func TestCompressedAppendError(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "append*.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	_, err = NewAppendWriter(f, &flushTest{}, WriterOptions{Compression: CompressionGzip})
+	if !errors.Is(err, ErrorCompressedAppend) {
+		t.Errorf("expected %v, but got %v", ErrorCompressedAppend, err)
+	}
+}
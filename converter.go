@@ -0,0 +1,71 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	ErrorConverterType = fmt.Errorf("converter returned a value that cannot be assigned to the field")
+)
+
+// Converter describes how to read a field type from, and write it to, a csv value.
+// Either function may be nil if the type is only ever read or only ever written.
+type Converter struct {
+	Parse  func(value string) (interface{}, error)
+	Format func(value interface{}) (string, error)
+}
+
+var (
+	convertersMutex sync.RWMutex
+	converters      = make(map[reflect.Type]Converter)
+)
+
+// RegisterConverter makes t a supported field type for every parser and writer in the process, using parse to read values and format to write them.
+// Converters take precedence over the built in handling of a type, and registering a type again replaces its converter.
+// Converters should be registered before any parser or writer binds a struct using the type, as bindings are cached.
+func RegisterConverter(t reflect.Type, parse func(string) (interface{}, error), format func(interface{}) (string, error)) {
+	convertersMutex.Lock()
+	defer convertersMutex.Unlock()
+
+	converters[t] = Converter{
+		Parse:  parse,
+		Format: format,
+	}
+}
+
+// lookupConverter finds the converter for t, preferring the overrides passed in over the registered converters.
+func lookupConverter(t reflect.Type, overrides map[reflect.Type]Converter) (converter Converter, ok bool) {
+	converter, ok = overrides[t]
+	if ok {
+		return converter, ok
+	}
+
+	convertersMutex.RLock()
+	defer convertersMutex.RUnlock()
+
+	converter, ok = converters[t]
+	return converter, ok
+}
+
+func setConvertedValue(field reflect.Value, converter *Converter, value string) (err error) {
+	converted, err := converter.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	convertedValue := reflect.ValueOf(converted)
+	switch {
+	case !convertedValue.IsValid():
+		field.Set(reflect.Zero(field.Type()))
+	case convertedValue.Type().AssignableTo(field.Type()):
+		field.Set(convertedValue)
+	case convertedValue.Type().ConvertibleTo(field.Type()):
+		field.Set(convertedValue.Convert(field.Type()))
+	default:
+		return ErrorConverterType
+	}
+
+	return nil
+}
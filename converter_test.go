@@ -0,0 +1,129 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type customerID struct {
+	Region string
+	Number int
+}
+
+func parseCustomerID(value string) (interface{}, error) {
+	region, number, found := strings.Cut(value, "-")
+	if !found {
+		return nil, fmt.Errorf("customer id must look like REGION-NUMBER")
+	}
+
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, err
+	}
+
+	return customerID{Region: region, Number: n}, nil
+}
+
+func formatCustomerID(value interface{}) (string, error) {
+	id := value.(customerID)
+	return fmt.Sprintf("%s-%d", id.Region, id.Number), nil
+}
+
+type yesNo bool
+
+type converterTest struct {
+	ID     customerID `csv:"index:0"`
+	Active yesNo      `csv:"index:1"`
+}
+
+func init() {
+	RegisterConverter(reflect.TypeOf(customerID{}), parseCustomerID, formatCustomerID)
+	RegisterConverter(reflect.TypeOf(yesNo(false)),
+		func(value string) (interface{}, error) { return value == "Y", nil },
+		func(value interface{}) (string, error) {
+			if value.(yesNo) {
+				return "Y", nil
+			}
+			return "N", nil
+		},
+	)
+}
+
+func TestRegisteredConverter(t *testing.T) {
+	p := NewParser(strings.NewReader("EU-42,Y"), ParserOptions{})
+
+	data := converterTest{}
+	err := p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with registered converter: %v", err)
+	}
+	if data.ID != (customerID{Region: "EU", Number: 42}) {
+		t.Errorf("improperly parsed ID. Got '%v' but expected 'EU-42'", data.ID)
+	}
+	if !data.Active {
+		t.Errorf("improperly parsed Active. Got '%v' but expected 'true'", data.Active)
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+	err = w.WriteRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error writing csv with registered converter: %v", err)
+	}
+	w.Flush()
+
+	if buf.String() != "EU-42,Y\n" {
+		t.Errorf("improperly written csv with registered converter. Got '%s' but expected 'EU-42,Y'", buf.String())
+	}
+}
+
+type converterTypeTest struct {
+	ID customerID `csv:"index:0"`
+}
+
+func TestConverterTypeError(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(customerID{}), func(value string) (interface{}, error) { return value, nil }, formatCustomerID)
+	defer RegisterConverter(reflect.TypeOf(customerID{}), parseCustomerID, formatCustomerID)
+
+	p := NewParser(strings.NewReader("EU-42"), ParserOptions{})
+
+	err := p.ReadRecord(&converterTypeTest{})
+	if !errors.Is(err, ErrorConverterType) {
+		t.Errorf("expected to encounter Converter Type error, but got %v", err)
+	}
+}
+
+func TestConverterOverrides(t *testing.T) {
+	overrides := map[reflect.Type]Converter{
+		reflect.TypeOf(yesNo(false)): {
+			Parse: func(value string) (interface{}, error) { return value == "oui", nil },
+		},
+	}
+	p := NewParser(strings.NewReader("EU-42,oui"), ParserOptions{Converters: overrides})
+
+	data := converterTest{}
+	err := p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with converter overrides: %v", err)
+	}
+	if !data.Active {
+		t.Errorf("parser did not use its converter override. Got '%v' but expected 'true'", data.Active)
+	}
+	if data.ID != (customerID{Region: "EU", Number: 42}) {
+		t.Errorf("parser did not fall back to the registered converter. Got '%v' but expected 'EU-42'", data.ID)
+	}
+
+	other := NewParser(strings.NewReader("EU-42,oui"), ParserOptions{})
+	err = other.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with registered converters: %v", err)
+	}
+	if data.Active {
+		t.Errorf("converter override leaked into another parser. Got '%v' but expected 'false'", data.Active)
+	}
+}
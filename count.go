@@ -0,0 +1,30 @@
+package csv
+
+import (
+	"io"
+)
+
+// CountRecords reads file to the end and returns the number of records it contains, using the Delimiter and CommentChar of options.
+// Quoted values that span lines are counted once, and comments and blank lines aren't counted. A header counts as a record, so subtract one for files that have one.
+// Records are not converted or kept, so it is cheap enough to run before an import to pre-size slices or report progress.
+func CountRecords(file io.Reader, options ParserOptions) (count int, err error) {
+	err = options.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	options.ReuseRecord = true
+	reader := newRecordReader(file, options)
+	allowRaggedRecords(reader)
+
+	for {
+		_, err = reader.Read()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+}
@@ -0,0 +1,40 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCountRecords(t *testing.T) {
+	data := "name;note\n# a comment\nwidget;\"spans\ntwo lines\"\n\ngizmo;short;extra\n"
+
+	count, err := CountRecords(strings.NewReader(data), ParserOptions{Delimiter: ';', CommentChar: '#'})
+	if err != nil {
+		t.Errorf("encountered error counting records: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("improperly counted records. Got %d but expected 3", count)
+	}
+}
+
+func TestLine(t *testing.T) {
+	p := NewParser(strings.NewReader("field1,fieldTwo,Field3\na,1,2\nb,3,4"), ParserOptions{})
+
+	err := p.ParseHeader(&headerTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+	if p.Line() != 0 {
+		t.Errorf("expected line 0 after parsing the header, but got %d", p.Line())
+	}
+
+	for i := 1; i <= 2; i++ {
+		err = p.ReadRecord(&headerTest{})
+		if err != nil {
+			t.Errorf("encountered error reading csv record: %v", err)
+		}
+		if p.Line() != i {
+			t.Errorf("expected line %d, but got %d", i, p.Line())
+		}
+	}
+}
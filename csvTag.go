@@ -1,12 +1,19 @@
 package csv
 
 import (
+	"bufio"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/cmplx"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 const (
@@ -17,25 +24,108 @@ const (
 	headerAttr          = "header"
 	indexAttr           = "index"
 	useCustomSetterAttr = "useCustomSetter"
+	precisionAttr       = "precision"
+	percentAttr         = "percent"
+	currencyAttr        = "currency"
+	minAttr             = "min"
+	maxAttr             = "max"
+	regexAttr           = "regex"
+	posAttr             = "pos"
+	trimAttr            = "trim"
+	omitemptyAttr       = "omitempty"
+	headerRegexAttr     = "headerRegex"
+	allowSharedAttr     = "allowShared"
+	maskAttr            = "mask"
+	encryptedAttr       = "encrypted"
+	posDelim            = "-"
+	profilePrefix       = "profile="
 )
 
 var (
 	ErrorMissingCustomSetter = fmt.Errorf("cannot use custom data type without implementing CustomSetter interface")
 	ErrorUnsupportedDataType = fmt.Errorf("must implement CustomSetter interface when using unsupported data types")
 	ErrorInvalidIndex        = fmt.Errorf("index must be a non negative integer")
+	ErrorDuplicateIndex      = fmt.Errorf("index is already used by another field")
+	ErrorDuplicateHeader     = fmt.Errorf("header is already used by another field")
+	ErrorDuplicateBinding    = fmt.Errorf("column is already bound to another field")
 	ErrorMalformedCsvTag     = fmt.Errorf("you need to specify either the header or index")
 	ErrorUnexportedField     = fmt.Errorf("csv tags may not be set on unexported fields")
 	ErrorFieldNotFound       = fmt.Errorf("field not found in header")
+	ErrorInvalidPrecision    = fmt.Errorf("precision must be a non negative integer")
+	ErrorPrecisionNotFloat   = fmt.Errorf("precision may only be set on float fields")
+	ErrorPercentNotFloat     = fmt.Errorf("percent may only be set on float fields")
+	ErrorCurrencyNotNumeric  = fmt.Errorf("currency may only be set on numeric fields")
+	ErrorNonFiniteFloat      = fmt.Errorf("NaN and Inf values are not allowed")
+	ErrorInvalidMinMax       = fmt.Errorf("min and max must be numbers")
+	ErrorMinMaxNotNumeric    = fmt.Errorf("min and max may only be set on numeric fields")
+	ErrorInvalidRegex        = fmt.Errorf("regex must be a valid regular expression")
+	ErrorInvalidPosition     = fmt.Errorf("pos must be a range of character positions counted from 1, such as 10-18")
+	ErrorMissingPosition     = fmt.Errorf("fixed-width fields must specify a pos")
+	ErrorHeaderNotParsed     = fmt.Errorf("fields bound by header can't be read until the header is parsed")
+	ErrorPartialRecord       = fmt.Errorf("some fields of the record could not be set")
+	ErrorInvalidArgument     = fmt.Errorf("must be a non-nil pointer to a struct")
+	ErrorBoundTypeMismatch   = fmt.Errorf("must point to the struct type the parser or writer was first used with")
+	ErrorHeaderRegexField    = fmt.Errorf("headerRegex may only be set on a slice or string keyed map of a supported type, without header, index or useCustomSetter")
 )
 
 type CustomSetter interface {
 	CustomSetter(fieldName string, value string) (err error)
 }
 
+// CustomSetterV2 may be implemented in place of CustomSetter when a setter needs to know where its value came from, such as to apply column dependent logic or to write better error messages.
+// When a struct implements both, CustomSetterV2 is used.
+type CustomSetterV2 interface {
+	CustomSetterV2(field FieldInfo, value string) (err error)
+}
+
+// FieldInfo describes the field and column a value is being set from.
+type FieldInfo struct {
+	FieldName string
+	// HeaderName is the label of the column in the parsed header, or the header name of the csv tag when no header has been parsed.
+	HeaderName  string
+	ColumnIndex int
+	Line        int
+}
+
+// AfterCsvRecordHook may be implemented by a struct to be called by ReadRecord once all fields of a record have been set.
+// Use it to compute derived fields, or to reject an inconsistent record by returning an error.
+type AfterCsvRecordHook interface {
+	AfterCsvRecord(line int) (err error)
+}
+
 type csvAttributes struct {
+	tag             string
+	fieldIndex      int
 	headerName      string
+	hasHeader       bool
 	columnIndex     int
+	hasIndex        bool
 	useCustomSetter bool
+	hasPrecision    bool
+	precision       int
+	percent         bool
+	currency        bool
+	hasMin          bool
+	min             float64
+	hasMax          bool
+	max             float64
+	pattern         *regexp.Regexp
+	hasPos          bool
+	posStart        int
+	posEnd          int
+	trim            bool
+	omitempty       bool
+	allowShared     bool
+	shared          bool
+	converter       *Converter
+	headerPattern   *regexp.Regexp
+	patternColumns  []int
+	offset          uintptr
+	kind            reflect.Kind
+	unsafeFastPath  bool
+	mask            string
+	masker          Masker
+	encrypted       bool
 }
 
 func isValidDataType(i interface{}) bool {
@@ -46,78 +136,406 @@ func isValidDataType(i interface{}) bool {
 	return false
 }
 
-func getCsvAttributes(structPointer interface{}) (csvAttrs map[string]csvAttributes, err error) {
-	csvAttrs = make(map[string]csvAttributes)
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
 
-	structValue := reflect.ValueOf(structPointer).Elem()
-	customDataSetter := reflect.TypeOf((*CustomSetter)(nil)).Elem()
-	supportsCustomData := reflect.TypeOf(structPointer).Implements(customDataSetter)
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// roundFloat rounds value to the given number of decimal places by way of its decimal representation,
+// so that the result matches what would be written out with the same precision.
+func roundFloat(value float64, precision int, bitSize int) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
 
-	for i := 0; i < structValue.NumField(); i++ {
-		field := structValue.Type().Field(i)
-		tag := field.Tag.Get(tagName)
-		if tag == "" {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(value, 'f', precision, bitSize), bitSize)
+	if err != nil {
+		return value
+	}
+
+	return rounded
+}
+
+// tagOptions holds the parser and writer settings that affect how the csv tags on a struct are interpreted.
+type tagOptions struct {
+	converters    map[reflect.Type]Converter
+	fixedWidth    bool
+	tagName       string
+	tagFallback   []string
+	profile       string
+	indexBase     int
+	headerNaming  NamingStrategy
+	fieldNamer    FieldNamer
+	deriveHeaders bool
+	maskers       map[string]Masker
+	// ignoreUnknown leaves unknown attributes to be reported by CheckStruct, which finds every one of them.
+	ignoreUnknown bool
+}
+
+// key returns the struct tag key that holds csv tags, which is csv unless TagName is set.
+func (o tagOptions) key() string {
+	if o.tagName != "" {
+		return o.tagName
+	}
+	return tagName
+}
+
+// headerFor derives the header name of field with the field namer, or with the naming strategy when there is no field namer.
+func (o tagOptions) headerFor(field reflect.StructField) string {
+	if o.fieldNamer != nil {
+		return o.fieldNamer.HeaderFor(field)
+	}
+	return o.headerNaming.name(field.Name)
+}
+
+// checkStructPointer returns an ArgumentError unless structPointer is a non-nil pointer to a struct, so that reflect doesn't panic on it.
+func checkStructPointer(structPointer interface{}) (err error) {
+	value := reflect.ValueOf(structPointer)
+	if value.Kind() == reflect.Pointer && !value.IsNil() && value.Elem().Kind() == reflect.Struct {
+		return nil
+	}
+
+	received := "nil"
+	if structPointer != nil {
+		received = value.Type().String()
+	}
+
+	return ArgumentError{
+		Received: received,
+		Err:      ErrorInvalidArgument,
+	}
+}
+
+func getCsvAttributes(structPointer interface{}, options tagOptions) (csvAttrs map[string]csvAttributes, err error) {
+	return getCsvAttributesWith(structPointer, options, supportsCustomSetter(reflect.TypeOf(structPointer)), ErrorMissingCustomSetter, ErrorUnsupportedDataType)
+}
+
+// getCsvAttributesWith reads the csv tags defined on structPointer. Parsers and writers differ only in which
+// custom data interface the struct must implement, so the errors to report when it doesn't are passed in.
+func getCsvAttributesWith(structPointer interface{}, options tagOptions, supportsCustomData bool, missingCustomErr error, unsupportedTypeErr error) (csvAttrs map[string]csvAttributes, err error) {
+	csvAttrs, errs := readCsvAttributes(reflect.TypeOf(structPointer).Elem(), options, supportsCustomData, missingCustomErr, unsupportedTypeErr, false)
+	if len(errs) > 0 {
+		return csvAttrs, errs[0]
+	}
+
+	return csvAttrs, nil
+}
+
+// readCsvAttributes reads the csv tags defined on the fields of structType, and returns the problems with them as CsvTagDefErrors.
+// It stops at the first problem unless all is set, in which case the fields with problems are left out of csvAttrs.
+func readCsvAttributes(structType reflect.Type, options tagOptions, supportsCustomData bool, missingCustomErr error, unsupportedTypeErr error, all bool) (csvAttrs map[string]csvAttributes, errs []error) {
+	csvAttrs = make(map[string]csvAttributes)
+	headers := make(map[string]string)
+	indexes := make(map[int]string)
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldAttrs, bound, err := fieldAttributes(field, options, supportsCustomData, missingCustomErr, unsupportedTypeErr)
+		if err == nil && !bound {
 			continue
 		}
 
-		if !field.IsExported() {
-			return csvAttrs, CsvTagDefError{
-				CsvTag:    tag,
-				FieldName: field.Name,
-				Err:       ErrorUnexportedField,
+		// Two fields bound to the same column usually means a copied tag wasn't updated, so sharing a column has to be asked for on both fields.
+		if err == nil && fieldAttrs.hasHeader {
+			if boundField, ok := headers[fieldAttrs.headerName]; ok {
+				err = checkSharedBinding(csvAttrs[boundField], fieldAttrs, fieldAttrs.tag, field.Name, boundField, ErrorDuplicateHeader)
+				fieldAttrs.shared = true
+			} else {
+				headers[fieldAttrs.headerName] = field.Name
 			}
 		}
 
-		fieldAttrs, err := getAttributesFromTag(tag)
-		if err != nil {
-			return csvAttrs, CsvTagDefError{
-				CsvTag:    tag,
-				FieldName: field.Name,
-				Err:       err,
+		if err == nil && fieldAttrs.hasIndex {
+			if boundField, ok := indexes[fieldAttrs.columnIndex]; ok {
+				err = checkSharedBinding(csvAttrs[boundField], fieldAttrs, fieldAttrs.tag, field.Name, boundField, ErrorDuplicateIndex)
+				fieldAttrs.shared = true
+			} else {
+				indexes[fieldAttrs.columnIndex] = field.Name
 			}
 		}
 
-		if fieldAttrs.useCustomSetter && !supportsCustomData {
-			return csvAttrs, CsvTagDefError{
-				CsvTag:    tag,
-				FieldName: field.Name,
-				Err:       ErrorMissingCustomSetter,
+		if err != nil {
+			errs = append(errs, err)
+			if !all {
+				return csvAttrs, errs
 			}
+			continue
 		}
 
-		if !isValidDataType(structValue.FieldByIndex([]int{i}).Interface()) && !supportsCustomData {
-			return csvAttrs, CsvTagDefError{
-				CsvTag:    tag,
-				FieldName: field.Name,
-				Err:       ErrorUnsupportedDataType,
+		fieldAttrs.fieldIndex = i
+		csvAttrs[field.Name] = fieldAttrs
+	}
+
+	return csvAttrs, errs
+}
+
+// fieldAttributes reads the csv tag of field, and reports whether the field is bound by it. Problems with the tag are returned as a CsvTagDefError.
+func fieldAttributes(field reflect.StructField, options tagOptions, supportsCustomData bool, missingCustomErr error, unsupportedTypeErr error) (fieldAttrs csvAttributes, bound bool, err error) {
+	tag, scoped := selectProfile(boundTag(field, options), options.profile)
+	if tag == "" {
+		return fieldAttrs, false, nil
+	}
+
+	tagErr := func(err error) (csvAttributes, bool, error) {
+		return fieldAttrs, true, CsvTagDefError{
+			CsvTag:    tag,
+			FieldName: field.Name,
+			Err:       err,
+		}
+	}
+
+	if !field.IsExported() {
+		return tagErr(ErrorUnexportedField)
+	}
+
+	fieldAttrs, err = getAttributesFromTag(tag)
+	if _, ok := err.(UnknownAttributeError); ok && options.ignoreUnknown {
+		err = nil
+	}
+	if err == ErrorMalformedCsvTag && scoped {
+		// The field is only bound in other profiles.
+		return fieldAttrs, false, nil
+	}
+	if err != nil {
+		return tagErr(err)
+	}
+
+	if fieldAttrs.hasHeader && fieldAttrs.headerName == "" {
+		fieldAttrs.headerName = options.headerFor(field)
+		if fieldAttrs.headerName == "" {
+			fieldAttrs.hasHeader = false
+			if !fieldAttrs.hasIndex && !fieldAttrs.hasPos {
+				// The field namer left the field unbound.
+				return fieldAttrs, false, nil
 			}
 		}
+	}
 
-		csvAttrs[structValue.Type().Field(i).Name] = fieldAttrs
+	if options.fixedWidth && !fieldAttrs.hasPos {
+		return tagErr(ErrorMissingPosition)
 	}
 
-	return csvAttrs, nil
+	// Fields bound by headerRegex hold the values of every matching column, so the checks below apply to the type of their elements.
+	valueType := field.Type
+	if fieldAttrs.headerPattern != nil {
+		isCollection := field.Type.Kind() == reflect.Slice || (field.Type.Kind() == reflect.Map && field.Type.Key().Kind() == reflect.String)
+		if !isCollection || fieldAttrs.hasHeader || fieldAttrs.hasIndex || fieldAttrs.useCustomSetter {
+			return tagErr(ErrorHeaderRegexField)
+		}
+		valueType = field.Type.Elem()
+	}
+
+	if !options.fixedWidth && !fieldAttrs.hasHeader && !fieldAttrs.hasIndex && fieldAttrs.headerPattern == nil {
+		return tagErr(ErrorMalformedCsvTag)
+	}
+
+	if fieldAttrs.useCustomSetter && !supportsCustomData {
+		return tagErr(missingCustomErr)
+	}
+
+	if fieldAttrs.hasPrecision && !isFloatKind(valueType.Kind()) {
+		return tagErr(ErrorPrecisionNotFloat)
+	}
+
+	if fieldAttrs.percent && !isFloatKind(valueType.Kind()) {
+		return tagErr(ErrorPercentNotFloat)
+	}
+
+	if fieldAttrs.currency && !isNumericKind(valueType.Kind()) {
+		return tagErr(ErrorCurrencyNotNumeric)
+	}
+
+	if (fieldAttrs.hasMin || fieldAttrs.hasMax) && !isNumericKind(valueType.Kind()) {
+		return tagErr(ErrorMinMaxNotNumeric)
+	}
+
+	if converter, ok := lookupConverter(valueType, options.converters); ok {
+		fieldAttrs.converter = &converter
+	}
+
+	if fieldAttrs.mask != "" {
+		masker, ok := lookupMasker(fieldAttrs.mask, options.maskers)
+		if !ok {
+			return tagErr(ErrorUnknownMask)
+		}
+		fieldAttrs.masker = masker
+	}
+
+	if !isValidDataType(reflect.Zero(valueType).Interface()) && fieldAttrs.converter == nil && (!supportsCustomData || fieldAttrs.headerPattern != nil) {
+		return tagErr(unsupportedTypeErr)
+	}
+
+	if fieldAttrs.hasIndex {
+		fieldAttrs.columnIndex -= options.indexBase
+		if fieldAttrs.columnIndex < 0 {
+			return tagErr(ErrorInvalidIndex)
+		}
+	}
+
+	fieldAttrs.tag = tag
+	fieldAttrs.offset = field.Offset
+	fieldAttrs.kind = field.Type.Kind()
+	fieldAttrs.unsafeFastPath = canUseUnsafeFastPath(field.Type, fieldAttrs)
+
+	return fieldAttrs, true, nil
+}
+
+// checkSharedBinding returns a CsvTagDefError holding a DuplicateBindingError unless both fields bound to the same column have the allowShared attribute.
+func checkSharedBinding(boundAttrs csvAttributes, fieldAttrs csvAttributes, tag string, fieldName string, boundField string, err error) error {
+	if boundAttrs.allowShared && fieldAttrs.allowShared {
+		return nil
+	}
+
+	return CsvTagDefError{
+		CsvTag:    tag,
+		FieldName: fieldName,
+		Err: DuplicateBindingError{
+			BoundFieldName: boundField,
+			Err:            err,
+		},
+	}
+}
+
+// boundTag returns the csv tag field is bound with. When headers are derived, an exported field without a tag is bound by a header attribute without a value,
+// so that its header name is derived from the field name, and a field tagged with - is left unbound.
+func boundTag(field reflect.StructField, options tagOptions) (tag string) {
+	tag = fieldTag(field, options)
+	if !options.deriveHeaders {
+		return tag
+	}
+
+	if tag == "-" {
+		return ""
+	}
+
+	if tag == "" && field.IsExported() && !field.Anonymous && !hasTagKey(field, options) {
+		return headerAttr
+	}
+
+	return tag
+}
+
+// hasTagKey reports whether field has any of the struct tag keys it may be bound with, so that a field left unbound on purpose, such as with a json name of -, isn't bound by a derived header.
+func hasTagKey(field reflect.StructField, options tagOptions) bool {
+	if _, ok := field.Tag.Lookup(options.key()); ok {
+		return true
+	}
+
+	for _, key := range options.tagFallback {
+		if _, ok := field.Tag.Lookup(key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldTag returns the csv tag of field. When tag fallbacks are set, the first of their keys present on the field is used, and the value of a key other than the csv tag key,
+// such as a json or protobuf tag, is read as a header name. An empty json name falls back to the field name, as it does in encoding/json, and a json name of - leaves the field unbound.
+func fieldTag(field reflect.StructField, options tagOptions) (tag string) {
+	if len(options.tagFallback) == 0 {
+		return field.Tag.Get(options.key())
+	}
+
+	for _, key := range options.tagFallback {
+		value, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		if key == options.key() {
+			return value
+		}
+
+		name := fallbackHeaderName(key, value)
+		switch name {
+		case "-":
+			return ""
+		case "":
+			name = field.Name
+		}
+
+		return headerAttr + valueDelim + name
+	}
+
+	return ""
+}
+
+// selectProfile returns the attributes of tag that apply to profile, which are those not scoped to a profile, such as trim, along with those scoped to it, such as profile=vendorA:header:Amt.
+// Attributes scoped to other profiles are left out. It reports whether tag has any attributes scoped to a profile, since a field with only scoped bindings is unbound in other profiles.
+func selectProfile(tag string, profile string) (selected string, scoped bool) {
+	if !strings.Contains(tag, profilePrefix) {
+		return tag, false
+	}
+
+	var attributes []string
+	for _, attribute := range splitTag(tag) {
+		if !strings.HasPrefix(attribute, profilePrefix) {
+			attributes = append(attributes, attribute)
+			continue
+		}
+
+		scoped = true
+		name, scopedAttribute, _ := strings.Cut(strings.TrimPrefix(attribute, profilePrefix), valueDelim)
+		if name == profile && profile != "" {
+			attributes = append(attributes, scopedAttribute)
+		}
+	}
+
+	return strings.Join(attributes, attrDelim), scoped
+}
+
+// fallbackHeaderName reads the name given to a field by a tag other than csv. Protobuf tags give it as a name= option, and other tags, such as json, yaml and db, as the first option.
+func fallbackHeaderName(key string, value string) (name string) {
+	options := strings.Split(value, ",")
+	if key != "protobuf" {
+		return options[0]
+	}
+
+	for _, option := range options {
+		if strings.HasPrefix(option, "name=") {
+			return strings.TrimPrefix(option, "name=")
+		}
+	}
+
+	return ""
+}
+
+// getFieldOrder returns the names of the fields described by csvAttrs in the order they are defined on the struct.
+func getFieldOrder(csvAttrs map[string]csvAttributes) (fieldNames []string) {
+	fieldNames = make([]string, 0, len(csvAttrs))
+	for fieldName := range csvAttrs {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Slice(fieldNames, func(i, j int) bool {
+		return csvAttrs[fieldNames[i]].fieldIndex < csvAttrs[fieldNames[j]].fieldIndex
+	})
+
+	return fieldNames
 }
 
 func getAttributesFromTag(tag string) (attrs csvAttributes, err error) {
-	attributes := strings.Split(tag, attrDelim)
-	var hasHeader = false
-	var hasIndex = false
+	attributes := splitTag(tag)
+	unknown := ""
 
 	for _, attribute := range attributes {
-		attributeArr := strings.Split(attribute, valueDelim)
-		key := attributeArr[0]
-		var value string
-		if len(attributeArr) > 1 {
-			value = attributeArr[1]
-		}
+		key, value := cutAttribute(attribute)
 
 		switch key {
 		case headerAttr:
-			hasHeader = true
+			attrs.hasHeader = true
 			attrs.headerName = value
 		case indexAttr:
-			hasIndex = true
+			attrs.hasIndex = true
 			attrs.columnIndex, err = strconv.Atoi(value)
 			if err != nil {
 				return attrs, ErrorInvalidIndex
@@ -127,26 +545,304 @@ func getAttributesFromTag(tag string) (attrs csvAttributes, err error) {
 			}
 		case useCustomSetterAttr:
 			attrs.useCustomSetter = true
+		case percentAttr:
+			attrs.percent = true
+		case currencyAttr:
+			attrs.currency = true
+		case minAttr:
+			attrs.hasMin = true
+			attrs.min, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return attrs, ErrorInvalidMinMax
+			}
+		case maxAttr:
+			attrs.hasMax = true
+			attrs.max, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return attrs, ErrorInvalidMinMax
+			}
+		case regexAttr:
+			attrs.pattern, err = regexp.Compile(value)
+			if err != nil {
+				return attrs, ErrorInvalidRegex
+			}
+		case headerRegexAttr:
+			attrs.headerPattern, err = regexp.Compile(value)
+			if err != nil {
+				return attrs, ErrorInvalidRegex
+			}
+		case posAttr:
+			attrs.hasPos = true
+			attrs.posStart, attrs.posEnd, err = parsePosition(value)
+			if err != nil {
+				return attrs, err
+			}
+		case trimAttr:
+			attrs.trim = true
+		case omitemptyAttr:
+			attrs.omitempty = true
+		case allowSharedAttr:
+			attrs.allowShared = true
+		case encryptedAttr:
+			attrs.encrypted = true
+		case maskAttr:
+			if value == "" {
+				return attrs, ErrorInvalidMask
+			}
+			attrs.mask = value
+		case precisionAttr:
+			attrs.hasPrecision = true
+			attrs.precision, err = strconv.Atoi(value)
+			if err != nil {
+				return attrs, ErrorInvalidPrecision
+			}
+			if attrs.precision < 0 {
+				return attrs, ErrorInvalidPrecision
+			}
+		case "":
+			// An empty attribute, such as after a trailing semicolon, has nothing to apply.
+		default:
+			if unknown == "" {
+				unknown = attribute
+			}
 		}
 	}
 
-	if !hasHeader && !hasIndex {
+	if !attrs.hasHeader && !attrs.hasIndex && !attrs.hasPos && attrs.headerPattern == nil {
 		return attrs, ErrorMalformedCsvTag
 	}
 
+	// Unknown attributes are usually misspelled, so the attribute that was meant would be silently missing.
+	if unknown != "" {
+		return attrs, UnknownAttributeError{
+			Attribute: unknown,
+			Err:       ErrorUnknownAttribute,
+		}
+	}
+
 	return attrs, nil
 }
 
+// splitTag splits tag into its attributes. Semicolons don't split a value quoted with single quotes, such as header:'a;b', or escaped with a backslash, such as regex:a\;b.
+// The attributes are returned as they are written, so that they can be joined again.
+func splitTag(tag string) (attributes []string) {
+	start := 0
+	quoted := false
+
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case quoted && tag[i] == '\'' && i+1 < len(tag) && tag[i+1] == '\'':
+			i++
+		case quoted && tag[i] == '\'':
+			quoted = false
+		case quoted:
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ';':
+			i++
+		case tag[i] == '\'' && i > 0 && tag[i-1] == ':':
+			quoted = true
+		case tag[i] == ';':
+			attributes = append(attributes, tag[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(attributes, tag[start:])
+}
+
+// cutAttribute splits attribute into its key and value at the first colon, so that values may contain colons, such as a time format of 15:04:05.
+// A value quoted with single quotes is unquoted, with a doubled single quote standing for one, and a semicolon escaped with a backslash is unescaped.
+func cutAttribute(attribute string) (key string, value string) {
+	key, value, _ = strings.Cut(attribute, valueDelim)
+
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return key, strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+
+	return key, strings.ReplaceAll(value, `\;`, ";")
+}
+
+// quoteTagValue quotes value with single quotes if it needs them to be read back as a single value by cutAttribute.
+func quoteTagValue(value string) string {
+	if !strings.ContainsAny(value, attrDelim) && !strings.HasPrefix(value, "'") && !strings.Contains(value, `\;`) {
+		return value
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// parsePosition reads a pos attribute value of either a single character position or an inclusive range of positions, both counted from 1.
+// It returns the zero-indexed start and exclusive end of the range, so they can be used to slice a line.
+func parsePosition(value string) (start int, end int, err error) {
+	first, last, isRange := strings.Cut(value, posDelim)
+	if !isRange {
+		last = first
+	}
+
+	start, err = strconv.Atoi(first)
+	if err != nil {
+		return 0, 0, ErrorInvalidPosition
+	}
+
+	end, err = strconv.Atoi(last)
+	if err != nil {
+		return 0, 0, ErrorInvalidPosition
+	}
+
+	if start < 1 || end < start {
+		return 0, 0, ErrorInvalidPosition
+	}
+
+	return start - 1, end, nil
+}
+
+// Parser reads csv records into structs with csv decorator tags. A Parser is not safe for concurrent use, so wrap it with SyncParser to share it between goroutines.
 type Parser struct {
-	reader   *csv.Reader
-	line     int
-	csvAttrs map[string]csvAttributes
+	file          io.Reader
+	offset        int64
+	reader        RecordReader
+	line          int
+	header        []string
+	csvAttrs      map[string]csvAttributes
+	fieldOrder    []string
+	useDecoder    bool
+	needsHeader   bool
+	binding       *Binding
+	boundType     reflect.Type
+	decodedRecord []string
+	headerChecked bool
+	hasPeeked     bool
+	peeked        []string
+	peekErr       error
+	peekOffset    int64
+	recordPeek    *recordPeek
+	rejects       *csv.Writer
+	pool          *ParserPool
+	buffer        *bufio.Reader
+	dedupe        *dedupeState
+	sectionEnded  bool
+	footer        *footerState
+	reconciled    *reconcileState
+	options       ParserOptions
 }
 
 type ParserOptions struct {
 	Delimiter   rune
 	CommentChar rune
+	// Dialect fills in the Delimiter, QuoteChar and EscapeChar of a common source of csv files, such as DialectPostgresCopy for files written by PostgreSQL's COPY, where they aren't set.
+	Dialect Dialect
+	// QuoteChar is the character that quotes values, such as a single quote. The zero value keeps the double quote. A doubled quote character inside a quoted value stands for one quote character.
+	QuoteChar rune
+	// EscapeChar is the character that escapes the character after it, such as the backslash of files written by MySQL's SELECT ... INTO OUTFILE, in quoted and unquoted values.
+	// The escape sequences \0, \b, \f, \n, \r, \t, \v and \Z stand for the characters MySQL and PostgreSQL write them for, and a value of only \N, their NULL, is read as an empty value.
+	// The zero value doesn't escape any character.
+	EscapeChar rune
+	// ReuseRecord causes the underlying csv reader to reuse the slice holding each record, as described by encoding/csv.
+	// Values set on string fields are never copied, so they share memory with the string backing the record they were read from. They aren't changed by later reads,
+	// but keeping any one of them keeps the whole line in memory. Set CloneStrings when string fields are kept for longer than the records they belong to.
 	ReuseRecord bool
+	// CloneStrings causes values set on string fields to be copied, so that they don't hold on to the memory of the record they were read from.
+	// Only string fields are copied, so numeric fields still cost no allocations.
+	CloneStrings bool
+	// NullToken is read as an empty value in fields using the omitempty attribute, which are left with their zero value, such as the `NULL` written by a writer with the same NullToken.
+	NullToken string
+	// RejectNonFinite causes NaN and Inf values in float and complex fields to be rejected with ErrorNonFiniteFloat.
+	RejectNonFinite bool
+	// Validator is called by ReadRecord with the struct pointer and line number after every record has been read.
+	// Any error it returns is reported as a RecordError. Use it to plug in a validation library or custom business rules.
+	Validator func(record interface{}, line int) error
+	// Converters are used by this parser in preference to any converters registered with RegisterConverter.
+	Converters map[reflect.Type]Converter
+	// PartialRecords causes ReadRecord to set every field it can, and report the fields that couldn't be set or failed validation together as a PartialRecordError,
+	// rather than stopping at the first. The AfterCsvRecord hook and Validator are not called for a partial record.
+	PartialRecords bool
+	// StrictColumns causes ReadRecord to return an ExtraColumnsError for a record with more columns than the header, or than the highest bound column index when there is no header,
+	// so that a delimiter inside an unquoted value is caught rather than shifting values into the wrong fields.
+	StrictColumns bool
+	// ExtraColumnTolerance is the number of columns a record may have beyond those expected by StrictColumns, such as for files whose records end with a delimiter.
+	ExtraColumnTolerance int
+	// RejectWriter receives every record that ReadRecord reads but can't set or that fails validation, as csv with the error in a leading reason column.
+	// If a header has been parsed, it is written first with a reason column added. ReadRecord still returns the error for the record.
+	RejectWriter io.Writer
+	// AutoDetectHeader causes the first call to ReadRecord to decide whether the first line is a header, when ParseHeader hasn't been called.
+	// The line is taken as a header if it contains the header name of a field, or if a value bound by index doesn't convert to its field's type. Otherwise it is read as a record.
+	AutoDetectHeader bool
+	// UnsafeFastPath causes fields of the default data types to be set by writing converted values directly to the memory of the struct, at field offsets computed once when the struct is bound,
+	// rather than through reflect.Value setters. Values are converted and validated exactly as they are otherwise. Fields with a converter or custom setter are set as usual.
+	// As with any parser, a struct of another type than the one it was first used with is rejected with ErrorBoundTypeMismatch.
+	UnsafeFastPath bool
+	// Profiler gathers statistics for every column of the records the parser reads, including records that are skipped or fail to be set.
+	Profiler *Profiler
+	// DedupeBy names the fields that together make up the key of a record. When it is set, ReadRecord handles records that repeat a key as described by DedupePolicy.
+	// The keys already seen aren't carried across a Checkpoint, so a resumed parser only dedupes the records after it.
+	DedupeBy     []string
+	DedupePolicy DedupePolicy
+	// Filter is called by ReadRecord with each record as it is read, before any field is set, and records it returns false for are skipped.
+	// Use it to drop irrelevant records cheaply, such as those with an inactive status. Skipped records are still counted in line numbers, and are never deduplicated.
+	Filter func(record []string, line int) bool
+	// CellTransform is called with the value of every bound column before it is trimmed, converted and validated, and the value it returns is used in its place.
+	// Use it for cleanup that applies across fields, such as removing stray symbols, rather than implementing CustomSetter. The header is the label of the column in the parsed header, or the header name of the csv tag when no header has been parsed.
+	// The package doesn't normalize Unicode, since that needs tables from outside the standard library, so use CellTransform with a normalizer such as norm.NFC.String of golang.org/x/text.
+	CellTransform func(column int, header string, value string) string
+	// InvalidUTF8 decides what happens to values of bound columns that aren't valid UTF-8. They are checked before CellTransform is applied.
+	InvalidUTF8 UTF8Policy
+	// TagFallback lists the struct tag keys to bind fields with, in order of preference, such as csv then json, so that structs already annotated for another format can be parsed without duplicating every name into csv tags.
+	// A field is bound by the first key it has. A csv tag, or a tag with the key given by TagName, is read as usual, and any other tag is read as a header name, such as the name of a json tag or the name= option of a protobuf tag. When it is empty, only csv tags are used.
+	TagFallback []string
+	// TagName replaces csv as the struct tag key that fields are bound with, so that several independent layouts can be defined on the same struct type, such as with csv and tsv tags.
+	TagName string
+	// Profile selects the attributes of csv tags scoped to a profile, such as profile=vendorA:header:Amt, so that one struct can bind the differently shaped files of several sources.
+	// Attributes that aren't scoped apply in every profile, and fields with only attributes scoped to other profiles are left unbound. When it is empty, only attributes that aren't scoped are used.
+	Profile string
+	// HeaderRenames maps labels of the file's header to the labels to use in their place, and is applied as the header is read, before fields are matched to it.
+	// Use it to adapt to a renamed column at runtime without changing struct tags. Everything that uses the header, such as ReadRecordMap and Checkpoint, sees the new labels.
+	HeaderRenames map[string]string
+	// IndexBase is the number of the first column in index attributes, and may be 0, the default, or 1, so that tags can number columns the way a specification does.
+	IndexBase int
+	// BlankLines decides what happens at blank lines. By default they are skipped, as encoding/csv does, but they can also end a section of a report, or be reported as errors.
+	// Blank lines before the first record and at the end of the file are always skipped, and several blank lines in a row are treated as one.
+	BlankLines BlankLinePolicy
+	// CommentPrefix starts lines that are skipped as comments, like CommentChar, but may be longer than one character, such as // or REM. It can't be used with CommentChar.
+	CommentPrefix string
+	// InlineComments removes the comment prefix, or the comment character, and the rest of the line after it wherever it appears outside of a quoted value, along with any spaces before it.
+	InlineComments bool
+	// FooterRows is the number of records at the end of the file that are footer rows, such as totals, rather than records. They are read ahead of the records above them,
+	// and are passed to FooterHandler, or skipped when it is nil, once the end of the file is reached.
+	FooterRows int
+	// IsFooter recognizes footer rows wherever they appear, such as rows whose first value is TOTAL. Rows it returns true for are passed to FooterHandler, or skipped when it is nil.
+	IsFooter func(record []string) bool
+	// FooterHandler is called with each footer row and its line, so that totals can be checked against the records that were read. Any error it returns is reported as a RecordError.
+	// Footer rows may have fewer columns than the records above them.
+	FooterHandler func(record []string, line int) error
+	// Reconcile compares the number of records, and optionally a column sum or a hash of the records, with the totals declared in the trailer, which is found among the footer rows.
+	// Mismatches are returned as a ReconciliationError, as is reaching the end of the file without a trailer.
+	Reconcile *Reconciliation
+	// HeaderNaming derives the header name of a field tagged with a header attribute without a value, such as csv:"header", from its field name. It defaults to NameFieldName, which uses the field name as it is.
+	HeaderNaming NamingStrategy
+	// FieldNamer derives header names from fields in place of HeaderNaming, so that an organization's own naming conventions can be applied to every struct without writing out each header name.
+	FieldNamer FieldNamer
+	// DeriveHeaders binds every exported field without a csv tag by a header name derived from its field name with HeaderNaming or FieldNamer, so that large structs need tags only where a name differs. Fields tagged with csv:"-" are left unbound.
+	DeriveHeaders bool
+	// MaskOnRead applies the maskers named by mask attributes to values as they are read, after they are trimmed, so that a file holding sensitive values can be loaded without keeping them.
+	// Masked values must still be valid for their fields, so it suits string fields.
+	MaskOnRead bool
+	// Maskers are used by this parser in preference to any maskers registered with RegisterMasker.
+	Maskers map[string]Masker
+	// FieldDecryptor decrypts the values of fields with the encrypted attribute as they are read. Reading such a field without one returns ErrorMissingFieldDecryptor.
+	FieldDecryptor FieldDecryptor
+}
+
+func (p *Parser) tagOptions() tagOptions {
+	return tagOptions{
+		converters:    p.options.Converters,
+		tagName:       p.options.TagName,
+		tagFallback:   p.options.TagFallback,
+		profile:       p.options.Profile,
+		indexBase:     p.options.IndexBase,
+		headerNaming:  p.options.HeaderNaming,
+		fieldNamer:    p.options.FieldNamer,
+		deriveHeaders: p.options.DeriveHeaders,
+		maskers:       p.options.Maskers,
+	}
 }
 
 func legalDelimiter(d rune) bool {
@@ -165,50 +861,109 @@ func legalDelimiter(d rune) bool {
 
 // NewParser creates a new csv parser for the provided file that supports the csv struct decorator tag.
 // Use ParserOptions to specify any desired changed from the default behavior as defined in the standard csv parser library.
+// If the options are invalid, every read returns the OptionError reported by ParserOptions.Validate.
 func NewParser(file io.Reader, options ParserOptions) (p Parser) {
-	p.reader = csv.NewReader(file)
-	p.csvAttrs = make(map[string]csvAttributes)
+	p = NewRecordParser(newParserReader(file, options), options)
+	p.file = file
+
+	err := options.Validate()
+	if err != nil {
+		p.reader = errorReader{err: err}
+	}
+
+	return p
+}
+
+// newCsvReader creates a standard library csv reader for file that is configured by options. Comments the standard library doesn't support are removed by a line filter.
+func newCsvReader(file io.Reader, options ParserOptions) (reader *csv.Reader) {
+	if _, filtered := file.(*lineFilter); !filtered && (options.CommentPrefix != "" || options.InlineComments) {
+		file = newLineFilter(file, options)
+	}
+
+	reader = csv.NewReader(file)
 
 	// Keep default value if zero-value rune is passed in
 	if legalDelimiter(options.Delimiter) {
-		p.reader.Comma = options.Delimiter
+		reader.Comma = options.Delimiter
 	}
 
-	p.reader.Comment = options.CommentChar
+	reader.Comment = options.CommentChar
 
-	p.reader.ReuseRecord = options.ReuseRecord
+	reader.ReuseRecord = options.ReuseRecord
 
-	return p
+	return reader
 }
 
-// ParseHeader reads the first line of the parser's csv file and interpret's the data as headers described by the csv decorator tags defined on structPointer.
-// The structPointer should be pointer to a struct with csv decorator tags applied.
-func (p *Parser) ParseHeader(structPointer interface{}) (err error) {
-	header, err := p.reader.Read()
+// ParseHeader reads the first line of the parser's csv file and interpret's the data as headers described by the csv decorator tags defined on structType.
+// Only the type of structType is used, so it may be a struct with csv decorator tags applied, a pointer to one, even a nil one, the reflect.Type of either,
+// or a Binding returned by another parser's BindHeader. Fields bound by index keep their index, so a struct may mix both kinds of binding.
+// A field with both a header and an index attribute is bound by header name, and falls back to its index when the header name isn't found.
+func (p *Parser) ParseHeader(structType interface{}) (err error) {
+	binding, isBinding := structType.(*Binding)
+	var structPointer interface{}
+	if !isBinding {
+		structPointer, err = structPointerFor(structType)
+		if err != nil {
+			return err
+		}
+	} else if binding == nil {
+		return ArgumentError{
+			Received: "nil *csv.Binding",
+			Err:      ErrorInvalidArgument,
+		}
+	}
+
+	structPointerType := reflect.TypeOf(structPointer)
+	if isBinding {
+		structPointerType = binding.structType
+	}
+	err = p.checkBoundType(structPointerType)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.readHeader()
 
 	if err != nil {
 		return err
 	}
 
-	if len(p.csvAttrs) == 0 {
-		p.csvAttrs, err = getCsvAttributes(structPointer)
-		if err != nil {
-			return err
-		}
+	if len(p.csvAttrs) != 0 {
+		return p.resolveHeader()
 	}
 
+	if isBinding {
+		p.restoreBinding(binding)
+		return p.resolveHeader()
+	}
+
+	return p.bind(structPointer)
+}
+
+// resolveHeader sets the column index of each field bound by header to the column of the parsed header with its header name.
+// Fields bound only by index keep their index, and fields with both attributes fall back to their index when the header name isn't found.
+// Fields bound by headerRegex are given every column whose label matches, which may be none.
+func (p *Parser) resolveHeader() (err error) {
 	for fieldName, csvAttrs := range p.csvAttrs {
-		var foundIdx = false
-
-		for idx, headerLabel := range header {
-			if headerLabel == csvAttrs.headerName {
-				csvAttrs.columnIndex = idx
-				p.csvAttrs[fieldName] = csvAttrs
-				foundIdx = true
-				break
+		if csvAttrs.headerPattern != nil {
+			csvAttrs.patternColumns = nil
+			for idx, label := range p.header {
+				if csvAttrs.headerPattern.MatchString(label) {
+					csvAttrs.patternColumns = append(csvAttrs.patternColumns, idx)
+				}
 			}
+			p.csvAttrs[fieldName] = csvAttrs
+			continue
+		}
+
+		if !csvAttrs.hasHeader {
+			continue
 		}
 
+		idx, foundIdx := findHeaderIndex(p.header, csvAttrs.headerName)
+		if !foundIdx && csvAttrs.hasIndex {
+			continue
+		}
 		if !foundIdx {
 			return FieldNotFoundError{
 				FieldName:  fieldName,
@@ -216,6 +971,9 @@ func (p *Parser) ParseHeader(structPointer interface{}) (err error) {
 				Err:        ErrorFieldNotFound,
 			}
 		}
+
+		csvAttrs.columnIndex = idx
+		p.csvAttrs[fieldName] = csvAttrs
 	}
 
 	return nil
@@ -225,25 +983,54 @@ func (p *Parser) ParseHeader(structPointer interface{}) (err error) {
 // The structPointer should be pointer to a struct with csv decorator tags applied, and data from the appropriate column in the csv file will be set on the fields of structPointer.
 func (p *Parser) ReadRecord(structPointer interface{}) (err error) {
 
-	if len(p.csvAttrs) == 0 {
-		p.csvAttrs, err = getCsvAttributes(structPointer)
+	err = p.bind(structPointer)
+	if err != nil {
+		return err
+	}
+
+	if p.options.AutoDetectHeader && !p.headerChecked && p.header == nil {
+		err = p.detectHeader(structPointer)
 		if err != nil {
 			return err
 		}
 	}
 
-	p.line++
-	readRecord, err := p.reader.Read()
+	if p.needsHeader && p.header == nil {
+		return ErrorHeaderNotParsed
+	}
 
+	readRecord, err := p.readKeptRecord()
 	if err != nil {
 		return err
 	}
 
-	for fieldName, csvAttrs := range p.csvAttrs {
-		idx := csvAttrs.columnIndex
-		value := readRecord[idx]
-		err := p.setFieldValue(structPointer, fieldName, value)
+	err = p.setRecord(structPointer, readRecord)
+	if err != nil && p.options.RejectWriter != nil {
+		rejectErr := p.reject(readRecord, err)
+		if rejectErr != nil {
+			return rejectErr
+		}
+	}
+
+	return err
+}
+
+// setRecordField sets the value of the named field from readRecord unless a decoder has already set it, and checks the value against the field's validation rules.
+// It returns a SetValueError or a ValidationError.
+func (p *Parser) setRecordField(structPointer interface{}, fieldName string, readRecord []string) (err error) {
+	csvAttrs := p.csvAttrs[fieldName]
+
+	if csvAttrs.headerPattern != nil {
+		return p.setPatternField(structPointer, fieldName, csvAttrs, readRecord)
+	}
 
+	value, err := p.cellValue(fieldName, csvAttrs, csvAttrs.columnIndex, readRecord)
+	if err != nil {
+		return err
+	}
+
+	if !p.useDecoder {
+		err := p.setFieldValue(structPointer, fieldName, csvAttrs, value)
 		if err != nil {
 			return SetValueError{
 				Line:      p.line,
@@ -254,70 +1041,401 @@ func (p *Parser) ReadRecord(structPointer interface{}) (err error) {
 		}
 	}
 
+	rule, err := validateFieldValue(structPointer, fieldName, csvAttrs, value)
+	if err != nil {
+		return ValidationError{
+			Line:      p.line,
+			Value:     value,
+			FieldName: fieldName,
+			Rule:      rule,
+			Err:       err,
+		}
+	}
+
 	return nil
 }
 
-func (p *Parser) setFieldValue(structPointer interface{}, fieldName string, value string) (err error) {
-	inStruct := reflect.ValueOf(structPointer)
-	field := inStruct.Elem().FieldByName(fieldName)
+// cellValue returns the value of the given column of readRecord for the named field, once the InvalidUTF8 and CellTransform options, decryption, trim and MaskOnRead have been applied.
+func (p *Parser) cellValue(fieldName string, attrs csvAttributes, idx int, readRecord []string) (value string, err error) {
+	if idx < 0 || idx >= len(readRecord) {
+		return "", SetValueError{
+			Line:      p.line,
+			FieldName: fieldName,
+			Err:       ErrorColumnOutOfRange,
+		}
+	}
+
+	value, err = p.checkUTF8(fieldName, idx, readRecord[idx])
+	if err != nil {
+		return "", err
+	}
+
+	if p.options.CellTransform != nil {
+		header := attrs.headerName
+		if idx < len(p.header) {
+			header = p.header[idx]
+		}
+		value = p.options.CellTransform(idx, header, value)
+	}
+
+	decrypted, err := p.decryptValue(fieldName, attrs, value)
+	if err != nil {
+		return "", SetValueError{
+			Line:      p.line,
+			Value:     value,
+			FieldName: fieldName,
+			Err:       err,
+		}
+	}
+	value = decrypted
+
+	if attrs.trim {
+		value = strings.TrimSpace(value)
+	}
+
+	if p.options.MaskOnRead {
+		value = attrs.applyMask(value)
+	}
 
-	if p.csvAttrs[fieldName].useCustomSetter {
-		method := inStruct.MethodByName("CustomSetter")
-		inputs := make([]reflect.Value, 2)
-		inputs[0] = reflect.ValueOf(fieldName)
-		inputs[1] = reflect.ValueOf(value)
+	return value, nil
+}
 
-		out := method.Call(inputs)[0]
+// Line returns the line number of the most recently read record. Records are counted from 1, and the header is not counted, so it matches the line reported in errors.
+func (p *Parser) Line() int {
+	return p.line
+}
+
+// setRecord sets the values of readRecord on the fields of structPointer, and runs the validation rules, hooks and validator for the record.
+func (p *Parser) setRecord(structPointer interface{}, readRecord []string) (err error) {
+	if p.options.StrictColumns {
+		err = p.checkExtraColumns(readRecord, p.boundColumns())
+		if err != nil {
+			return err
+		}
+	}
 
-		if !out.IsZero() {
-			return fmt.Errorf("%v", out)
+	if p.useDecoder {
+		err = p.decodeRecord(structPointer.(RecordDecoder), readRecord)
+		if err != nil {
+			return err
 		}
+	}
 
+	var fieldErrs []error
+	for _, fieldName := range p.fieldOrder {
+		err = p.setRecordField(structPointer, fieldName, readRecord)
+		if err == nil {
+			continue
+		}
+
+		if !p.options.PartialRecords {
+			return err
+		}
+		fieldErrs = append(fieldErrs, err)
+	}
+
+	if len(fieldErrs) > 0 {
+		return PartialRecordError{
+			Line:   p.line,
+			Errors: fieldErrs,
+			Err:    ErrorPartialRecord,
+		}
+	}
+
+	if hook, ok := structPointer.(AfterCsvRecordHook); ok {
+		err = hook.AfterCsvRecord(p.line)
+		if err != nil {
+			return RecordError{
+				Line: p.line,
+				Err:  err,
+			}
+		}
+	}
+
+	if p.options.Validator != nil {
+		err = p.options.Validator(structPointer, p.line)
+		if err != nil {
+			return RecordError{
+				Line: p.line,
+				Err:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Parser) bind(structPointer interface{}) (err error) {
+	err = checkStructPointer(structPointer)
+	if err != nil {
+		return err
+	}
+
+	if len(p.csvAttrs) != 0 {
+		return p.checkBoundType(reflect.TypeOf(structPointer))
+	}
+
+	p.boundType = reflect.TypeOf(structPointer)
+	if p.pool == nil || !p.pool.restoreBinding(p, structPointer) {
+		p.csvAttrs, err = getCsvAttributes(structPointer, p.tagOptions())
+		if err != nil {
+			return err
+		}
+
+		p.fieldOrder = getFieldOrder(p.csvAttrs)
+		_, implementsDecoder := structPointer.(RecordDecoder)
+		p.useDecoder = implementsDecoder && p.canUseCodec()
+
+		for _, attrs := range p.csvAttrs {
+			if (attrs.hasHeader && !attrs.hasIndex) || attrs.headerPattern != nil {
+				p.needsHeader = true
+			}
+		}
+
+		p.keepBinding(structPointer)
+		if p.pool != nil {
+			p.pool.storeBinding(p.binding)
+		}
+	}
+
+	if p.header != nil {
+		return p.resolveHeader()
+	}
+
+	return nil
+}
+
+// checkBoundType returns an ArgumentError when structPointerType isn't a pointer to the struct type the parser was bound to, since the bound attributes, and the field offsets
+// the unsafe fast path writes to, only describe that type.
+func (p *Parser) checkBoundType(structPointerType reflect.Type) (err error) {
+	if p.boundType == nil || structPointerType == p.boundType {
 		return nil
 	}
 
-	switch field.Interface().(type) {
-	case string:
+	return ArgumentError{
+		Received: structPointerType.String(),
+		Err:      ErrorBoundTypeMismatch,
+	}
+}
+
+// findHeaderIndex returns the index of the first column in header with the given label.
+func findHeaderIndex(header []string, label string) (idx int, found bool) {
+	for idx, headerLabel := range header {
+		if headerLabel == label {
+			return idx, true
+		}
+	}
+
+	return -1, false
+}
+
+// readHeader reads the next line of the parser's csv file and keeps a copy of it as the header, with any HeaderRenames applied.
+func (p *Parser) readHeader() (header []string, err error) {
+	if p.recordPeek != nil {
+		// A record kept by Peek is the next line, so it is the header. Headers don't take a line number.
+		header, err = p.recordPeek.record, p.recordPeek.err
+		p.recordPeek = nil
+	} else if reader, ok := p.reader.(headerReader); ok {
+		header, err = reader.readHeader()
+	} else {
+		header, err = p.reader.Read()
+	}
+	if err != nil {
+		return header, err
+	}
+
+	p.header = p.renameHeader(header)
+
+	return p.header, nil
+}
+
+// renameHeader returns a copy of header with the labels listed in HeaderRenames renamed.
+func (p *Parser) renameHeader(header []string) (renamed []string) {
+	renamed = make([]string, len(header))
+	for idx, label := range header {
+		if newLabel, ok := p.options.HeaderRenames[label]; ok {
+			label = newLabel
+		}
+		renamed[idx] = label
+	}
+
+	return renamed
+}
+
+func (p *Parser) readRecord() (record []string, err error) {
+	if p.hasPeeked {
+		p.hasPeeked = false
+		record, err = p.peeked, p.peekErr
+	} else {
+		record, err = p.nextRecord()
+	}
+
+	p.line++
+
+	// Blank lines aren't records, so they don't take a line number.
+	if errors.Is(err, ErrorBlankLine) || err == ErrorEndOfSection {
+		p.line--
+		p.sectionEnded = err == ErrorEndOfSection
+	}
+
+	if err == nil && p.options.Profiler != nil {
+		p.options.Profiler.observe(p.header, record)
+	}
+
+	return record, err
+}
+
+// readKeptRecord reads the next record that the Filter and DedupeBy options keep, or returns the one kept by Peek.
+func (p *Parser) readKeptRecord() (record []string, err error) {
+	if p.recordPeek != nil {
+		return p.takePeekedRecord()
+	}
+
+	if len(p.options.DedupeBy) > 0 {
+		return p.readDedupedRecord()
+	}
+
+	return p.readFilteredRecord()
+}
+
+// readNextRecord reads the next record of the file without applying the Filter and DedupeBy options, or returns the one kept by Peek, which is always the next record read.
+func (p *Parser) readNextRecord() (record []string, err error) {
+	if p.recordPeek != nil {
+		return p.takePeekedRecord()
+	}
+
+	return p.readRecord()
+}
+
+// takePeekedRecord returns the record kept by Peek, and sets the line to the one it was read on.
+func (p *Parser) takePeekedRecord() (record []string, err error) {
+	peek := p.recordPeek
+	p.recordPeek = nil
+	p.line = peek.line
+
+	return peek.record, peek.err
+}
+
+// readFilteredRecord reads the next record that the Filter option keeps. Records that can't be read are always returned, along with their error.
+func (p *Parser) readFilteredRecord() (record []string, err error) {
+	for {
+		record, err = p.readRecord()
+		if err != nil || p.options.Filter == nil || p.options.Filter(record, p.line) {
+			return record, err
+		}
+	}
+}
+
+// isNull reports whether value leaves a field using the omitempty attribute with its zero value, as an empty value or the NullToken does.
+func (p *Parser) isNull(attrs csvAttributes, value string) bool {
+	return attrs.omitempty && (value == "" || (p.options.NullToken != "" && value == p.options.NullToken))
+}
+
+// setFieldValue sets value on the named field of structPointer as described by attrs. The field is found by its index rather than its name,
+// since this is called for every cell.
+func (p *Parser) setFieldValue(structPointer interface{}, fieldName string, attrs csvAttributes, value string) (err error) {
+	if p.options.UnsafeFastPath && attrs.unsafeFastPath && !p.isNull(attrs, value) {
+		return p.setValueUnsafe(structPointer, attrs, value)
+	}
+
+	field := reflect.ValueOf(structPointer).Elem().Field(attrs.fieldIndex)
+
+	if p.isNull(attrs, value) {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	if attrs.useCustomSetter {
+		// The error is returned as is, so that it can be inspected with errors.Is and errors.As once wrapped in a SetValueError.
+		if setter, ok := structPointer.(CustomSetterV2); ok {
+			return setter.CustomSetterV2(p.fieldInfo(fieldName), value)
+		}
+
+		return structPointer.(CustomSetter).CustomSetter(fieldName, value)
+	}
+
+	return p.setValue(field, attrs, value)
+}
+
+// fieldInfo describes the field with the given name, and the column and line its value is being read from.
+func (p *Parser) fieldInfo(fieldName string) (info FieldInfo) {
+	attrs := p.csvAttrs[fieldName]
+
+	info = FieldInfo{
+		FieldName:   fieldName,
+		HeaderName:  attrs.headerName,
+		ColumnIndex: attrs.columnIndex,
+		Line:        p.line,
+	}
+
+	if attrs.columnIndex < len(p.header) {
+		info.HeaderName = p.header[attrs.columnIndex]
+	}
+
+	return info
+}
+
+// setValue converts value as described by attrs, and sets it on field.
+// It switches on the kind of the field rather than its boxed value so that setting a value doesn't allocate, and rejects named types, which need a converter or a custom setter.
+func (p *Parser) setValue(field reflect.Value, attrs csvAttributes, value string) (err error) {
+	if attrs.converter != nil && attrs.converter.Parse != nil {
+		return setConvertedValue(field, attrs.converter, value)
+	}
+
+	if attrs.currency {
+		value = stripCurrency(value)
+	}
+
+	if field.Type().PkgPath() != "" {
+		return ErrorUnsupportedDataType
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		if p.options.CloneStrings {
+			value = cloneString(value)
+		}
 		field.SetString(value)
-	case bool:
+	case reflect.Bool:
 		boolValue, err := strconv.ParseBool(value)
 		if err != nil {
 			return err
 		}
 		field.SetBool(boolValue)
-	case int, int8, int16, int32, int64:
-		intValue, err := strconv.Atoi(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Values are parsed at the field's size, so that a value that would overflow it is an error rather than wrapping around.
+		intValue, err := strconv.ParseInt(value, 10, field.Type().Bits())
 		if err != nil {
 			return err
 		}
-		field.SetInt(int64(intValue))
-	case uint, uint8, uint16, uint32, uint64:
-		uintValue, err := strconv.ParseUint(value, 10, 64)
+		field.SetInt(intValue)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintValue, err := strconv.ParseUint(value, 10, field.Type().Bits())
 		if err != nil {
 			return err
 		}
 		field.SetUint(uintValue)
-	case float32:
-		floatValue, err := strconv.ParseFloat(value, 32)
+	case reflect.Float32:
+		floatValue, err := p.parseFloat(attrs, value, 32)
 		if err != nil {
 			return err
 		}
 		field.SetFloat(floatValue)
-	case float64:
-		floatValue, err := strconv.ParseFloat(value, 64)
+	case reflect.Float64:
+		floatValue, err := p.parseFloat(attrs, value, 64)
 		if err != nil {
 			return err
 		}
 		field.SetFloat(floatValue)
 
-	case complex64:
-		cmplxValue, err := strconv.ParseComplex(value, 64)
+	case reflect.Complex64:
+		cmplxValue, err := p.parseComplex(value, 64)
 		if err != nil {
 			return err
 		}
 		field.SetComplex(cmplxValue)
-	case complex128:
-		cmplxValue, err := strconv.ParseComplex(value, 128)
+	case reflect.Complex128:
+		cmplxValue, err := p.parseComplex(value, 128)
 		if err != nil {
 			return err
 		}
@@ -329,6 +1447,67 @@ func (p *Parser) setFieldValue(structPointer interface{}, fieldName string, valu
 	return nil
 }
 
+func (p *Parser) parseFloat(attrs csvAttributes, value string, bitSize int) (floatValue float64, err error) {
+	if attrs.percent {
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	}
+
+	floatValue, err = strconv.ParseFloat(value, bitSize)
+	if err != nil {
+		return floatValue, err
+	}
+
+	if p.options.RejectNonFinite && (math.IsNaN(floatValue) || math.IsInf(floatValue, 0)) {
+		return floatValue, ErrorNonFiniteFloat
+	}
+
+	if attrs.percent {
+		floatValue /= 100
+	}
+
+	if attrs.hasPrecision {
+		floatValue = roundFloat(floatValue, attrs.precision, bitSize)
+	}
+
+	return floatValue, nil
+}
+
+func (p *Parser) parseComplex(value string, bitSize int) (cmplxValue complex128, err error) {
+	cmplxValue, err = strconv.ParseComplex(value, bitSize)
+	if err != nil {
+		return cmplxValue, err
+	}
+
+	if p.options.RejectNonFinite && (cmplx.IsNaN(cmplxValue) || cmplx.IsInf(cmplxValue)) {
+		return cmplxValue, ErrorNonFiniteFloat
+	}
+
+	return cmplxValue, nil
+}
+
+// cloneString returns a copy of value that doesn't share its memory.
+func cloneString(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.Grow(len(value))
+	b.WriteString(value)
+	return b.String()
+}
+
+// stripCurrency removes currency symbols, thousands separators and whitespace from value,
+// leaving a plain number that strconv can parse.
+func stripCurrency(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ',' || unicode.IsSpace(r) || unicode.Is(unicode.Sc, r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
 type CsvTagDefError struct {
 	CsvTag    string
 	FieldName string
@@ -341,6 +1520,21 @@ func (e CsvTagDefError) Error() string {
 
 func (e CsvTagDefError) Unwrap() error { return e.Err }
 
+// DuplicateBindingError is held by the CsvTagDefError of a field bound to the same header or index as an earlier field, unless both have the allowShared attribute.
+// Err is ErrorDuplicateHeader or ErrorDuplicateIndex, and the error also matches ErrorDuplicateBinding.
+type DuplicateBindingError struct {
+	BoundFieldName string
+	Err            error
+}
+
+func (e DuplicateBindingError) Error() string {
+	return fmt.Sprintf("column is already bound to field %s: %v", e.BoundFieldName, e.Err)
+}
+
+func (e DuplicateBindingError) Unwrap() error { return e.Err }
+
+func (e DuplicateBindingError) Is(target error) bool { return target == ErrorDuplicateBinding }
+
 type FieldNotFoundError struct {
 	FieldName  string
 	HeaderName string
@@ -365,3 +1559,39 @@ func (e SetValueError) Error() string {
 }
 
 func (e SetValueError) Unwrap() error { return e.Err }
+
+// PartialRecordError is returned by ReadRecord when the PartialRecords option is set and some fields of a record couldn't be set.
+// Errors holds a SetValueError or ValidationError for each of those fields, in struct order. Every other field has been set.
+type PartialRecordError struct {
+	Line   int
+	Errors []error
+	Err    error
+}
+
+func (e PartialRecordError) Error() string {
+	return fmt.Sprintf("record on line %d: %d fields could not be set, the first with: %v", e.Line, len(e.Errors), e.Errors[0])
+}
+
+func (e PartialRecordError) Unwrap() error { return e.Err }
+
+type ArgumentError struct {
+	Received string
+	Err      error
+}
+
+func (e ArgumentError) Error() string {
+	return fmt.Sprintf("problem with argument of type %s: %v", e.Received, e.Err)
+}
+
+func (e ArgumentError) Unwrap() error { return e.Err }
+
+type RecordError struct {
+	Line int
+	Err  error
+}
+
+func (e RecordError) Error() string {
+	return fmt.Sprintf("record on line %d: %v", e.Line, e.Err)
+}
+
+func (e RecordError) Unwrap() error { return e.Err }
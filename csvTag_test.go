@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -327,3 +329,755 @@ func TestFieldNotFoundError(t *testing.T) {
 		t.Errorf("expected to encounter Field Not Found error, but got %v", err)
 	}
 }
+
+type precisionTest struct {
+	Price float64 `csv:"index:0;precision:2"`
+	Rate  float32 `csv:"index:1;precision:1"`
+}
+
+func TestPrecision(t *testing.T) {
+	p := NewParser(strings.NewReader("19.98999999,0.26"), ParserOptions{})
+
+	data := precisionTest{}
+	err := p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with precision: %v", err)
+	}
+	if data.Price != 19.99 {
+		t.Errorf("improperly rounded Price. Got '%v' but expected '%v'", data.Price, 19.99)
+	}
+	if data.Rate != float32(0.3) {
+		t.Errorf("improperly rounded Rate. Got '%v' but expected '%v'", data.Rate, float32(0.3))
+	}
+}
+
+type invalidPrecision struct {
+	Price float64 `csv:"index:0;precision:-2"`
+}
+
+func TestInvalidPrecisionError(t *testing.T) {
+	p := NewParser(strings.NewReader("19.99"), ParserOptions{})
+
+	err := p.ReadRecord(&invalidPrecision{})
+	if err == nil {
+		t.Errorf("expected to encounter Invalid Precision error, but got none")
+	}
+	if !errors.Is(err, ErrorInvalidPrecision) {
+		t.Errorf("expected to encounter Invalid Precision error, but got %v", err)
+	}
+}
+
+type precisionNotFloat struct {
+	Count int `csv:"index:0;precision:2"`
+}
+
+func TestPrecisionNotFloatError(t *testing.T) {
+	p := NewParser(strings.NewReader("19"), ParserOptions{})
+
+	err := p.ReadRecord(&precisionNotFloat{})
+	if err == nil {
+		t.Errorf("expected to encounter Precision Not Float error, but got none")
+	}
+	if !errors.Is(err, ErrorPrecisionNotFloat) {
+		t.Errorf("expected to encounter Precision Not Float error, but got %v", err)
+	}
+}
+
+type percentCurrencyTest struct {
+	Discount float64 `csv:"index:0;percent"`
+	Price    float64 `csv:"index:1;currency;precision:2"`
+	Units    int     `csv:"index:2;currency"`
+}
+
+func TestPercentAndCurrency(t *testing.T) {
+	p := NewParser(strings.NewReader(`12.5%,"$1,234.50","€ 1,000"`), ParserOptions{})
+
+	data := percentCurrencyTest{}
+	err := p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with percent and currency: %v", err)
+	}
+	if data.Discount != 0.125 {
+		t.Errorf("improperly parsed Discount. Got '%v' but expected '%v'", data.Discount, 0.125)
+	}
+	if data.Price != 1234.5 {
+		t.Errorf("improperly parsed Price. Got '%v' but expected '%v'", data.Price, 1234.5)
+	}
+	if data.Units != 1000 {
+		t.Errorf("improperly parsed Units. Got '%v' but expected '%v'", data.Units, 1000)
+	}
+}
+
+type percentNotFloat struct {
+	Discount int `csv:"index:0;percent"`
+}
+
+func TestPercentNotFloatError(t *testing.T) {
+	p := NewParser(strings.NewReader("12%"), ParserOptions{})
+
+	err := p.ReadRecord(&percentNotFloat{})
+	if !errors.Is(err, ErrorPercentNotFloat) {
+		t.Errorf("expected to encounter Percent Not Float error, but got %v", err)
+	}
+}
+
+type specialFloatTest struct {
+	Scientific float64 `csv:"index:0"`
+	PosInf     float64 `csv:"index:1"`
+	NegInf     float32 `csv:"index:2"`
+	NotANumber float64 `csv:"index:3"`
+}
+
+func TestSpecialFloatTokens(t *testing.T) {
+	p := NewParser(strings.NewReader("1.2E+5,Inf,-inf,NaN"), ParserOptions{})
+
+	data := specialFloatTest{}
+	err := p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing special float tokens: %v", err)
+	}
+	if data.Scientific != 120000 {
+		t.Errorf("improperly parsed Scientific. Got '%v' but expected '%v'", data.Scientific, 120000)
+	}
+	if !math.IsInf(data.PosInf, 1) {
+		t.Errorf("improperly parsed PosInf. Got '%v' but expected '+Inf'", data.PosInf)
+	}
+	if !math.IsInf(float64(data.NegInf), -1) {
+		t.Errorf("improperly parsed NegInf. Got '%v' but expected '-Inf'", data.NegInf)
+	}
+	if !math.IsNaN(data.NotANumber) {
+		t.Errorf("improperly parsed NotANumber. Got '%v' but expected 'NaN'", data.NotANumber)
+	}
+}
+
+func TestNonFiniteFloatError(t *testing.T) {
+	p := NewParser(strings.NewReader("1.2E+5,Inf,-inf,NaN"), ParserOptions{RejectNonFinite: true})
+
+	err := p.ReadRecord(&specialFloatTest{})
+	if !errors.Is(err, ErrorNonFiniteFloat) {
+		t.Errorf("expected to encounter Non Finite Float error, but got %v", err)
+	}
+}
+
+var errorUnbalancedTotal = fmt.Errorf("total does not match sum of parts")
+
+type afterRecordTest struct {
+	Part1 int `csv:"index:0"`
+	Part2 int `csv:"index:1"`
+	Total int `csv:"index:2"`
+	Line  int
+}
+
+func (art *afterRecordTest) AfterCsvRecord(line int) (err error) {
+	art.Line = line
+	if art.Part1+art.Part2 != art.Total {
+		return errorUnbalancedTotal
+	}
+	return nil
+}
+
+func TestAfterCsvRecordHook(t *testing.T) {
+	p := NewParser(strings.NewReader("1,2,3\n4,5,6"), ParserOptions{})
+
+	data := afterRecordTest{}
+	err := p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with after record hook: %v", err)
+	}
+	if data.Line != 1 {
+		t.Errorf("after record hook received line %d but expected 1", data.Line)
+	}
+
+	err = p.ReadRecord(&data)
+	if !errors.Is(err, errorUnbalancedTotal) {
+		t.Errorf("expected to encounter error from after record hook, but got %v", err)
+	}
+	var recordErr RecordError
+	if !errors.As(err, &recordErr) || recordErr.Line != 2 {
+		t.Errorf("expected a RecordError on line 2, but got %v", err)
+	}
+}
+
+func TestHeaderNotParsedError(t *testing.T) {
+	p := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+
+	err := p.ReadRecord(&headerTest{})
+	if !errors.Is(err, ErrorHeaderNotParsed) {
+		t.Errorf("expected to encounter Header Not Parsed error, but got %v", err)
+	}
+
+	err = p.ParseHeader(&headerTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header after Header Not Parsed error: %v", err)
+	}
+
+	data := headerTest{}
+	err = p.ReadRecord(&data)
+	if err != nil || data.Field1 != headerTestResults[0].Field1 {
+		t.Errorf("expected to read the first record after parsing the header, but read '%+v' and got %v", data, err)
+	}
+}
+
+type mixedBindingTest struct {
+	ID   int    `csv:"index:0"`
+	Name string `csv:"header:name"`
+	Code string `csv:"header:code;index:2"`
+}
+
+func TestMixedHeaderAndIndex(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected mixedBindingTest
+	}{
+		{data: "id,code,name\n7,X1,widget", expected: mixedBindingTest{ID: 7, Name: "widget", Code: "X1"}},
+		{data: "id,name,misc\n7,widget,X2", expected: mixedBindingTest{ID: 7, Name: "widget", Code: "X2"}},
+	}
+
+	for _, test := range tests {
+		p := NewParser(strings.NewReader(test.data), ParserOptions{})
+
+		err := p.ParseHeader(&mixedBindingTest{})
+		if err != nil {
+			t.Errorf("encountered error parsing csv header with mixed bindings: %v", err)
+		}
+
+		data := mixedBindingTest{}
+		err = p.ReadRecord(&data)
+		if err != nil {
+			t.Errorf("encountered error parsing csv with mixed bindings: %v", err)
+		}
+		if data != test.expected {
+			t.Errorf("improperly parsed csv with mixed bindings. Got '%+v' but expected '%+v'", data, test.expected)
+		}
+	}
+
+	p := NewParser(strings.NewReader("id,code\n7,X1"), ParserOptions{})
+	err := p.ParseHeader(&mixedBindingTest{})
+	if !errors.Is(err, ErrorFieldNotFound) {
+		t.Errorf("expected to encounter Field Not Found error, but got %v", err)
+	}
+}
+
+var errorUnknownColor = fmt.Errorf("unknown color")
+
+type customSetterErrorTest struct {
+	Color string `csv:"index:0;useCustomSetter"`
+}
+
+func (cset *customSetterErrorTest) CustomSetter(fieldName string, value string) (err error) {
+	if value != "red" && value != "blue" {
+		return errorUnknownColor
+	}
+	cset.Color = value
+	return nil
+}
+
+func TestCustomSetterErrorWrapping(t *testing.T) {
+	p := NewParser(strings.NewReader("red\nmauve"), ParserOptions{})
+
+	err := p.ReadRecord(&customSetterErrorTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv with custom setter: %v", err)
+	}
+
+	err = p.ReadRecord(&customSetterErrorTest{})
+	if !errors.Is(err, errorUnknownColor) {
+		t.Errorf("expected to encounter error from custom setter, but got %v", err)
+	}
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 2 || setValueErr.Value != "mauve" {
+		t.Errorf("expected a SetValueError for mauve on line 2, but got %v", err)
+	}
+}
+
+type customSetterV2Test struct {
+	ID     int     `csv:"index:0"`
+	Amount float64 `csv:"header:amount_usd;useCustomSetter"`
+	Fee    float64 `csv:"header:fee_cents;useCustomSetter"`
+	fields []FieldInfo
+}
+
+func (csv2 *customSetterV2Test) CustomSetterV2(field FieldInfo, value string) (err error) {
+	csv2.fields = append(csv2.fields, field)
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(field.HeaderName, "_cents") {
+		amount /= 100
+	}
+
+	if field.FieldName == "Amount" {
+		csv2.Amount = amount
+	} else {
+		csv2.Fee = amount
+	}
+
+	return nil
+}
+
+func TestCustomSetterV2(t *testing.T) {
+	p := NewParser(strings.NewReader("id,fee_cents,amount_usd\n1,250,12.5"), ParserOptions{})
+
+	err := p.ParseHeader(&customSetterV2Test{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	data := customSetterV2Test{}
+	err = p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with CustomSetterV2: %v", err)
+	}
+	if data.Amount != 12.5 || data.Fee != 2.5 {
+		t.Errorf("improperly set values with CustomSetterV2. Got '%+v'", data)
+	}
+
+	for _, field := range data.fields {
+		if field.FieldName == "Fee" && (field.HeaderName != "fee_cents" || field.ColumnIndex != 1 || field.Line != 1) {
+			t.Errorf("improper field info passed to CustomSetterV2. Got '%+v'", field)
+		}
+	}
+}
+
+func TestInvalidArgumentError(t *testing.T) {
+	var nilPointer *headerTest
+	arguments := []struct {
+		argument interface{}
+		received string
+		// typeOnly is set for arguments that describe a struct type without pointing to a struct, which ParseHeader accepts.
+		typeOnly bool
+	}{
+		{argument: headerTest{}, received: "csv.headerTest", typeOnly: true},
+		{argument: nilPointer, received: "*csv.headerTest", typeOnly: true},
+		{argument: new(string), received: "*string"},
+		{argument: nil, received: "nil"},
+	}
+
+	for _, arg := range arguments {
+		p := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+
+		err := p.ParseHeader(arg.argument)
+		var argumentErr ArgumentError
+		if arg.typeOnly && err != nil {
+			t.Errorf("encountered error parsing the header for %s: %v", arg.received, err)
+		}
+		if !arg.typeOnly && (!errors.As(err, &argumentErr) || argumentErr.Received != arg.received || !errors.Is(err, ErrorInvalidArgument)) {
+			t.Errorf("expected an ArgumentError for %s when parsing the header, but got %v", arg.received, err)
+		}
+
+		err = p.ReadRecord(arg.argument)
+		if !errors.Is(err, ErrorInvalidArgument) {
+			t.Errorf("expected to encounter Invalid Argument error for %s when reading, but got %v", arg.received, err)
+		}
+
+		w := NewWriter(&strings.Builder{}, WriterOptions{})
+		err = w.WriteRecord(arg.argument)
+		if !errors.Is(err, ErrorInvalidArgument) {
+			t.Errorf("expected to encounter Invalid Argument error for %s when writing, but got %v", arg.received, err)
+		}
+	}
+}
+
+func TestReuseRecordStrings(t *testing.T) {
+	for _, cloneStrings := range []bool{false, true} {
+		p := NewParser(strings.NewReader("first,1\nsecond,2"), ParserOptions{ReuseRecord: true, CloneStrings: cloneStrings})
+
+		var first, second peekTest
+		err := p.ReadRecord(&first)
+		if err != nil {
+			t.Errorf("encountered error reading csv record: %v", err)
+		}
+		err = p.ReadRecord(&second)
+		if err != nil {
+			t.Errorf("encountered error reading csv record: %v", err)
+		}
+
+		if first.Section != "first" || second.Section != "second" {
+			t.Errorf("expected string fields to keep their values across reads with CloneStrings %t, but got %q and %q", cloneStrings, first.Section, second.Section)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	var lines []int
+	options := ParserOptions{
+		Filter: func(record []string, line int) bool {
+			lines = append(lines, line)
+			return record[0] == "EU"
+		},
+	}
+
+	records, err := Parse[dedupeTest](strings.NewReader(dedupeTestData), options)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with a filter: %v", err)
+	}
+
+	expected := []dedupeTest{{"EU", 1, 10}, {"EU", 1, 30}, {"EU", 2, 40}}
+	if len(records) != len(expected) {
+		t.Errorf("expected filtered records %+v, but got %+v", expected, records)
+		return
+	}
+	for i := range expected {
+		if records[i] != expected[i] {
+			t.Errorf("expected filtered record %+v, but got %+v", expected[i], records[i])
+		}
+	}
+
+	if len(lines) != 5 || lines[4] != 5 {
+		t.Errorf("expected the filter to see every record with its line number, but got lines %v", lines)
+	}
+}
+
+func TestCellTransform(t *testing.T) {
+	var headers []string
+	options := ParserOptions{
+		CellTransform: func(column int, header string, value string) string {
+			headers = append(headers, fmt.Sprintf("%d:%s", column, header))
+			return strings.TrimPrefix(value, "'")
+		},
+	}
+
+	p := NewParser(strings.NewReader("price,name\n'12, 'apple"), options)
+	var record struct {
+		Price int    `csv:"header:price"`
+		Name  string `csv:"header:name;trim"`
+	}
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with a cell transform: %v", err)
+	}
+
+	// the value is transformed before it is trimmed, so the quote after the space is kept
+	if record.Price != 12 || record.Name != "'apple" {
+		t.Errorf("expected transformed values 12 and 'apple, but got %d and %s", record.Price, record.Name)
+	}
+
+	if strings.Join(headers, ",") != "0:price,1:name" {
+		t.Errorf("expected the transform to be called with each column and header, but got %v", headers)
+	}
+}
+
+type tagFallbackTest struct {
+	Name     string  `json:"name"`
+	Price    float64 `csv:"header:cost" json:"price"`
+	Quantity int     `protobuf:"varint,3,opt,name=qty,json=quantity,proto3" json:"quantity"`
+	Note     string  `json:",omitempty"`
+	Internal string  `json:"-"`
+	Ignored  string
+}
+
+func TestTagFallback(t *testing.T) {
+	data := "name,cost,qty,Note,Internal\nWidget,9.5,3,fragile,secret"
+	p := NewParser(strings.NewReader(data), ParserOptions{TagFallback: []string{"csv", "protobuf", "json"}})
+
+	var record tagFallbackTest
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with tag fallbacks: %v", err)
+	}
+
+	expected := tagFallbackTest{Name: "Widget", Price: 9.5, Quantity: 3, Note: "fragile"}
+	if record != expected {
+		t.Errorf("improperly parsed csv with tag fallbacks. Got '%+v' but expected '%+v'", record, expected)
+	}
+
+	p = NewParser(strings.NewReader(data), ParserOptions{})
+	err = p.ParseHeader(&record)
+	if err != nil || len(p.csvAttrs) != 1 {
+		t.Errorf("expected only csv tags to be bound without tag fallbacks, but bound %d fields with error %v", len(p.csvAttrs), err)
+	}
+}
+
+type tagNameTest struct {
+	Name  string  `csv:"header:name" tsv:"index:1"`
+	Price float64 `csv:"header:price" tsv:"index:0"`
+}
+
+func TestTagName(t *testing.T) {
+	p := NewParser(strings.NewReader("9.5\tWidget"), ParserOptions{Delimiter: '\t', TagName: "tsv"})
+
+	var record tagNameTest
+	err := p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with tag name: %v", err)
+	}
+
+	expected := tagNameTest{Name: "Widget", Price: 9.5}
+	if record != expected {
+		t.Errorf("improperly parsed csv with tag name. Got '%+v' but expected '%+v'", record, expected)
+	}
+
+	var output strings.Builder
+	w := NewWriter(&output, WriterOptions{TagName: "tsv"})
+	err = w.WriteRecord(&record)
+	w.Flush()
+	if err != nil || output.String() != "9.5,Widget\n" {
+		t.Errorf("improperly wrote csv with tag name. Got '%v' and error %v", output.String(), err)
+	}
+
+	p = NewParser(strings.NewReader(""), ParserOptions{TagName: "my tag"})
+	err = p.ReadRecord(&record)
+	if !errors.Is(err, ErrorInvalidTagName) {
+		t.Errorf("expected to encounter ErrorInvalidTagName error, but got %v", err)
+	}
+}
+
+type profileTest struct {
+	Amount float64 `csv:"trim;profile=vendorA:header:Amt;profile=vendorB:index:2"`
+	SKU    string  `csv:"header:sku;profile=vendorB:index:0"`
+	Note   string  `csv:"profile=vendorA:header:comment"`
+}
+
+func TestProfile(t *testing.T) {
+	p := NewParser(strings.NewReader("sku,comment,Amt\nW-1,fragile, 9.5"), ParserOptions{Profile: "vendorA"})
+
+	var record profileTest
+	err := p.ParseHeader(&record)
+	if err == nil {
+		err = p.ReadRecord(&record)
+	}
+	if err != nil {
+		t.Errorf("encountered error parsing csv with profile vendorA: %v", err)
+	}
+
+	expected := profileTest{Amount: 9.5, SKU: "W-1", Note: "fragile"}
+	if record != expected {
+		t.Errorf("improperly parsed csv with profile vendorA. Got '%+v' but expected '%+v'", record, expected)
+	}
+
+	p = NewParser(strings.NewReader("W-2,x, 12"), ParserOptions{Profile: "vendorB"})
+
+	record = profileTest{}
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with profile vendorB: %v", err)
+	}
+
+	expected = profileTest{Amount: 12, SKU: "W-2"}
+	if record != expected {
+		t.Errorf("improperly parsed csv with profile vendorB. Got '%+v' but expected '%+v'", record, expected)
+	}
+
+	p = NewParser(strings.NewReader("sku\nW-3"), ParserOptions{})
+
+	record = profileTest{}
+	err = p.ParseHeader(&record)
+	if err == nil {
+		err = p.ReadRecord(&record)
+	}
+	if err != nil || record != (profileTest{SKU: "W-3"}) {
+		t.Errorf("expected only attributes outside of profiles to be used without a profile, but got '%+v' and error %v", record, err)
+	}
+}
+
+func TestHeaderRenames(t *testing.T) {
+	p := NewParser(strings.NewReader("FIELD_ONE,fieldTwo,field_3\nString,12,34"), ParserOptions{
+		HeaderRenames: map[string]string{"FIELD_ONE": "field1", "field_3": "Field3"},
+	})
+
+	var record headerTest
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv header with renames: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with header renames: %v", err)
+	}
+
+	expected := headerTest{Field1: "String", Field2: 12, Field3: 34}
+	if record != expected {
+		t.Errorf("improperly parsed csv with header renames. Got '%+v' but expected '%+v'", record, expected)
+	}
+
+	if !reflect.DeepEqual(p.header, []string{"field1", "fieldTwo", "Field3"}) {
+		t.Errorf("expected the renamed header to be kept, but got '%v'", p.header)
+	}
+}
+
+type indexBaseTest struct {
+	Name  string  `csv:"index:1"`
+	Price float64 `csv:"index:3"`
+}
+
+func TestIndexBase(t *testing.T) {
+	p := NewParser(strings.NewReader("Widget,unused,9.5"), ParserOptions{IndexBase: 1})
+
+	var record indexBaseTest
+	err := p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with index base 1: %v", err)
+	}
+
+	expected := indexBaseTest{Name: "Widget", Price: 9.5}
+	if record != expected {
+		t.Errorf("improperly parsed csv with index base 1. Got '%+v' but expected '%+v'", record, expected)
+	}
+
+	var output strings.Builder
+	w := NewWriter(&output, WriterOptions{IndexBase: 1})
+	err = w.WriteRecord(&record)
+	w.Flush()
+	if err != nil || output.String() != "Widget,,9.5\n" {
+		t.Errorf("improperly wrote csv with index base 1. Got '%v' and error %v", output.String(), err)
+	}
+
+	type zeroIndex struct {
+		Name string `csv:"index:0"`
+	}
+	p = NewParser(strings.NewReader("Widget"), ParserOptions{IndexBase: 1})
+	err = p.ReadRecord(&zeroIndex{})
+	if !errors.Is(err, ErrorInvalidIndex) {
+		t.Errorf("expected to encounter ErrorInvalidIndex error for index 0 with index base 1, but got %v", err)
+	}
+}
+
+func TestDuplicateIndex(t *testing.T) {
+	type duplicateIndex struct {
+		Name  string `csv:"index:0"`
+		Label string `csv:"index:0"`
+	}
+
+	p := NewParser(strings.NewReader("Widget"), ParserOptions{})
+	err := p.ReadRecord(&duplicateIndex{})
+
+	var tagErr CsvTagDefError
+	if !errors.Is(err, ErrorDuplicateIndex) || !errors.As(err, &tagErr) || tagErr.FieldName != "Label" {
+		t.Errorf("expected to encounter ErrorDuplicateIndex error for field Label, but got %v", err)
+	}
+}
+
+func TestDuplicateBinding(t *testing.T) {
+	type duplicateHeader struct {
+		Name  string `csv:"header:name"`
+		Label string `csv:"header:name"`
+	}
+
+	p := NewParser(strings.NewReader("name\nWidget"), ParserOptions{})
+	err := p.ParseHeader(&duplicateHeader{})
+
+	var bindingErr DuplicateBindingError
+	if !errors.Is(err, ErrorDuplicateBinding) || !errors.Is(err, ErrorDuplicateHeader) || !errors.As(err, &bindingErr) || bindingErr.BoundFieldName != "Name" {
+		t.Errorf("expected to encounter ErrorDuplicateBinding error bound to field Name, but got %v", err)
+	}
+
+	type oneShared struct {
+		Name  string `csv:"index:0;allowShared"`
+		Label string `csv:"index:0"`
+	}
+
+	p = NewParser(strings.NewReader("Widget"), ParserOptions{})
+	err = p.ReadRecord(&oneShared{})
+	if !errors.Is(err, ErrorDuplicateBinding) {
+		t.Errorf("expected to encounter ErrorDuplicateBinding error when only one field allows sharing, but got %v", err)
+	}
+}
+
+type sharedBinding struct {
+	Code   string `csv:"header:code;allowShared"`
+	Number int    `csv:"header:code;allowShared"`
+	Name   string `csv:"header:name"`
+}
+
+func TestAllowShared(t *testing.T) {
+	p := NewParser(strings.NewReader("code,name\n042,Widget"), ParserOptions{})
+
+	var record sharedBinding
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	expected := sharedBinding{Code: "042", Number: 42, Name: "Widget"}
+	if err != nil || record != expected {
+		t.Errorf("improperly read shared column. Got '%+v' and error %v", record, err)
+	}
+
+	var output strings.Builder
+	w := NewWriter(&output, WriterOptions{})
+	err = w.WriteHeader(&record)
+	if err == nil {
+		err = w.WriteRecord(&record)
+	}
+	w.Flush()
+
+	if err != nil || output.String() != "code,name\n042,Widget\n" {
+		t.Errorf("expected the shared column to be written once from field Code. Got '%v' and error %v", output.String(), err)
+	}
+}
+
+type misspelledAttribute struct {
+	Name string `csv:"header:name;trimm;omitempty"`
+}
+
+func TestUnknownAttribute(t *testing.T) {
+	p := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+
+	err := p.ParseHeader(&misspelledAttribute{})
+
+	var unknown UnknownAttributeError
+	if !errors.As(err, &unknown) || !errors.Is(err, ErrorUnknownAttribute) || unknown.Attribute != "trimm" {
+		t.Errorf("expected to encounter ErrorUnknownAttribute error for trimm, but got %v", err)
+	}
+
+	var tagErr CsvTagDefError
+	if !errors.As(err, &tagErr) || tagErr.FieldName != "Name" {
+		t.Errorf("expected the error to be a CsvTagDefError for field Name, but got %v", err)
+	}
+}
+
+func TestTagValueDelimiters(t *testing.T) {
+	tests := []struct {
+		tag        string
+		headerName string
+		pattern    string
+	}{
+		{`header:time:utc`, "time:utc", ""},
+		{`header:'a;b';trim`, "a;b", ""},
+		{`header:'it''s';trim`, "it's", ""},
+		{`header:it's`, "it's", ""},
+		{`header:a\;b`, "a;b", ""},
+		{`header:x;regex:^a\;b$`, "x", "^a;b$"},
+		{`profile=vendorA:header:'a;b'`, "a;b", ""},
+	}
+
+	for _, test := range tests {
+		tag, _ := selectProfile(test.tag, "vendorA")
+		attrs, err := getAttributesFromTag(tag)
+		if err != nil {
+			t.Errorf("encountered error reading tag %s: %v", test.tag, err)
+			continue
+		}
+
+		if attrs.headerName != test.headerName {
+			t.Errorf("expected tag %s to have header %s, but got %s", test.tag, test.headerName, attrs.headerName)
+		}
+
+		if test.pattern != "" && (attrs.pattern == nil || attrs.pattern.String() != test.pattern) {
+			t.Errorf("expected tag %s to have regex %s, but got %v", test.tag, test.pattern, attrs.pattern)
+		}
+	}
+}
+
+func TestQuoteTagValue(t *testing.T) {
+	for _, value := range []string{"plain", "a:b", "a;b", "'quoted'", `a\;b`} {
+		attrs, err := getAttributesFromTag(headerAttr + valueDelim + quoteTagValue(value))
+		if err != nil || attrs.headerName != value {
+			t.Errorf("expected quoted header %s to read back as itself, but got %s and error %v", value, attrs.headerName, err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package csv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrorDuplicateKey        = fmt.Errorf("a record with the same key has already been read")
+	ErrorUnknownDedupeField  = fmt.Errorf("dedupe field is not a csv tagged field")
+	ErrorInvalidDedupePolicy = fmt.Errorf("dedupe policy must be DedupeKeepFirst, DedupeKeepLast or DedupeError")
+)
+
+// DedupePolicy decides which of the records sharing a key ReadRecord returns, when ParserOptions.DedupeBy is set.
+type DedupePolicy int
+
+const (
+	// DedupeKeepFirst returns the first record with each key, and skips the rest.
+	DedupeKeepFirst DedupePolicy = iota
+	// DedupeKeepLast returns only the last record with each key. It has to read the rest of the file before returning the first record, and keeps every record it will return in memory.
+	DedupeKeepLast
+	// DedupeError returns the first record with each key, and a DuplicateKeyError for each of the rest.
+	DedupeError
+)
+
+// dedupeState tracks the keys ReadRecord has seen, and with DedupeKeepLast, the records waiting to be returned.
+type dedupeState struct {
+	firstLines map[string]int
+	loaded     bool
+	pending    []dedupeRecord
+	err        error
+}
+
+type dedupeRecord struct {
+	line   int
+	offset int64
+	record []string
+	err    error
+}
+
+// dedupeKey builds the key of record from the values of the DedupeBy fields. Each value is prefixed with its length, so that no two different keys are the same.
+func (p *Parser) dedupeKey(record []string) (key string, err error) {
+	var b strings.Builder
+	for _, fieldName := range p.options.DedupeBy {
+		attrs, ok := p.csvAttrs[fieldName]
+		if !ok {
+			return "", FieldNotFoundError{
+				FieldName: fieldName,
+				Err:       ErrorUnknownDedupeField,
+			}
+		}
+
+		var value string
+		if attrs.columnIndex < len(record) {
+			value = record[attrs.columnIndex]
+		}
+		if attrs.trim {
+			value = strings.TrimSpace(value)
+		}
+
+		b.WriteString(strconv.Itoa(len(value)))
+		b.WriteString(":")
+		b.WriteString(value)
+	}
+
+	return b.String(), nil
+}
+
+// readDedupedRecord reads the next record that DedupePolicy allows to be returned.
+func (p *Parser) readDedupedRecord() (record []string, err error) {
+	if p.dedupe == nil {
+		p.dedupe = &dedupeState{firstLines: make(map[string]int)}
+	}
+
+	if p.options.DedupePolicy == DedupeKeepLast {
+		return p.readLastRecord()
+	}
+
+	for {
+		record, err = p.readFilteredRecord()
+		if err != nil {
+			return record, err
+		}
+
+		key, err := p.dedupeKey(record)
+		if err != nil {
+			return nil, err
+		}
+
+		firstLine, seen := p.dedupe.firstLines[key]
+		if !seen {
+			p.dedupe.firstLines[key] = p.line
+			return record, nil
+		}
+
+		if p.options.DedupePolicy == DedupeError {
+			return nil, DuplicateKeyError{
+				Line:      p.line,
+				FirstLine: firstLine,
+				Err:       ErrorDuplicateKey,
+			}
+		}
+	}
+}
+
+// readLastRecord reads the rest of the file the first time it is called, and then returns the last record with each key in the order those records appear, along with any error reading a record.
+// Reading stops at the first error that isn't about a single record, such as one from the underlying reader, which is returned after the records read before it.
+func (p *Parser) readLastRecord() (record []string, err error) {
+	state := p.dedupe
+
+	if !state.loaded {
+		positions := make(map[string]int)
+
+		for {
+			offset, _ := p.readerOffset()
+			record, err := p.readFilteredRecord()
+			if err != nil && !isRecordReadError(err) {
+				// The error is returned once the records read before it have been, and for every read after them, since nothing more can be read.
+				state.err = err
+				break
+			}
+
+			entry := dedupeRecord{line: p.line, offset: offset, err: err}
+			if record != nil {
+				// Keep a copy, since the reader may reuse the record on the next read.
+				entry.record = append([]string(nil), record...)
+			}
+
+			if err == nil {
+				key, err := p.dedupeKey(record)
+				if err != nil {
+					state.loaded = true
+					return nil, err
+				}
+
+				if idx, seen := positions[key]; seen {
+					state.pending[idx].record = nil
+				}
+				positions[key] = len(state.pending)
+			}
+
+			state.pending = append(state.pending, entry)
+		}
+		state.loaded = true
+	}
+
+	for len(state.pending) > 0 {
+		entry := state.pending[0]
+		state.pending = state.pending[1:]
+
+		if entry.record == nil && entry.err == nil {
+			continue
+		}
+
+		p.line = entry.line
+		return entry.record, entry.err
+	}
+
+	return nil, state.err
+}
+
+// pendingOffset returns the offset the first record still pending starts at, counting any records skipped after it, and whether there is one. The rest of the file
+// has already been read once records are pending, so that the offset of the reader is past them.
+func (state *dedupeState) pendingOffset() (offset int64, ok bool) {
+	if state == nil || !state.loaded || len(state.pending) == 0 {
+		return 0, false
+	}
+
+	return state.pending[0].offset, true
+}
+
+// isRecordReadError reports whether err is a problem with a single record, such as a malformed line, after which the records following it can still be read.
+func isRecordReadError(err error) bool {
+	var parseErr *csv.ParseError
+	var blankLineErr UnexpectedBlankLineError
+	var recordErr RecordError
+
+	return errors.As(err, &parseErr) || errors.As(err, &blankLineErr) || errors.As(err, &recordErr)
+}
+
+type DuplicateKeyError struct {
+	Line      int
+	FirstLine int
+	Err       error
+}
+
+func (e DuplicateKeyError) Error() string {
+	return fmt.Sprintf("record on line %d: key was first seen on line %d: %v", e.Line, e.FirstLine, e.Err)
+}
+
+func (e DuplicateKeyError) Unwrap() error { return e.Err }
@@ -0,0 +1,183 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+type dedupeTest struct {
+	Region string `csv:"header:region"`
+	ID     int    `csv:"header:id"`
+	Price  int    `csv:"header:price"`
+}
+
+const dedupeTestData = `region,id,price
+EU,1,10
+US,1,20
+EU,1,30
+EU,2,40
+US,1,50`
+
+func readDedupeTest(t *testing.T, options ParserOptions) (records []dedupeTest, lines []int, errs []error) {
+	p := NewParser(strings.NewReader(dedupeTestData), options)
+	err := p.ParseHeader(&dedupeTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	for {
+		var record dedupeTest
+		err := p.ReadRecord(&record)
+		if err == io.EOF {
+			return records, lines, errs
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		records = append(records, record)
+		lines = append(lines, p.Line())
+	}
+}
+
+func TestDedupeKeepFirst(t *testing.T) {
+	records, lines, errs := readDedupeTest(t, ParserOptions{DedupeBy: []string{"Region", "ID"}})
+
+	expected := []dedupeTest{{"EU", 1, 10}, {"US", 1, 20}, {"EU", 2, 40}}
+	expectedLines := []int{1, 2, 4}
+	if len(errs) != 0 || len(records) != len(expected) {
+		t.Errorf("expected records %+v, but got %+v and errors %v", expected, records, errs)
+		return
+	}
+
+	for i := range expected {
+		if records[i] != expected[i] || lines[i] != expectedLines[i] {
+			t.Errorf("expected record %+v on line %d, but got %+v on line %d", expected[i], expectedLines[i], records[i], lines[i])
+		}
+	}
+}
+
+func TestDedupeKeepLast(t *testing.T) {
+	records, lines, errs := readDedupeTest(t, ParserOptions{DedupeBy: []string{"Region", "ID"}, DedupePolicy: DedupeKeepLast})
+
+	expected := []dedupeTest{{"EU", 1, 30}, {"EU", 2, 40}, {"US", 1, 50}}
+	expectedLines := []int{3, 4, 5}
+	if len(errs) != 0 || len(records) != len(expected) {
+		t.Errorf("expected records %+v, but got %+v and errors %v", expected, records, errs)
+		return
+	}
+
+	for i := range expected {
+		if records[i] != expected[i] || lines[i] != expectedLines[i] {
+			t.Errorf("expected record %+v on line %d, but got %+v on line %d", expected[i], expectedLines[i], records[i], lines[i])
+		}
+	}
+}
+
+func TestDedupeError(t *testing.T) {
+	records, _, errs := readDedupeTest(t, ParserOptions{DedupeBy: []string{"Region", "ID"}, DedupePolicy: DedupeError})
+
+	if len(records) != 3 || len(errs) != 2 {
+		t.Errorf("expected 3 records and 2 errors, but got %+v and %v", records, errs)
+		return
+	}
+
+	var duplicateErr DuplicateKeyError
+	if !errors.As(errs[0], &duplicateErr) || !errors.Is(errs[0], ErrorDuplicateKey) {
+		t.Errorf("expected to encounter ErrorDuplicateKey error, but got %v", errs[0])
+	} else if duplicateErr.Line != 3 || duplicateErr.FirstLine != 1 {
+		t.Errorf("expected duplicate on line 3 of the key first seen on line 1, but got %v", duplicateErr)
+	}
+}
+
+func TestDedupeUnknownField(t *testing.T) {
+	_, _, errs := readDedupeTest(t, ParserOptions{DedupeBy: []string{"Missing"}})
+
+	if len(errs) == 0 || !errors.Is(errs[0], ErrorUnknownDedupeField) {
+		t.Errorf("expected to encounter ErrorUnknownDedupeField error, but got %v", errs)
+	}
+}
+
+func TestDedupeKeepLastReadError(t *testing.T) {
+	errRead := errors.New("connection reset")
+	file := io.MultiReader(strings.NewReader(dedupeTestData+"\n"), iotest.ErrReader(errRead))
+	p := NewParser(file, ParserOptions{DedupeBy: []string{"Region", "ID"}, DedupePolicy: DedupeKeepLast})
+	err := p.ParseHeader(&dedupeTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	var records []dedupeTest
+	for i := 0; i < 10; i++ {
+		var record dedupeTest
+		err = p.ReadRecord(&record)
+		if err != nil {
+			break
+		}
+		records = append(records, record)
+	}
+
+	if !errors.Is(err, errRead) || len(records) != 3 {
+		t.Errorf("expected the 3 records read before the read error and then the error, but got %+v and %v", records, err)
+	}
+
+	// Every later read returns the error too, since nothing more can be read.
+	err = p.ReadRecord(&dedupeTest{})
+	if !errors.Is(err, errRead) {
+		t.Errorf("expected to encounter the read error again, but got %v", err)
+	}
+}
+
+func TestDedupeKeepLastInvalidOptions(t *testing.T) {
+	p := NewParser(strings.NewReader("a,1"), ParserOptions{Delimiter: '\n', DedupeBy: []string{"Section"}, DedupePolicy: DedupeKeepLast})
+
+	err := p.ReadRecord(&peekTest{})
+	var optionErr OptionError
+	if !errors.As(err, &optionErr) || optionErr.Option != "Delimiter" {
+		t.Errorf("expected an OptionError for the Delimiter when reading, but got %v", err)
+	}
+}
+
+func TestDedupeKeepLastCheckpoint(t *testing.T) {
+	options := ParserOptions{DedupeBy: []string{"Region", "ID"}, DedupePolicy: DedupeKeepLast}
+	p := NewParser(strings.NewReader(dedupeTestData), options)
+	err := p.ParseHeader(&dedupeTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	var record dedupeTest
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading csv record: %v", err)
+	}
+
+	// The rest of the file has been read ahead, but the checkpoint points after the record returned.
+	checkpoint, err := p.Checkpoint()
+	if err != nil || checkpoint.Line != 3 || checkpoint.ByteOffset != int64(strings.Index(dedupeTestData, "EU,2")) {
+		t.Errorf("expected a checkpoint after line 3, but got '%+v' and error %v", checkpoint, err)
+	}
+
+	resumed, err := ResumeParser(strings.NewReader(dedupeTestData), checkpoint, options)
+	if err != nil {
+		t.Errorf("encountered error resuming parser: %v", err)
+	}
+
+	var records []dedupeTest
+	for {
+		err = resumed.ReadRecord(&record)
+		if err != nil {
+			break
+		}
+		records = append(records, record)
+	}
+
+	expected := []dedupeTest{{"EU", 2, 40}, {"US", 1, 50}}
+	if err != io.EOF || !reflect.DeepEqual(records, expected) {
+		t.Errorf("expected to resume with %+v, but got %+v and error %v", expected, records, err)
+	}
+}
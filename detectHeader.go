@@ -0,0 +1,70 @@
+package csv
+
+import (
+	"reflect"
+)
+
+// detectHeader peeks at the first line of the parser's csv file, and consumes it as the header if it looks like one. Otherwise it is left to be read as a record.
+func (p *Parser) detectHeader(structPointer interface{}) (err error) {
+	p.headerChecked = true
+
+	record, err := p.peekRecord()
+	if err != nil {
+		// The error is returned again when the line is read as a record.
+		return nil
+	}
+
+	// The line is checked with the labels it would have as a header, so that a renamed label is found by its new name.
+	header := p.renameHeader(record)
+	if !p.looksLikeHeader(structPointer, header) {
+		return nil
+	}
+
+	p.hasPeeked = false
+
+	for _, attrs := range p.csvAttrs {
+		if attrs.hasHeader || attrs.headerPattern != nil {
+			p.header = header
+			return p.resolveHeader()
+		}
+	}
+
+	return nil
+}
+
+// looksLikeHeader reports whether record contains the header name of any field or a value matching the headerRegex of any field, or a value bound by index that can't be converted to its field's type.
+func (p *Parser) looksLikeHeader(structPointer interface{}, record []string) bool {
+	for _, attrs := range p.csvAttrs {
+		if attrs.hasHeader {
+			if _, found := findHeaderIndex(record, attrs.headerName); found {
+				return true
+			}
+		}
+
+		if attrs.headerPattern != nil {
+			for _, value := range record {
+				if attrs.headerPattern.MatchString(value) {
+					return true
+				}
+			}
+		}
+	}
+
+	structType := reflect.TypeOf(structPointer).Elem()
+	for _, attrs := range p.csvAttrs {
+		if attrs.hasHeader || attrs.headerPattern != nil || attrs.useCustomSetter || attrs.columnIndex >= len(record) {
+			continue
+		}
+
+		field := reflect.New(structType.Field(attrs.fieldIndex).Type).Elem()
+		if field.Kind() == reflect.String {
+			continue
+		}
+
+		if p.setValue(field, attrs, record[attrs.columnIndex]) != nil {
+			return true
+		}
+	}
+
+	return false
+}
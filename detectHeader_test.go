@@ -0,0 +1,65 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAutoDetectHeader(t *testing.T) {
+	tests := []struct {
+		data   string
+		first  peekTest
+		header bool
+	}{
+		{data: "section,count\na,1", first: peekTest{Section: "a", Count: 1}, header: true},
+		{data: "a,1\nb,2", first: peekTest{Section: "a", Count: 1}},
+	}
+
+	for _, test := range tests {
+		p := NewParser(strings.NewReader(test.data), ParserOptions{AutoDetectHeader: true})
+
+		var record peekTest
+		err := p.ReadRecord(&record)
+		if err != nil {
+			t.Errorf("encountered error reading csv record with auto detected header: %v", err)
+		}
+		if record != test.first || p.Line() != 1 {
+			t.Errorf("improperly detected header in '%s'. Read '%+v' on line %d", test.data, record, p.Line())
+		}
+	}
+}
+
+func TestAutoDetectHeaderNames(t *testing.T) {
+	p := NewParser(strings.NewReader("Field3,field1,fieldTwo\n3,a,2"), ParserOptions{AutoDetectHeader: true})
+
+	var record headerTest
+	err := p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading csv record with auto detected header: %v", err)
+	}
+	if record.Field1 != "a" || record.Field2 != 2 || record.Field3 != 3 {
+		t.Errorf("improperly bound auto detected header. Got '%+v'", record)
+	}
+}
+
+type detectRenamedTest struct {
+	ID   int    `csv:"header:id"`
+	Name string `csv:"header:name"`
+}
+
+func TestAutoDetectHeaderRenames(t *testing.T) {
+	renames := map[string]string{"Cust ID": "id", "Full Name": "name"}
+
+	for _, data := range []string{"Cust ID,name\n7,Ann", "name,Cust ID\nAnn,7", "Full Name,Cust ID\nAnn,7"} {
+		p := NewParser(strings.NewReader(data), ParserOptions{AutoDetectHeader: true, HeaderRenames: renames})
+
+		var record detectRenamedTest
+		err := p.ReadRecord(&record)
+		if err != nil {
+			t.Errorf("encountered error reading csv record with an auto detected, renamed header: %v", err)
+		}
+		if record != (detectRenamedTest{ID: 7, Name: "Ann"}) || p.Line() != 1 {
+			t.Errorf("improperly detected renamed header in '%s'. Read '%+v' on line %d", data, record, p.Line())
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package csv
+
+import (
+	"fmt"
+)
+
+var (
+	ErrorInvalidDialect = fmt.Errorf("dialect must be DialectDefault, DialectRFC4180, DialectExcel, DialectPostgresCopy or DialectMySQLOutfile")
+)
+
+// Dialect bundles the delimiter, quoting, escaping and null conventions of a common source of csv files, so that its files can be read with one option.
+// Options that are set explicitly take precedence over those of the dialect.
+type Dialect int
+
+const (
+	// DialectDefault reads files as encoding/csv does, which already follows RFC 4180 closely.
+	DialectDefault Dialect = iota
+	// DialectRFC4180 reads files as RFC 4180 describes them, with values separated by commas and quoted with double quotes.
+	DialectRFC4180
+	// DialectExcel reads files saved by Excel as CSV UTF-8, with values separated by commas and quoted with double quotes, and skips the byte order mark Excel writes at the start of the file.
+	DialectExcel
+	// DialectPostgresCopy reads files written by PostgreSQL's COPY in its default text format, with values separated by tabs, special characters escaped with a backslash
+	// rather than quoted, and NULL written as \N, which is read as an empty value.
+	DialectPostgresCopy
+	// DialectMySQLOutfile reads files written by MySQL's SELECT ... INTO OUTFILE with its default options, with values separated by tabs, special characters escaped with a backslash
+	// rather than quoted, and NULL written as \N, which is read as an empty value.
+	DialectMySQLOutfile
+)
+
+// withDialect returns options with the options of their dialect filled in where they aren't set.
+func (o ParserOptions) withDialect() ParserOptions {
+	switch o.Dialect {
+	case DialectPostgresCopy, DialectMySQLOutfile:
+		if o.Delimiter == 0 {
+			o.Delimiter = '\t'
+		}
+		if o.EscapeChar == 0 {
+			o.EscapeChar = '\\'
+		}
+	}
+
+	return o
+}
+
+// unquoted reports whether the dialect's values are never quoted, so that quote characters are read as they are.
+func (d Dialect) unquoted() bool {
+	return d == DialectPostgresCopy || d == DialectMySQLOutfile
+}
@@ -0,0 +1,158 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type dialectRow struct {
+	ID   int    `csv:"header:id"`
+	Name string `csv:"header:name"`
+	Note string `csv:"header:note"`
+}
+
+func readDialectRows(t *testing.T, file string, options ParserOptions) (rows []dialectRow) {
+	t.Helper()
+
+	p := NewParser(strings.NewReader(file), options)
+
+	var row dialectRow
+	err := p.ParseHeader(&row)
+	if err != nil {
+		t.Fatalf("encountered error parsing the header: %v", err)
+	}
+
+	for {
+		err = p.ReadRecord(&row)
+		if err == io.EOF {
+			return rows
+		}
+		if err != nil {
+			t.Fatalf("encountered error reading line %d: %v", p.Line(), err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestDialectPostgresCopy(t *testing.T) {
+	file := "id\tname\tnote\n1\t\"Widget\"\t\\N\n2\tGizmo\\tLarge\tline\\nbreak\n"
+	rows := readDialectRows(t, file, ParserOptions{Dialect: DialectPostgresCopy})
+
+	// Values are never quoted, so quotes are part of the value.
+	expected := []dialectRow{{ID: 1, Name: `"Widget"`}, {ID: 2, Name: "Gizmo\tLarge", Note: "line\nbreak"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("improperly read a PostgreSQL COPY file. Expected '%+v', but got '%+v'", expected, rows)
+	}
+}
+
+func TestDialectMySQLOutfile(t *testing.T) {
+	file := "1\tWidget\tmulti\\\nline\n2\tGizmo\t\\N\n"
+	p := NewParser(strings.NewReader(file), ParserOptions{Dialect: DialectMySQLOutfile})
+
+	type outfileRow struct {
+		ID   int    `csv:"index:0"`
+		Name string `csv:"index:1"`
+		Note string `csv:"index:2"`
+	}
+
+	var rows []outfileRow
+	for {
+		var row outfileRow
+		err := p.ReadRecord(&row)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("encountered error reading line %d: %v", p.Line(), err)
+		}
+		rows = append(rows, row)
+	}
+
+	expected := []outfileRow{{ID: 1, Name: "Widget", Note: "multi\nline"}, {ID: 2, Name: "Gizmo"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("improperly read a MySQL outfile. Expected '%+v', but got '%+v'", expected, rows)
+	}
+}
+
+func TestDialectExcel(t *testing.T) {
+	file := "\xEF\xBB\xBFid,name,note\r\n1,\"Widget, large\",\r\n"
+	rows := readDialectRows(t, file, ParserOptions{Dialect: DialectExcel})
+
+	expected := []dialectRow{{ID: 1, Name: "Widget, large"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("improperly read an Excel file. Expected '%+v', but got '%+v'", expected, rows)
+	}
+
+	p := NewParser(strings.NewReader(file), ParserOptions{Dialect: DialectExcel})
+	err := p.ParseHeader(&dialectRow{})
+	if err != nil {
+		t.Fatalf("encountered error parsing the header: %v", err)
+	}
+
+	// The byte order mark counts towards the offset, so that it can be used to seek in the file.
+	offset, err := p.ByteOffset()
+	if err != nil || offset != int64(len("\xEF\xBB\xBFid,name,note\r\n")) {
+		t.Errorf("expected the offset of the first record, but got %d and error %v", offset, err)
+	}
+}
+
+func TestDialectMySQLOutfileLatin1(t *testing.T) {
+	type outfileRow struct {
+		ID   int    `csv:"index:0"`
+		Name string `csv:"index:1"`
+	}
+
+	// Invalid bytes are kept as they are, even when escaped, so that the InvalidUTF8 policy applies as it does to other files.
+	file := "1\tcaf\xe9\n2\tna\\\xefve\n"
+	p := NewParser(strings.NewReader(file), ParserOptions{Dialect: DialectMySQLOutfile})
+
+	var rows []outfileRow
+	for {
+		var row outfileRow
+		err := p.ReadRecord(&row)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("encountered error reading line %d: %v", p.Line(), err)
+		}
+		rows = append(rows, row)
+	}
+
+	expected := []outfileRow{{ID: 1, Name: "caf\xe9"}, {ID: 2, Name: "na\xefve"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("improperly read a latin1 MySQL outfile. Expected '%q', but got '%q'", expected, rows)
+	}
+
+	p = NewParser(strings.NewReader(file), ParserOptions{Dialect: DialectMySQLOutfile, InvalidUTF8: UTF8Error})
+	err := p.ReadRecord(&outfileRow{})
+	var utf8Err InvalidUTF8Error
+	if !errors.As(err, &utf8Err) || utf8Err.FieldName != "Name" {
+		t.Errorf("expected to encounter an InvalidUTF8Error for Name, but got %v", err)
+	}
+}
+
+func TestDialectExplicitOptions(t *testing.T) {
+	file := "id|name|note\n1|Widget|\\N\n"
+	rows := readDialectRows(t, file, ParserOptions{Dialect: DialectPostgresCopy, Delimiter: '|'})
+
+	expected := []dialectRow{{ID: 1, Name: "Widget"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected the delimiter option to take precedence over the dialect. Expected '%+v', but got '%+v'", expected, rows)
+	}
+}
+
+func TestDialectValidate(t *testing.T) {
+	err := ParserOptions{Dialect: DialectMySQLOutfile + 1}.Validate()
+	if !errors.Is(err, ErrorInvalidDialect) {
+		t.Errorf("expected to encounter ErrorInvalidDialect error, but got %v", err)
+	}
+
+	_, err = ParseParallel[dialectRow](strings.NewReader(""), 0, 1, ParserOptions{Dialect: DialectPostgresCopy})
+	if !errors.Is(err, ErrorQuotingNotParallel) {
+		t.Errorf("expected to encounter ErrorQuotingNotParallel error, but got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package csv
+
+import (
+	"io"
+	"strings"
+)
+
+// DiffKind is the kind of change a RecordChange describes.
+type DiffKind int
+
+const (
+	// DiffAdded is a record of the new file whose key isn't in the old file.
+	DiffAdded DiffKind = iota
+	// DiffRemoved is a record of the old file whose key isn't in the new file.
+	DiffRemoved
+	// DiffChanged is a record whose key is in both files, but whose values differ.
+	DiffChanged
+)
+
+// RecordChange is a difference between two csv files for one key, as returned by RecordDiff.Next.
+type RecordChange struct {
+	Kind DiffKind
+	// Key holds the values of the key columns, in the order the key columns were listed.
+	Key []string
+	// Line is the line of the record in the new file, or in the old file for a removed record.
+	Line int
+	// Record holds the values of the added or removed record, keyed by header label. It is nil for a changed record.
+	Record map[string]string
+	// Fields lists the values of a changed record that differ, in the order of the old file's header.
+	Fields []FieldChange
+}
+
+// FieldChange is a value that differs between the old and the new record for a key.
+type FieldChange struct {
+	Column string
+	From   string
+	To     string
+}
+
+// RecordDiff compares the records of two csv files by key, as created by Diff. Changes are returned one at a time by Next.
+type RecordDiff struct {
+	keyFields []string
+	oldHeader []string
+	newHeader []string
+	shared    []string
+	old       map[string]diffRecord
+	oldOrder  []string
+	seen      map[string]bool
+	parser    Parser
+	removed   int
+}
+
+// diffRecord is a record of the old file, with the line it was read from.
+type diffRecord struct {
+	key    []string
+	record map[string]string
+	line   int
+}
+
+// Diff compares the records of the csv files oldFile and newFile, such as yesterday's feed and today's, matching records by the values of the columns labeled keyFields.
+// The old file is read into memory, and the new file is read as changes are returned by Next. Both files must have a header, and are read with options.
+// Only the columns that both headers share are compared, and Headers reports how the headers differ. A key that appears twice in either file is reported as ErrorDuplicateKey.
+func Diff(oldFile, newFile io.Reader, keyFields []string, options ParserOptions) (diff *RecordDiff, err error) {
+	diff = &RecordDiff{
+		keyFields: keyFields,
+		old:       make(map[string]diffRecord),
+		seen:      make(map[string]bool),
+	}
+
+	oldParser := NewParser(oldFile, options)
+	diff.oldHeader, err = oldParser.readHeader()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	err = checkDiffKeys(diff.oldHeader, keyFields)
+	if err != nil {
+		return nil, err
+	}
+
+	for diff.oldHeader != nil {
+		record, err := oldParser.ReadRecordMap()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key, id := diffKey(record, keyFields)
+		if _, found := diff.old[id]; found {
+			return nil, RecordError{
+				Line: oldParser.Line(),
+				Err:  ErrorDuplicateKey,
+			}
+		}
+
+		diff.old[id] = diffRecord{key: key, record: record, line: oldParser.Line()}
+		diff.oldOrder = append(diff.oldOrder, id)
+	}
+
+	diff.parser = NewParser(newFile, options)
+	diff.newHeader, err = diff.parser.readHeader()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	err = checkDiffKeys(diff.newHeader, keyFields)
+	if err != nil {
+		return nil, err
+	}
+
+	inNew := make(map[string]bool, len(diff.newHeader))
+	for _, label := range diff.newHeader {
+		inNew[label] = true
+	}
+	for _, label := range diff.oldHeader {
+		if inNew[label] {
+			diff.shared = append(diff.shared, label)
+		}
+	}
+
+	return diff, nil
+}
+
+// checkDiffKeys reports a FieldNotFoundError if any of keyFields isn't a label of header. An empty file has no header to check.
+func checkDiffKeys(header []string, keyFields []string) (err error) {
+	if header == nil {
+		return nil
+	}
+
+	for _, keyField := range keyFields {
+		if _, found := findHeaderIndex(header, keyField); !found {
+			return FieldNotFoundError{
+				FieldName:  keyField,
+				HeaderName: keyField,
+				Err:        ErrorFieldNotFound,
+			}
+		}
+	}
+
+	return nil
+}
+
+// diffKey returns the values of the key columns of record, and a string identifying them.
+func diffKey(record map[string]string, keyFields []string) (key []string, id string) {
+	key = make([]string, len(keyFields))
+	for idx, keyField := range keyFields {
+		key[idx] = record[keyField]
+	}
+
+	return key, strings.Join(key, "\x00")
+}
+
+// Headers reports how the header of the new file differs from the header of the old file, as described by CompareHeaders.
+func (d *RecordDiff) Headers() HeaderDiff {
+	return CompareHeaders(d.oldHeader, d.newHeader)
+}
+
+// Next returns the next change between the files. Added and changed records are returned in the order of the new file, followed by the removed records
+// in the order of the old file. It returns io.EOF once every change has been returned.
+func (d *RecordDiff) Next() (change RecordChange, err error) {
+	for d.newHeader != nil {
+		record, err := d.parser.ReadRecordMap()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return change, err
+		}
+
+		key, id := diffKey(record, d.keyFields)
+		if d.seen[id] {
+			return change, RecordError{
+				Line: d.parser.Line(),
+				Err:  ErrorDuplicateKey,
+			}
+		}
+		d.seen[id] = true
+
+		old, found := d.old[id]
+		if !found {
+			return RecordChange{Kind: DiffAdded, Key: key, Line: d.parser.Line(), Record: record}, nil
+		}
+
+		var fields []FieldChange
+		for _, column := range d.shared {
+			if old.record[column] != record[column] {
+				fields = append(fields, FieldChange{Column: column, From: old.record[column], To: record[column]})
+			}
+		}
+		if len(fields) > 0 {
+			return RecordChange{Kind: DiffChanged, Key: key, Line: d.parser.Line(), Fields: fields}, nil
+		}
+	}
+
+	for d.removed < len(d.oldOrder) {
+		id := d.oldOrder[d.removed]
+		d.removed++
+
+		if !d.seen[id] {
+			old := d.old[id]
+			return RecordChange{Kind: DiffRemoved, Key: old.key, Line: old.line, Record: old.record}, nil
+		}
+	}
+
+	return change, io.EOF
+}
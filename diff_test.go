@@ -0,0 +1,99 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readChanges(t *testing.T, diff *RecordDiff) (changes []RecordChange) {
+	t.Helper()
+
+	for {
+		change, err := diff.Next()
+		if err == io.EOF {
+			return changes
+		}
+		if err != nil {
+			t.Fatalf("encountered error reading changes: %v", err)
+		}
+		changes = append(changes, change)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	yesterday := "id,name,price\n1,apple,1.00\n2,pear,2.00\n3,plum,3.00\n"
+	today := "id,name,price,stock\n3,plum,3.50,7\n1,apple,1.00,2\n4,fig,4.00,1\n"
+
+	diff, err := Diff(strings.NewReader(yesterday), strings.NewReader(today), []string{"id"}, ParserOptions{})
+	if err != nil {
+		t.Fatalf("encountered error comparing files: %v", err)
+	}
+
+	expected := []RecordChange{
+		{Kind: DiffChanged, Key: []string{"3"}, Line: 1, Fields: []FieldChange{{Column: "price", From: "3.00", To: "3.50"}}},
+		{Kind: DiffAdded, Key: []string{"4"}, Line: 3, Record: map[string]string{"id": "4", "name": "fig", "price": "4.00", "stock": "1"}},
+		{Kind: DiffRemoved, Key: []string{"2"}, Line: 2, Record: map[string]string{"id": "2", "name": "pear", "price": "2.00"}},
+	}
+
+	changes := readChanges(t, diff)
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("improperly compared files.\nGot      %+v\nexpected %+v", changes, expected)
+	}
+
+	if headers := diff.Headers(); !reflect.DeepEqual(headers.Extra, []string{"stock"}) {
+		t.Errorf("expected the stock column to be reported as extra, but got %+v", headers)
+	}
+}
+
+func TestDiffCompositeKey(t *testing.T) {
+	yesterday := "region,id,amount\neu,1,10\nus,1,20\n"
+	today := "region,id,amount\nus,1,25\neu,1,10\n"
+
+	diff, err := Diff(strings.NewReader(yesterday), strings.NewReader(today), []string{"region", "id"}, ParserOptions{})
+	if err != nil {
+		t.Fatalf("encountered error comparing files: %v", err)
+	}
+
+	changes := readChanges(t, diff)
+	if len(changes) != 1 || !reflect.DeepEqual(changes[0].Key, []string{"us", "1"}) || changes[0].Kind != DiffChanged {
+		t.Errorf("expected only the us record to have changed, but got %+v", changes)
+	}
+}
+
+func TestDiffErrors(t *testing.T) {
+	_, err := Diff(strings.NewReader("id\n1\n"), strings.NewReader("id\n1\n"), []string{"code"}, ParserOptions{})
+	if !errors.Is(err, ErrorFieldNotFound) {
+		t.Errorf("expected %v for a missing key column, but got %v", ErrorFieldNotFound, err)
+	}
+
+	_, err = Diff(strings.NewReader("id\n1\n1\n"), strings.NewReader("id\n1\n"), []string{"id"}, ParserOptions{})
+	var recordErr RecordError
+	if !errors.Is(err, ErrorDuplicateKey) || !errors.As(err, &recordErr) || recordErr.Line != 2 {
+		t.Errorf("expected %v on line 2 of the old file, but got %v", ErrorDuplicateKey, err)
+	}
+
+	diff, err := Diff(strings.NewReader("id\n1\n"), strings.NewReader("id\n2\n2\n"), []string{"id"}, ParserOptions{})
+	if err != nil {
+		t.Fatalf("encountered error comparing files: %v", err)
+	}
+	_, _ = diff.Next()
+	_, err = diff.Next()
+	if !errors.Is(err, ErrorDuplicateKey) {
+		t.Errorf("expected %v for a duplicate key in the new file, but got %v", ErrorDuplicateKey, err)
+	}
+}
+
+func TestDiffEmptyFile(t *testing.T) {
+	diff, err := Diff(strings.NewReader("id,name\n1,a\n"), strings.NewReader(""), []string{"id"}, ParserOptions{})
+	if err != nil {
+		t.Fatalf("encountered error comparing files: %v", err)
+	}
+
+	changes := readChanges(t, diff)
+	if len(changes) != 1 || changes[0].Kind != DiffRemoved {
+		t.Errorf("expected every record to be removed, but got %+v", changes)
+	}
+}
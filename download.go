@@ -0,0 +1,69 @@
+package csv
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+)
+
+var (
+	ErrorNotStructSlice = fmt.Errorf("must pass a slice of structs or struct pointers")
+)
+
+// ServeCSV writes the records of slice to an http response as a csv file download named filename, with a header line, as described by the csv decorator tags of the slice's element type.
+// The slice may hold structs or pointers to structs. The Content-Type and Content-Disposition headers are set as the first bytes are written,
+// so when an error is returned before anything has been written, such as for a struct with invalid tags, the handler can still respond with an error.
+func ServeCSV(w http.ResponseWriter, filename string, slice interface{}, options WriterOptions) (err error) {
+	records := reflect.ValueOf(slice)
+	if records.Kind() != reflect.Slice {
+		return ErrorNotStructSlice
+	}
+
+	elemType := records.Type().Elem()
+	isPointer := elemType.Kind() == reflect.Pointer
+	if isPointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return ErrorNotStructSlice
+	}
+
+	writer := NewWriter(&downloadWriter{response: w, filename: filename}, options)
+
+	err = writer.WriteHeader(reflect.New(elemType).Interface())
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < records.Len(); i++ {
+		record := records.Index(i)
+		if !isPointer {
+			record = record.Addr()
+		}
+
+		err = writer.WriteRecord(record.Interface())
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// downloadWriter sets the headers of a csv file download on response just before the first bytes are written to it.
+type downloadWriter struct {
+	response http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (d *downloadWriter) Write(b []byte) (n int, err error) {
+	if !d.started {
+		d.started = true
+		d.response.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		d.response.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": d.filename}))
+	}
+
+	return d.response.Write(b)
+}
@@ -0,0 +1,57 @@
+package csv
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+type downloadTest struct {
+	Name  string `csv:"header:name"`
+	Price int    `csv:"header:price"`
+}
+
+func TestServeCSV(t *testing.T) {
+	records := []downloadTest{{"Widget", 3}, {"Gadget", 5}}
+
+	for _, slice := range []interface{}{records, []*downloadTest{&records[0], &records[1]}} {
+		response := httptest.NewRecorder()
+
+		err := ServeCSV(response, "prices é.csv", slice, WriterOptions{})
+		if err != nil {
+			t.Errorf("encountered error serving csv: %v", err)
+		}
+
+		expected := "name,price\nWidget,3\nGadget,5\n"
+		if response.Body.String() != expected {
+			t.Errorf("improperly served csv. Got '%v' but expected '%v'", response.Body.String(), expected)
+		}
+
+		if contentType := response.Header().Get("Content-Type"); contentType != "text/csv; charset=utf-8" {
+			t.Errorf("improperly set Content-Type header. Got '%v'", contentType)
+		}
+
+		expectedDisposition := "attachment; filename*=utf-8''prices%20%C3%A9.csv"
+		if disposition := response.Header().Get("Content-Disposition"); disposition != expectedDisposition {
+			t.Errorf("improperly set Content-Disposition header. Got '%v' but expected '%v'", disposition, expectedDisposition)
+		}
+	}
+}
+
+func TestServeCSVErrors(t *testing.T) {
+	response := httptest.NewRecorder()
+
+	err := ServeCSV(response, "prices.csv", []string{"Widget"}, WriterOptions{})
+	if !errors.Is(err, ErrorNotStructSlice) {
+		t.Errorf("expected to encounter ErrorNotStructSlice error, but got %v", err)
+	}
+
+	err = ServeCSV(response, "prices.csv", []invalidRegex{{}}, WriterOptions{})
+	if err == nil {
+		t.Errorf("expected to encounter error serving struct with invalid tags")
+	}
+
+	if len(response.Header()) != 0 || response.Body.Len() != 0 {
+		t.Errorf("expected nothing to be written before an error, but got headers %v and body '%v'", response.Header(), response.Body.String())
+	}
+}
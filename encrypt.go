@@ -0,0 +1,47 @@
+package csv
+
+import (
+	"fmt"
+)
+
+var (
+	ErrorMissingFieldEncryptor = fmt.Errorf("fields with the encrypted attribute can't be written without a FieldEncryptor")
+	ErrorMissingFieldDecryptor = fmt.Errorf("fields with the encrypted attribute can't be read without a FieldDecryptor")
+)
+
+// FieldEncryptor encrypts the values of fields with the encrypted attribute as they are written, such as csv:"header:ssn;encrypted", so that sensitive columns
+// are encrypted at rest in the files that are exchanged. The encrypted value is written as it is returned, so it should be text, such as base64.
+type FieldEncryptor interface {
+	EncryptField(fieldName string, value string) (encrypted string, err error)
+}
+
+// FieldDecryptor decrypts the values of fields with the encrypted attribute as they are read, before they are trimmed, masked and set on the field.
+type FieldDecryptor interface {
+	DecryptField(fieldName string, value string) (decrypted string, err error)
+}
+
+// encryptValue encrypts value for the named field with the writer's FieldEncryptor, if the field has the encrypted attribute. Empty values are left empty.
+func (w *Writer) encryptValue(fieldName string, attrs csvAttributes, value string) (encrypted string, err error) {
+	if !attrs.encrypted || value == "" {
+		return value, nil
+	}
+
+	if w.options.FieldEncryptor == nil {
+		return "", ErrorMissingFieldEncryptor
+	}
+
+	return w.options.FieldEncryptor.EncryptField(fieldName, value)
+}
+
+// decryptValue decrypts value for the named field with the parser's FieldDecryptor, if the field has the encrypted attribute. Empty values are left empty.
+func (p *Parser) decryptValue(fieldName string, attrs csvAttributes, value string) (decrypted string, err error) {
+	if !attrs.encrypted || value == "" {
+		return value, nil
+	}
+
+	if p.options.FieldDecryptor == nil {
+		return "", ErrorMissingFieldDecryptor
+	}
+
+	return p.options.FieldDecryptor.DecryptField(fieldName, value)
+}
@@ -0,0 +1,133 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type encryptTest struct {
+	Name string `csv:"header:name"`
+	SSN  string `csv:"header:ssn;encrypted"`
+	Age  int    `csv:"header:age;encrypted"`
+}
+
+// base64Encryptor stands in for a real cipher, encoding values with the field name so that the tests can check which field each value came from.
+type base64Encryptor struct{}
+
+func (base64Encryptor) EncryptField(fieldName string, value string) (string, error) {
+	return base64.StdEncoding.EncodeToString([]byte(fieldName + ":" + value)), nil
+}
+
+func (base64Encryptor) DecryptField(fieldName string, value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(string(decoded), fieldName+":") {
+		return "", errors.New("value was encrypted for another field")
+	}
+
+	return strings.TrimPrefix(string(decoded), fieldName+":"), nil
+}
+
+func TestEncryptedRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{FieldEncryptor: base64Encryptor{}})
+
+	err := w.WriteHeader(&encryptTest{})
+	if err != nil {
+		t.Fatalf("encountered error writing csv header: %v", err)
+	}
+
+	records := []encryptTest{{Name: "Ann", SSN: "123-45-6789", Age: 41}, {Name: "Bob", Age: 7}}
+	for idx := range records {
+		err = w.WriteRecord(&records[idx])
+		if err != nil {
+			t.Fatalf("encountered error writing csv record: %v", err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing csv writer: %v", err)
+	}
+
+	encrypt := func(fieldName string, value string) string {
+		encrypted, _ := base64Encryptor{}.EncryptField(fieldName, value)
+		return encrypted
+	}
+	expected := "name,ssn,age\nAnn," + encrypt("SSN", "123-45-6789") + "," + encrypt("Age", "41") + "\nBob,," + encrypt("Age", "7") + "\n"
+	if buf.String() != expected {
+		t.Fatalf("improperly encrypted records. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+
+	p := NewParser(&buf, ParserOptions{FieldDecryptor: base64Encryptor{}})
+
+	var record encryptTest
+	err = p.ParseHeader(&record)
+	if err != nil {
+		t.Fatalf("encountered error parsing csv header: %v", err)
+	}
+
+	for _, expected := range records {
+		record = encryptTest{}
+		err = p.ReadRecord(&record)
+		if err != nil {
+			t.Fatalf("encountered error reading csv record: %v", err)
+		}
+		if record != expected {
+			t.Errorf("improperly decrypted record. Got %+v but expected %+v", record, expected)
+		}
+	}
+}
+
+func TestEncryptedWithoutEncryptor(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+
+	err := w.WriteRecord(&encryptTest{Name: "Ann", SSN: "123-45-6789"})
+	if !errors.Is(err, ErrorMissingFieldEncryptor) {
+		t.Errorf("expected ErrorMissingFieldEncryptor but got %v", err)
+	}
+
+	var valueErr GetValueError
+	if !errors.As(err, &valueErr) || valueErr.FieldName != "SSN" {
+		t.Errorf("expected a GetValueError for field SSN but got %v", err)
+	}
+}
+
+func TestEncryptedWithoutDecryptor(t *testing.T) {
+	p := NewParser(strings.NewReader("name,ssn,age\nAnn,MTIz,\n"), ParserOptions{})
+
+	var record encryptTest
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Fatalf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if !errors.Is(err, ErrorMissingFieldDecryptor) {
+		t.Errorf("expected ErrorMissingFieldDecryptor but got %v", err)
+	}
+}
+
+func TestDecryptError(t *testing.T) {
+	p := NewParser(strings.NewReader("name,ssn,age\nAnn,not base64!,\n"), ParserOptions{FieldDecryptor: base64Encryptor{}})
+
+	var record encryptTest
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Fatalf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+
+	var valueErr SetValueError
+	if !errors.As(err, &valueErr) || valueErr.FieldName != "SSN" || valueErr.Line != 1 {
+		t.Errorf("expected a SetValueError for field SSN on line 1 but got %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+	"unsafe"
+)
+
+// FileParser is a Parser that reads a local file, which it memory maps where the platform supports it. It has every method of Parser, and must be closed once parsing is done.
+type FileParser struct {
+	Parser
+	file   *os.File
+	mapped []byte
+}
+
+// NewFileParser opens the file at path and creates a parser for it, as NewParser would. On platforms that support it the file is memory mapped, and records are read straight from
+// the mapping: each line is copied once, into the string its values are sliced from, rather than through a read buffer and then field by field, which suits analytics workloads on large
+// local files. Records with quoted values are read by the standard csv reader, as are all records when the options filter comments or blank lines or set a dialect or custom quoting.
+// If the file can't be mapped, such as when it is empty or isn't a regular file, it is read as usual. The parser is seekable either way, and structs stay valid after Close.
+// The mapping reads the file as it is on disk, so the file must not be truncated while it is parsed: reading past its new end faults the process with SIGBUS rather than returning an error.
+func NewFileParser(path string, options ParserOptions) (fp FileParser, err error) {
+	fp.file, err = os.Open(path)
+	if err != nil {
+		return fp, err
+	}
+
+	fp.mapped, err = mmapFile(fp.file)
+	if err != nil || fp.mapped == nil {
+		fp.mapped = nil
+		fp.Parser = NewParser(fp.file, options)
+		return fp, nil
+	}
+
+	fp.Parser = NewParser(bytes.NewReader(fp.mapped), options)
+	fp.useMappedReader(0)
+
+	return fp, nil
+}
+
+// Mapped reports whether the parser is reading from a memory mapped file, rather than falling back to reading the file as usual.
+func (fp *FileParser) Mapped() bool {
+	return fp.mapped != nil
+}
+
+// SeekToOffset moves the parser to the given byte offset of its file, as Parser.SeekToOffset does.
+func (fp *FileParser) SeekToOffset(offset int64) (err error) {
+	err = fp.Parser.SeekToOffset(offset)
+	if err != nil {
+		return err
+	}
+
+	fp.useMappedReader(offset)
+
+	return nil
+}
+
+// useMappedReader has the parser split the records of the mapped file in place from offset on, unless its options need the records read otherwise.
+func (fp *FileParser) useMappedReader(offset int64) {
+	options := fp.options.withDialect()
+	if fp.mapped == nil || options.Validate() != nil || needsLineFilter(options) || customQuoting(options) {
+		return
+	}
+
+	// The string shares the memory of the mapping, so that the file isn't copied as a whole. Records are copied out of it as they are read.
+	text := *(*string)(unsafe.Pointer(&fp.mapped))
+	fp.reader = newMappedReader(text[offset:], options)
+}
+
+// Close unmaps and closes the parser's file. The parser must not be used after it is closed.
+func (fp *FileParser) Close() (err error) {
+	if fp.mapped != nil {
+		err = munmapFile(fp.mapped)
+		fp.mapped = nil
+	}
+
+	closeErr := fp.file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// mappedReader reads the records of a memory mapped file as the standard csv reader would. Records whose lines hold no quote are copied out of the file and split
+// without any further copies. Records with quoted values, which may span lines and need their quotes removed, are read by a standard csv reader from where they start.
+type mappedReader struct {
+	text            string
+	offset          int
+	line            int
+	delimiter       string
+	comment         string
+	reuseRecord     bool
+	fieldsPerRecord int
+	record          []string
+	quoted          *csv.Reader
+	quotedSource    *bufio.Reader
+	quotedLines     int
+}
+
+func newMappedReader(text string, options ParserOptions) (reader *mappedReader) {
+	reader = &mappedReader{
+		text:         text,
+		delimiter:    ",",
+		reuseRecord:  options.ReuseRecord,
+		quotedSource: bufio.NewReader(nil),
+	}
+
+	if legalDelimiter(options.Delimiter) {
+		reader.delimiter = string(options.Delimiter)
+	}
+	if options.CommentChar != 0 {
+		reader.comment = string(options.CommentChar)
+	}
+
+	// The csv reader uses the buffered reader as it is, so that it can be pointed at each quoted record in turn.
+	reader.quoted = newCsvReader(reader.quotedSource, options)
+	reader.quoted.FieldsPerRecord = -1
+
+	return reader
+}
+
+func (r *mappedReader) Read() (record []string, err error) {
+	recordLine := r.line + 1
+	record, err = r.readRecord()
+	if err != nil {
+		return record, err
+	}
+
+	if r.fieldsPerRecord == 0 {
+		r.fieldsPerRecord = len(record)
+	} else if r.fieldsPerRecord > 0 && len(record) != r.fieldsPerRecord {
+		return record, &csv.ParseError{StartLine: recordLine, Line: recordLine, Column: 1, Err: csv.ErrFieldCount}
+	}
+
+	return record, nil
+}
+
+// InputOffset returns the number of bytes of input read so far, which is the offset of the start of the next record.
+func (r *mappedReader) InputOffset() int64 {
+	return int64(r.offset)
+}
+
+// readRecord reads the next record, skipping blank lines and comments as the standard csv reader does.
+func (r *mappedReader) readRecord() (record []string, err error) {
+	for {
+		if r.offset >= len(r.text) {
+			return nil, io.EOF
+		}
+
+		end := len(r.text)
+		if idx := strings.IndexByte(r.text[r.offset:], '\n'); idx >= 0 {
+			end = r.offset + idx + 1
+		}
+
+		line := strings.TrimSuffix(strings.TrimSuffix(r.text[r.offset:end], "\n"), "\r")
+		if line == "" || (r.comment != "" && strings.HasPrefix(line, r.comment)) {
+			r.offset = end
+			r.line++
+			continue
+		}
+
+		if strings.ContainsRune(line, '"') {
+			return r.readQuotedRecord()
+		}
+
+		r.offset = end
+		r.line++
+
+		return r.split(cloneString(line)), nil
+	}
+}
+
+// split splits line into its values, which share its memory.
+func (r *mappedReader) split(line string) (record []string) {
+	if r.reuseRecord {
+		record = r.record[:0]
+	}
+
+	for {
+		idx := strings.Index(line, r.delimiter)
+		if idx < 0 {
+			record = append(record, line)
+			break
+		}
+
+		record = append(record, line[:idx])
+		line = line[idx+len(r.delimiter):]
+	}
+
+	if r.reuseRecord {
+		r.record = record
+	}
+
+	return record
+}
+
+// readQuotedRecord reads the record starting at the reader's offset with the standard csv reader, and moves the offset past it. Line numbers of errors are counted from the start of the file.
+func (r *mappedReader) readQuotedRecord() (record []string, err error) {
+	r.quotedSource.Reset(strings.NewReader(r.text[r.offset:]))
+
+	start := r.quoted.InputOffset()
+	record, err = r.quoted.Read()
+	consumed := r.text[r.offset : r.offset+int(r.quoted.InputOffset()-start)]
+
+	// The csv reader counts every line it reads, including a last line without a line break.
+	lines := strings.Count(consumed, "\n")
+	if consumed != "" && !strings.HasSuffix(consumed, "\n") {
+		lines++
+	}
+
+	if parseErr, ok := err.(*csv.ParseError); ok {
+		shift := r.line - r.quotedLines
+		parseErr.StartLine += shift
+		parseErr.Line += shift
+	}
+
+	r.offset += len(consumed)
+	r.line += lines
+	r.quotedLines += lines
+
+	return record, err
+}
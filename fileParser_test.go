@@ -0,0 +1,173 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFileParser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.csv")
+	err := os.WriteFile(path, []byte(indexTestData), 0o600)
+	if err != nil {
+		t.Errorf("encountered error writing test file: %v", err)
+		return
+	}
+
+	fp, err := NewFileParser(path, ParserOptions{Delimiter: '\t'})
+	if err != nil {
+		t.Errorf("encountered error opening file parser: %v", err)
+		return
+	}
+
+	var records []indexTest
+	for {
+		var record indexTest
+		err := fp.ReadRecord(&record)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Errorf("encountered error parsing csv file: %v", err)
+			break
+		}
+		records = append(records, record)
+	}
+
+	err = fp.Close()
+	if err != nil {
+		t.Errorf("encountered error closing file parser: %v", err)
+	}
+
+	if len(records) != len(indexTestResults) {
+		t.Errorf("expected to read %d records, but got %d", len(indexTestResults), len(records))
+		return
+	}
+
+	// the records must stay valid once the file has been unmapped
+	for i, record := range records {
+		if record != indexTestResults[i] {
+			t.Errorf("expected record %d to be %+v, but got %+v", i, indexTestResults[i], record)
+		}
+	}
+}
+
+func TestFileParserEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.csv")
+	err := os.WriteFile(path, nil, 0o600)
+	if err != nil {
+		t.Errorf("encountered error writing test file: %v", err)
+		return
+	}
+
+	fp, err := NewFileParser(path, ParserOptions{})
+	if err != nil {
+		t.Errorf("encountered error opening file parser: %v", err)
+		return
+	}
+	defer fp.Close()
+
+	if fp.Mapped() {
+		t.Errorf("expected an empty file to be read without mapping it")
+	}
+
+	err = fp.ReadRecord(&indexTest{})
+	if err != io.EOF {
+		t.Errorf("expected to encounter io.EOF, but got %v", err)
+	}
+}
+
+func TestFileParserNotFound(t *testing.T) {
+	_, err := NewFileParser(filepath.Join(t.TempDir(), "missing.csv"), ParserOptions{})
+	if !os.IsNotExist(err) {
+		t.Errorf("expected to encounter a not exist error, but got %v", err)
+	}
+}
+
+// readOffsetRecords reads every record of p, along with the error and byte offset after each.
+func readOffsetRecords(p *Parser) (results []string) {
+	for {
+		record, err := p.readRecord()
+		offset, _ := p.ByteOffset()
+		results = append(results, fmt.Sprintf("%q %v %d", record, err, offset))
+		if err == io.EOF {
+			return results
+		}
+	}
+}
+
+func TestFileParserMappedRecords(t *testing.T) {
+	for _, test := range []struct {
+		data    string
+		options ParserOptions
+	}{
+		{data: "a,b,c\n1,2,3\r\n\n4,,6\n7,8,9"},
+		{data: "a,b\n\"quoted, value\",2\n\"multi\nline\",\"say \"\"hi\"\"\"\n3,4\n"},
+		{data: "a,b\n1,2,3\n\"bare\"quote,1\n4,5\n\"unterminated,6\n"},
+		{data: "# comment, with \"quote\"\na;b\n# more\n1;2\n", options: ParserOptions{Delimiter: ';', CommentChar: '#'}},
+		{data: "a§b\nnaïve§2\nlast§\"3\"", options: ParserOptions{Delimiter: '§', ReuseRecord: true}},
+		{data: "a\tb\r\n1\t2\r", options: ParserOptions{Delimiter: '\t'}},
+	} {
+		path := filepath.Join(t.TempDir(), "records.csv")
+		err := os.WriteFile(path, []byte(test.data), 0o600)
+		if err != nil {
+			t.Fatalf("encountered error writing test file: %v", err)
+		}
+
+		fp, err := NewFileParser(path, test.options)
+		if err != nil {
+			t.Fatalf("encountered error opening file parser: %v", err)
+		}
+
+		if _, split := fp.reader.(*mappedReader); fp.Mapped() && !split {
+			t.Errorf("expected the records of the mapped file %q to be split in place", test.data)
+		}
+
+		expectedParser := NewParser(strings.NewReader(test.data), test.options)
+		expected := readOffsetRecords(&expectedParser)
+		results := readOffsetRecords(&fp.Parser)
+
+		if !reflect.DeepEqual(results, expected) {
+			t.Errorf("expected the mapped file %q to be read as\n%s\nbut got\n%s", test.data, strings.Join(expected, "\n"), strings.Join(results, "\n"))
+		}
+
+		err = fp.Close()
+		if err != nil {
+			t.Errorf("encountered error closing file parser: %v", err)
+		}
+	}
+}
+
+func TestFileParserSeek(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seek.csv")
+	err := os.WriteFile(path, []byte("a,1\nb,2\nc,3\n"), 0o600)
+	if err != nil {
+		t.Fatalf("encountered error writing test file: %v", err)
+	}
+
+	fp, err := NewFileParser(path, ParserOptions{})
+	if err != nil {
+		t.Fatalf("encountered error opening file parser: %v", err)
+	}
+	defer fp.Close()
+
+	err = fp.SeekToOffset(4)
+	if err != nil {
+		t.Fatalf("encountered error seeking: %v", err)
+	}
+
+	var record peekTest
+	err = fp.ReadRecord(&record)
+	if err != nil || record != (peekTest{Section: "b", Count: 2}) {
+		t.Errorf("expected to read the record at the offset, but got %+v and error %v", record, err)
+	}
+
+	offset, err := fp.ByteOffset()
+	if err != nil || offset != 8 {
+		t.Errorf("expected the offset of the next record to be 8, but got %d and error %v", offset, err)
+	}
+}
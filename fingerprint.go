@@ -0,0 +1,70 @@
+package csv
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaFingerprint returns a hex encoded SHA-256 hash of the csv layout described by the csv decorator tags defined on structPointer, so that a pipeline can detect
+// schema changes between runs and key cached artifacts by layout. The hash covers the header name, index and type of each bound field, in the order the fields are defined.
+// Header names are compared without regard to case or surrounding spaces, and renaming a Go field without changing its tag leaves the fingerprint unchanged.
+// Tags are read as a parser with the default options reads them.
+func SchemaFingerprint(structPointer interface{}) (fingerprint string, err error) {
+	err = checkStructPointer(structPointer)
+	if err != nil {
+		return "", err
+	}
+
+	columns, err := StructColumns(reflect.TypeOf(structPointer))
+	if err != nil {
+		return "", err
+	}
+
+	records := make([][]string, len(columns))
+	for idx, column := range columns {
+		index := ""
+		if column.HasIndex {
+			index = strconv.Itoa(column.Index)
+		}
+
+		records[idx] = []string{normalizeFingerprintLabel(column.HeaderName), index, column.Type.String()}
+	}
+
+	return fingerprintRecords(records)
+}
+
+// HeaderFingerprint returns a hex encoded SHA-256 hash of the parsed header, so that a pipeline can detect when the layout of a feed changes between runs.
+// Labels are compared without regard to case, surrounding spaces or a byte order mark, but their order matters. It returns ErrorHeaderNotParsed if no header has been parsed.
+func (p *Parser) HeaderFingerprint() (fingerprint string, err error) {
+	if p.header == nil {
+		return "", ErrorHeaderNotParsed
+	}
+
+	labels := make([]string, len(p.header))
+	for idx, label := range p.header {
+		labels[idx] = normalizeFingerprintLabel(label)
+	}
+
+	return fingerprintRecords([][]string{labels})
+}
+
+// normalizeFingerprintLabel reduces a header label to the form that is hashed into a fingerprint.
+func normalizeFingerprintLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(label, "\ufeff")))
+}
+
+// fingerprintRecords returns the hex encoded SHA-256 hash of records written as csv, so that values containing delimiters can't collide with separate values.
+func fingerprintRecords(records [][]string) (fingerprint string, err error) {
+	hash := sha256.New()
+
+	err = csv.NewWriter(hash).WriteAll(records)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
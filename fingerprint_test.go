@@ -0,0 +1,85 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type fingerprintTest struct {
+	ID     int     `csv:"header:id"`
+	Amount float64 `csv:"header:amount;precision:2"`
+}
+
+type fingerprintRenamed struct {
+	Key   int     `csv:"header: ID "`
+	Total float64 `csv:"header:amount"`
+}
+
+type fingerprintRetyped struct {
+	ID     string  `csv:"header:id"`
+	Amount float64 `csv:"header:amount"`
+}
+
+func TestSchemaFingerprint(t *testing.T) {
+	original, err := SchemaFingerprint(&fingerprintTest{})
+	if err != nil {
+		t.Fatalf("encountered error fingerprinting schema: %v", err)
+	}
+
+	if len(original) != 64 {
+		t.Errorf("expected a hex encoded SHA-256 hash, but got '%s'", original)
+	}
+
+	renamed, err := SchemaFingerprint(&fingerprintRenamed{})
+	if err != nil {
+		t.Fatalf("encountered error fingerprinting schema: %v", err)
+	}
+	if renamed != original {
+		t.Errorf("expected renaming fields and changing the case of headers to keep the fingerprint")
+	}
+
+	retyped, err := SchemaFingerprint(&fingerprintRetyped{})
+	if err != nil {
+		t.Fatalf("encountered error fingerprinting schema: %v", err)
+	}
+	if retyped == original {
+		t.Errorf("expected changing the type of a field to change the fingerprint")
+	}
+
+	_, err = SchemaFingerprint(fingerprintTest{})
+	if !errors.Is(err, ErrorInvalidArgument) {
+		t.Errorf("expected %v for a struct that isn't a pointer, but got %v", ErrorInvalidArgument, err)
+	}
+}
+
+func TestHeaderFingerprint(t *testing.T) {
+	fingerprint := func(file string) string {
+		p := NewParser(strings.NewReader(file), ParserOptions{})
+		_, err := p.ReadRecordMap()
+		if err != nil && err != io.EOF {
+			t.Fatalf("encountered error parsing header: %v", err)
+		}
+
+		result, err := p.HeaderFingerprint()
+		if err != nil {
+			t.Fatalf("encountered error fingerprinting header: %v", err)
+		}
+		return result
+	}
+
+	original := fingerprint("id,amount\n1,2\n")
+	if fingerprint("\ufeffID, Amount\n") != original {
+		t.Errorf("expected case, spaces and a byte order mark not to change the fingerprint")
+	}
+	if fingerprint("amount,id\n") == original {
+		t.Errorf("expected reordering columns to change the fingerprint")
+	}
+
+	p := NewParser(strings.NewReader("id,amount\n"), ParserOptions{})
+	_, err := p.HeaderFingerprint()
+	if !errors.Is(err, ErrorHeaderNotParsed) {
+		t.Errorf("expected %v before the header is parsed, but got %v", ErrorHeaderNotParsed, err)
+	}
+}
@@ -0,0 +1,119 @@
+package csv
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// FixedWidthParser reads records from a fixed-width file, where each field is found at the same character positions on every line.
+// Structs are bound with the same csv tags as Parser, using the pos attribute in place of header and index, and values are set, validated and reported exactly as Parser does.
+type FixedWidthParser struct {
+	parser Parser
+	reader *bufio.Reader
+}
+
+// NewFixedWidthParser creates a new fixed-width parser for the provided file. Blank lines are skipped, as are lines starting with the CommentChar of options.
+// The Delimiter and ReuseRecord options have no effect on a fixed-width file.
+func NewFixedWidthParser(file io.Reader, options ParserOptions) (fp FixedWidthParser) {
+	fp.parser.csvAttrs = make(map[string]csvAttributes)
+	fp.parser.options = options
+	fp.reader = bufio.NewReader(file)
+
+	return fp
+}
+
+func (fp *FixedWidthParser) bind(structPointer interface{}) (err error) {
+	err = checkStructPointer(structPointer)
+	if err != nil {
+		return err
+	}
+
+	p := &fp.parser
+	if len(p.csvAttrs) != 0 {
+		return p.checkBoundType(reflect.TypeOf(structPointer))
+	}
+	p.boundType = reflect.TypeOf(structPointer)
+
+	options := p.tagOptions()
+	options.fixedWidth = true
+
+	p.csvAttrs, err = getCsvAttributes(structPointer, options)
+	if err != nil {
+		return err
+	}
+
+	// Each line is sliced into a record holding the fields in struct order, so that the record can be set like any other.
+	p.fieldOrder = getFieldOrder(p.csvAttrs)
+	for idx, fieldName := range p.fieldOrder {
+		attrs := p.csvAttrs[fieldName]
+		attrs.columnIndex = idx
+		p.csvAttrs[fieldName] = attrs
+	}
+
+	_, implementsDecoder := structPointer.(RecordDecoder)
+	p.useDecoder = implementsDecoder && p.canUseCodec()
+
+	return nil
+}
+
+// ReadRecord reads the next line of the parser's fixed-width file and sets the data found at each field's pos on structPointer.
+// The structPointer should be pointer to a struct with csv decorator tags applied, all of which specify a pos. A line too short to reach a field gives it the part of its value that is present, if any.
+func (fp *FixedWidthParser) ReadRecord(structPointer interface{}) (err error) {
+	err = fp.bind(structPointer)
+	if err != nil {
+		return err
+	}
+
+	fp.parser.line++
+	line, err := fp.readLine()
+	if err != nil {
+		return err
+	}
+
+	return fp.parser.setRecord(structPointer, fp.splitLine(line))
+}
+
+// readLine returns the next line of the file that is neither blank nor a comment, without its line ending.
+func (fp *FixedWidthParser) readLine() (line string, err error) {
+	for {
+		line, err = fp.reader.ReadString('\n')
+		if line == "" && err != nil {
+			return "", err
+		}
+
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		if line == "" {
+			continue
+		}
+
+		commentChar := fp.parser.options.CommentChar
+		if commentChar != 0 && strings.HasPrefix(line, string(commentChar)) {
+			continue
+		}
+
+		return line, nil
+	}
+}
+
+func (fp *FixedWidthParser) splitLine(line string) (record []string) {
+	runes := []rune(line)
+	record = make([]string, len(fp.parser.fieldOrder))
+
+	for idx, fieldName := range fp.parser.fieldOrder {
+		attrs := fp.parser.csvAttrs[fieldName]
+
+		start, end := attrs.posStart, attrs.posEnd
+		if start > len(runes) {
+			start = len(runes)
+		}
+		if end > len(runes) {
+			end = len(runes)
+		}
+
+		record[idx] = string(runes[start:end])
+	}
+
+	return record
+}
@@ -0,0 +1,112 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type fixedWidthTest struct {
+	ID     int     `csv:"pos:1-4"`
+	Name   string  `csv:"pos:5-14;trim"`
+	Price  float64 `csv:"pos:15-22;trim;currency;precision:2"`
+	Active bool    `csv:"pos:23"`
+}
+
+func TestFixedWidthParser(t *testing.T) {
+	data := "0001Widget       $4.99t\n" +
+		"# discontinued products follow\n" +
+		"\n" +
+		"0002Gizmo é    1,200.5f\r\n" +
+		"0003Short"
+
+	p := NewFixedWidthParser(strings.NewReader(data), ParserOptions{CommentChar: '#'})
+
+	expected := []fixedWidthTest{
+		{ID: 1, Name: "Widget", Price: 4.99, Active: true},
+		{ID: 2, Name: "Gizmo é", Price: 1200.5, Active: false},
+	}
+	for _, want := range expected {
+		var record fixedWidthTest
+		err := p.ReadRecord(&record)
+		if err != nil {
+			t.Errorf("encountered error reading fixed-width record: %v", err)
+		}
+		if record != want {
+			t.Errorf("improperly read fixed-width record. Got '%+v' but expected '%+v'", record, want)
+		}
+	}
+
+	var record fixedWidthTest
+	err := p.ReadRecord(&record)
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 3 {
+		t.Errorf("expected a SetValueError on line 3, but got %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != io.EOF {
+		t.Errorf("expected to reach the end of the file, but got %v", err)
+	}
+}
+
+type fixedWidthMissingPos struct {
+	ID   int    `csv:"pos:1-4"`
+	Name string `csv:"header:name"`
+}
+
+func TestFixedWidthMissingPositionError(t *testing.T) {
+	p := NewFixedWidthParser(strings.NewReader("0001Widget"), ParserOptions{})
+
+	err := p.ReadRecord(&fixedWidthMissingPos{})
+	if !errors.Is(err, ErrorMissingPosition) {
+		t.Errorf("expected to encounter Missing Position error, but got %v", err)
+	}
+}
+
+type fixedWidthInvalidPos struct {
+	ID int `csv:"pos:4-1"`
+}
+
+func TestFixedWidthInvalidPositionError(t *testing.T) {
+	p := NewFixedWidthParser(strings.NewReader("0001"), ParserOptions{})
+
+	err := p.ReadRecord(&fixedWidthInvalidPos{})
+	if !errors.Is(err, ErrorInvalidPosition) {
+		t.Errorf("expected to encounter Invalid Position error, but got %v", err)
+	}
+}
+
+func TestPositionOnlyCsvTagError(t *testing.T) {
+	p := NewParser(strings.NewReader("0001"), ParserOptions{})
+
+	err := p.ReadRecord(&fixedWidthInvalidPos{})
+	if !errors.Is(err, ErrorInvalidPosition) {
+		t.Errorf("expected to encounter Invalid Position error, but got %v", err)
+	}
+
+	err = p.ReadRecord(&fixedWidthTest{})
+	if !errors.Is(err, ErrorMalformedCsvTag) {
+		t.Errorf("expected to encounter Malformed Csv Tag error, but got %v", err)
+	}
+}
+
+func TestFixedWidthOtherStructType(t *testing.T) {
+	p := NewFixedWidthParser(strings.NewReader("0001Widget       $4.99t\n0002Gizmo      $1.00f"), ParserOptions{})
+
+	err := p.ReadRecord(&fixedWidthInvalidPos{})
+	if !errors.Is(err, ErrorInvalidPosition) {
+		t.Errorf("expected to encounter Invalid Position error, but got %v", err)
+	}
+
+	err = p.ReadRecord(&fixedWidthTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing fixed-width file: %v", err)
+	}
+
+	err = p.ReadRecord(&fixedWidthMissingPos{})
+	if !errors.Is(err, ErrorBoundTypeMismatch) {
+		t.Errorf("expected to encounter ErrorBoundTypeMismatch error, but got %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+package csv
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"regexp/syntax"
+	"strings"
+	"time"
+)
+
+// fixtureAttempts is the number of values generated for a field before giving up on finding one that passes its min, max and regex attributes.
+const fixtureAttempts = 100
+
+// fixtureSpan is the width of the range numbers are generated in when a field doesn't set both min and max.
+const fixtureSpan = 1000
+
+// FixtureOptions sets how GenerateFixture generates records.
+type FixtureOptions struct {
+	// Seed seeds the generated values, so that the same seed always generates the same fixture for the same struct.
+	Seed int64
+	// OmitHeader leaves out the header, which is written before the records by default.
+	OmitHeader bool
+	// WriterOptions sets how the records are written, such as the delimiter and any converters for fields of other types.
+	WriterOptions WriterOptions
+}
+
+// GenerateFixture generates n records of synthetic csv data for the struct structPointer points to, such as to seed tests or load generators. Values are generated for fields of the
+// default data types and time.Time, within the field's min and max attributes, or between 0 and 1000, and rounded to the field's precision. The values of string fields with a regex
+// attribute match it, so a regex such as ^(new|paid|shipped)$ lists the values of an enumeration. Every value passes the field's validation rules, and a field whose rules no generated value
+// passes is reported as a ValidationError. Values are written with the field's attributes, converters and custom getters, so fields of other types are written from their zero values.
+func GenerateFixture(structPointer interface{}, n int, options FixtureOptions) (fixture io.Reader, err error) {
+	err = checkStructPointer(structPointer)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, options.WriterOptions)
+	structType := reflect.TypeOf(structPointer).Elem()
+
+	err = w.bind(reflect.New(structType).Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.OmitHeader {
+		err = w.WriteHeader(reflect.New(structType).Interface())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	generator := fixtureGenerator{random: rand.New(rand.NewSource(options.Seed))}
+	for line := 1; line <= n; line++ {
+		record := reflect.New(structType)
+		for _, fieldName := range w.fieldOrder {
+			err = generator.setField(&w, record, fieldName, line)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = w.WriteRecord(record.Interface())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+// fixtureGenerator generates the values of fixture records.
+type fixtureGenerator struct {
+	random *rand.Rand
+}
+
+// setField sets the named field of record to a generated value that passes the field's validation rules, retrying with other values as needed.
+func (g fixtureGenerator) setField(w *Writer, record reflect.Value, fieldName string, line int) (err error) {
+	attrs := w.csvAttrs[fieldName]
+	if attrs.useCustomSetter || attrs.headerPattern != nil {
+		return nil
+	}
+
+	field := record.Elem().Field(attrs.fieldIndex)
+
+	var value, rule string
+	for attempt := 0; attempt < fixtureAttempts; attempt++ {
+		if !g.generate(field, attrs) {
+			return nil
+		}
+
+		value, err = w.getFieldValue(record.Interface(), fieldName)
+		if err != nil {
+			return GetValueError{
+				Line:      line,
+				FieldName: fieldName,
+				Err:       err,
+			}
+		}
+
+		if attrs.omitempty && field.IsZero() {
+			return nil
+		}
+
+		rule, err = validateValue(field, attrs, value)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return ValidationError{
+		Line:      line,
+		Value:     value,
+		FieldName: fieldName,
+		Rule:      rule,
+		Err:       err,
+	}
+}
+
+// generate sets field to a random value within the bounds set by attrs. It reports false for fields of types it doesn't generate values for.
+func (g fixtureGenerator) generate(field reflect.Value, attrs csvAttributes) (generated bool) {
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+		field.Set(reflect.ValueOf(time.Unix(start+g.random.Int63n(30*365*24*60*60), 0).UTC()))
+		return true
+	}
+
+	if attrs.converter != nil {
+		return false
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		if attrs.pattern != nil {
+			field.SetString(g.matching(attrs.pattern.String()))
+		} else {
+			field.SetString(g.letters(8))
+		}
+	case reflect.Bool:
+		field.SetBool(g.random.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := field.Type().Bits()
+		low, high := g.bounds(attrs, -math.Ldexp(1, bits-1), math.Ldexp(1, bits-1)-1, fixtureSpan)
+		field.SetInt(int64(g.between(math.Ceil(low), math.Floor(high))))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bits := field.Type().Bits()
+		low, high := g.bounds(attrs, 0, math.Ldexp(1, bits)-1, fixtureSpan)
+		field.SetUint(uint64(g.between(math.Ceil(low), math.Floor(high))))
+	case reflect.Float32, reflect.Float64:
+		// Percentages are generated as fractions, as they are written multiplied by 100.
+		span := float64(fixtureSpan)
+		if attrs.percent {
+			span = 1
+		}
+		low, high := g.bounds(attrs, -math.MaxFloat32, math.MaxFloat32, span)
+
+		precision := 2
+		if attrs.percent {
+			precision = 4
+		}
+		if attrs.hasPrecision {
+			precision = attrs.precision
+		}
+		field.SetFloat(roundFloat(low+g.random.Float64()*(high-low), precision, field.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		field.SetComplex(complex(math.Round(g.random.Float64()*fixtureSpan*100)/100, math.Round(g.random.Float64()*fixtureSpan*100)/100))
+	default:
+		return false
+	}
+
+	return true
+}
+
+// bounds returns the range numbers are generated in for a field, from its min and max attributes, within the limits of its type. Without both attributes,
+// the range spans span from whichever is set, or from 0, and a positive max alone is generated up to from 0.
+func (g fixtureGenerator) bounds(attrs csvAttributes, lowest float64, highest float64, span float64) (low float64, high float64) {
+	switch {
+	case attrs.hasMin && attrs.hasMax:
+		low, high = attrs.min, attrs.max
+	case attrs.hasMin:
+		low, high = attrs.min, attrs.min+span
+	case attrs.hasMax:
+		low, high = attrs.max-span, attrs.max
+		if attrs.max > 0 {
+			low = math.Max(low, 0)
+		}
+	default:
+		low, high = 0, span
+	}
+
+	return math.Max(low, lowest), math.Min(high, highest)
+}
+
+// between returns a whole number from low to high, inclusive. It returns low when the range is empty, leaving validation to reject it.
+func (g fixtureGenerator) between(low float64, high float64) float64 {
+	if high <= low {
+		return low
+	}
+
+	return low + math.Floor(g.random.Float64()*(high-low+1))
+}
+
+// letters returns n random lower case letters.
+func (g fixtureGenerator) letters(n int) string {
+	var value strings.Builder
+	for i := 0; i < n; i++ {
+		value.WriteByte(byte('a' + g.random.Intn(26)))
+	}
+
+	return value.String()
+}
+
+// matching returns a random string matched by the regular expression pattern, as compiled by regexp. Repeats without an upper bound are repeated at most three more times than needed.
+// Anchors and word boundaries are ignored, so the string may not match a pattern that depends on them, and is then rejected by validation.
+func (g fixtureGenerator) matching(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+
+	var value strings.Builder
+	g.writeMatching(&value, re.Simplify())
+
+	return value.String()
+}
+
+// writeMatching writes a random string matched by re to value.
+func (g fixtureGenerator) writeMatching(value *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		value.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		value.WriteRune(g.classRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		value.WriteByte(byte('a' + g.random.Intn(26)))
+	case syntax.OpCapture:
+		g.writeMatching(value, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.writeMatching(value, sub)
+		}
+	case syntax.OpAlternate:
+		g.writeMatching(value, re.Sub[g.random.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		low, high := 0, 3
+		switch re.Op {
+		case syntax.OpPlus:
+			low, high = 1, 4
+		case syntax.OpQuest:
+			high = 1
+		case syntax.OpRepeat:
+			low, high = re.Min, re.Max
+			if high < 0 {
+				high = low + 3
+			}
+		}
+
+		for count := low + g.random.Intn(high-low+1); count > 0; count-- {
+			g.writeMatching(value, re.Sub[0])
+		}
+	}
+}
+
+// classRune returns a random rune of the character class ranges, preferring printable ascii characters.
+func (g fixtureGenerator) classRune(ranges []rune) rune {
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := ranges[i]; r <= ranges[i+1] && r <= '~'; r++ {
+			if r >= ' ' {
+				printable = append(printable, r)
+			}
+		}
+	}
+	if len(printable) > 0 {
+		return printable[g.random.Intn(len(printable))]
+	}
+
+	if len(ranges) < 2 {
+		return 'a'
+	}
+
+	pair := g.random.Intn(len(ranges)/2) * 2
+	return ranges[pair] + rune(g.random.Int63n(int64(ranges[pair+1]-ranges[pair])+1))
+}
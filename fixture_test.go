@@ -0,0 +1,104 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+type fixtureTest struct {
+	ID       int     `csv:"header:id;min:1;max:99"`
+	Status   string  `csv:"header:status;regex:^(new|paid|shipped)$"`
+	Code     string  `csv:"header:code;regex:^[A-Z]{3}-\\d{4}$"`
+	Name     string  `csv:"header:name"`
+	Price    float64 `csv:"header:price;precision:2;min:0.5;max:20"`
+	Discount float64 `csv:"header:discount;percent;max:0.5"`
+	Count    uint8   `csv:"header:count;min:200"`
+	Active   bool    `csv:"header:active"`
+}
+
+func TestGenerateFixture(t *testing.T) {
+	fixture, err := GenerateFixture(&fixtureTest{}, 50, FixtureOptions{Seed: 7})
+	if err != nil {
+		t.Fatalf("encountered error generating fixture: %v", err)
+	}
+
+	p := NewParser(fixture, ParserOptions{})
+
+	var record fixtureTest
+	err = p.ParseHeader(&record)
+	if err != nil {
+		t.Fatalf("encountered error parsing csv header: %v", err)
+	}
+
+	statuses := make(map[string]bool)
+	count := 0
+	for {
+		err = p.ReadRecord(&record)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("encountered error reading generated record: %v", err)
+		}
+		count++
+
+		statuses[record.Status] = true
+		if record.Count < 200 {
+			t.Errorf("expected count of at least 200 but got %d", record.Count)
+		}
+		if record.Discount < 0 || record.Discount > 0.5 {
+			t.Errorf("expected discount between 0 and 0.5 but got %v", record.Discount)
+		}
+		if len(record.Name) != 8 {
+			t.Errorf("expected a name of 8 letters but got '%s'", record.Name)
+		}
+	}
+
+	if count != 50 {
+		t.Errorf("expected 50 records but got %d", count)
+	}
+	if len(statuses) != 3 {
+		t.Errorf("expected every status to be generated but got %v", statuses)
+	}
+}
+
+func TestGenerateFixtureSeed(t *testing.T) {
+	generate := func(seed int64) string {
+		fixture, err := GenerateFixture(&fixtureTest{}, 5, FixtureOptions{Seed: seed, OmitHeader: true})
+		if err != nil {
+			t.Fatalf("encountered error generating fixture: %v", err)
+		}
+
+		data, err := io.ReadAll(fixture)
+		if err != nil {
+			t.Fatalf("encountered error reading fixture: %v", err)
+		}
+		return string(data)
+	}
+
+	if generate(1) != generate(1) {
+		t.Errorf("expected the same fixture for the same seed")
+	}
+	if generate(1) == generate(2) {
+		t.Errorf("expected different fixtures for different seeds")
+	}
+}
+
+type impossibleFixtureTest struct {
+	Size int `csv:"header:size;min:1.2;max:1.8"`
+}
+
+func TestGenerateFixtureImpossible(t *testing.T) {
+	_, err := GenerateFixture(&impossibleFixtureTest{}, 1, FixtureOptions{})
+
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) || validationErr.FieldName != "Size" {
+		t.Errorf("expected a ValidationError for field Size but got %v", err)
+	}
+
+	_, err = GenerateFixture(impossibleFixtureTest{}, 1, FixtureOptions{})
+	if !errors.Is(err, ErrorInvalidArgument) {
+		t.Errorf("expected ErrorInvalidArgument but got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package csv
+
+import (
+	"fmt"
+)
+
+var (
+	ErrorWriterClosed = fmt.Errorf("cannot write to a writer after it is closed")
+)
+
+// FlushEvery makes the writer flush its buffered data to the underlying file after every n lines it writes, including the header, so that a long-running export
+// leaves a usable file behind if it stops part way, and write errors are reported by the write that caused them rather than at the end. An n of zero or less
+// turns automatic flushing off, which is the default.
+func (w *Writer) FlushEvery(n int) {
+	if n < 0 {
+		n = 0
+	}
+
+	w.flushEvery = n
+	w.unflushed = 0
+}
+
+// write writes record as the next line of the writer's csv file, flushing it if FlushEvery lines have been written since the last flush.
+func (w *Writer) write(record []string) (err error) {
+	if w.closed {
+		return ErrorWriterClosed
+	}
+
+	err = w.writer.Write(record)
+	if err != nil {
+		return err
+	}
+
+	w.unflushed++
+	if w.flushEvery > 0 && w.unflushed >= w.flushEvery {
+		return w.Flush()
+	}
+
+	return nil
+}
+
+// Flush writes any buffered data to the underlying file, and reports any error that occurred during a previous write or flush.
+// When the output is compressed, the data written so far is flushed through the compressor, which costs a little compression.
+func (w *Writer) Flush() (err error) {
+	w.unflushed = 0
+	w.writer.Flush()
+
+	err = w.writer.Error()
+	if err != nil || w.compressor == nil {
+		return err
+	}
+
+	return w.compressor.Flush()
+}
+
+// Error reports the OptionError of the writer's options, or any error that occurred writing to the underlying file during a previous write or flush.
+// Errors writing to the file are kept, so once one occurs, every later write, flush and call to Error reports it.
+func (w *Writer) Error() (err error) {
+	if w.optionsErr != nil {
+		return w.optionsErr
+	}
+
+	return w.writer.Error()
+}
+
+// Close flushes any buffered data to the underlying file, and reports any error that occurred during a previous write or flush, so that it can be deferred
+// to make sure the tail of the file is written. Records held for sorting are written, and a compressed stream is finished. Later writes return ErrorWriterClosed. As with gzip.Writer, the underlying file is not closed.
+func (w *Writer) Close() (err error) {
+	if w.closed {
+		return w.Error()
+	}
+
+	err = w.finishSorted()
+	w.closed = true
+	if err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+
+	err = w.writer.Error()
+	if err != nil || w.compressor == nil {
+		return err
+	}
+
+	return w.compressor.Close()
+}
@@ -0,0 +1,140 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type flushTest struct {
+	Name  string `csv:"header:name"`
+	Count int    `csv:"header:count"`
+}
+
+// failingWriter accepts limit bytes, and then fails every write.
+type failingWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+var errorDiskFull = fmt.Errorf("disk full")
+
+func (f *failingWriter) Write(b []byte) (n int, err error) {
+	if f.buf.Len()+len(b) > f.limit {
+		return 0, errorDiskFull
+	}
+
+	return f.buf.Write(b)
+}
+
+func TestFlushEvery(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+	w.FlushEvery(2)
+
+	err := w.WriteHeader(&flushTest{})
+	if err != nil {
+		t.Fatalf("encountered error writing csv header: %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing to be flushed after one line, but got '%s'", buf.String())
+	}
+
+	err = w.WriteRecord(&flushTest{Name: "a", Count: 1})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+
+	expected := "name,count\na,1\n"
+	if buf.String() != expected {
+		t.Errorf("expected '%s' to be flushed after two lines, but got '%s'", expected, buf.String())
+	}
+
+	err = w.WriteRecord(&flushTest{Name: "b", Count: 2})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+
+	if buf.String() != expected {
+		t.Errorf("expected the third line to stay buffered, but got '%s'", buf.String())
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing csv writer: %v", err)
+	}
+
+	expected += "b,2\n"
+	if buf.String() != expected {
+		t.Errorf("expected close to flush the tail of the file. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+func TestFlushEveryReportsWriteError(t *testing.T) {
+	file := &failingWriter{limit: 5}
+	w := NewWriter(file, WriterOptions{})
+	w.FlushEvery(1)
+
+	err := w.WriteRecord(&flushTest{Name: "a", Count: 1})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+
+	err = w.WriteRecord(&flushTest{Name: "b", Count: 2})
+	if !errors.Is(err, errorDiskFull) {
+		t.Errorf("expected the write that failed to report %v, but got %v", errorDiskFull, err)
+	}
+
+	if !errors.Is(w.Error(), errorDiskFull) {
+		t.Errorf("expected Error to report %v, but got %v", errorDiskFull, w.Error())
+	}
+
+	err = w.WriteRecord(&flushTest{Name: "c", Count: 3})
+	if !errors.Is(err, errorDiskFull) {
+		t.Errorf("expected later writes to report %v, but got %v", errorDiskFull, err)
+	}
+
+	if !errors.Is(w.Close(), errorDiskFull) {
+		t.Errorf("expected Close to report %v", errorDiskFull)
+	}
+}
+
+func TestWriterClose(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+
+	err := w.WriteRecord(&flushTest{Name: "a", Count: 1})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing csv writer: %v", err)
+	}
+
+	if buf.String() != "a,1\n" {
+		t.Errorf("expected close to flush the record, but got '%s'", buf.String())
+	}
+
+	err = w.WriteRecord(&flushTest{Name: "b", Count: 2})
+	if !errors.Is(err, ErrorWriterClosed) {
+		t.Errorf("expected %v writing after close, but got %v", ErrorWriterClosed, err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Errorf("expected closing twice to succeed, but got %v", err)
+	}
+}
+
+func TestWriterErrorReportsOptions(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, WriterOptions{HeaderCase: HeaderCase(99)})
+
+	var optionErr OptionError
+	if !errors.As(w.Error(), &optionErr) {
+		t.Errorf("expected Error to report an OptionError, but got %v", w.Error())
+	}
+}
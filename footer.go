@@ -0,0 +1,139 @@
+package csv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorInvalidFooterRows = fmt.Errorf("footer rows must not be negative")
+)
+
+// footerState holds the records read ahead of the parser, so that the last FooterRows records of the file can be told apart from the rest.
+type footerState struct {
+	pending []footerRecord
+	eof     bool
+}
+
+type footerRecord struct {
+	record []string
+	err    error
+	offset int64
+}
+
+// nextRecord reads the next record that isn't a footer. Footer rows are passed to the FooterHandler as they are found, and take up a line number like any other record.
+func (p *Parser) nextRecord() (record []string, err error) {
+	for {
+		record, err = p.readAhead()
+		if err == nil || errors.Is(err, csv.ErrFieldCount) {
+			if p.options.IsFooter != nil && p.options.IsFooter(record) {
+				err = p.handleFooter(record)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		if err == nil {
+			err = p.observeRecord(record)
+		}
+
+		return record, p.checkTrailerSeen(err)
+	}
+}
+
+// readAhead reads the next record, keeping FooterRows records read ahead of it. Once the end of the file is reached, the records still read ahead are the footer rows,
+// which are handled before io.EOF is returned.
+func (p *Parser) readAhead() (record []string, err error) {
+	if p.options.FooterRows <= 0 {
+		return p.reader.Read()
+	}
+
+	if p.footer == nil {
+		p.footer = &footerState{}
+	}
+
+	for !p.footer.eof && len(p.footer.pending) <= p.options.FooterRows {
+		offset := int64(0)
+		if reader, ok := p.reader.(inputOffsetReader); ok {
+			offset = reader.InputOffset()
+		}
+
+		record, err := p.reader.Read()
+		if err == io.EOF {
+			p.footer.eof = true
+			break
+		}
+
+		// Keep a copy, since the reader may reuse the record on the next read.
+		p.footer.pending = append(p.footer.pending, footerRecord{record: append([]string(nil), record...), err: err, offset: offset})
+	}
+
+	if len(p.footer.pending) > p.options.FooterRows {
+		next := p.footer.pending[0]
+		p.footer.pending = p.footer.pending[1:]
+		return next.record, next.err
+	}
+
+	for len(p.footer.pending) > 0 {
+		footer := p.footer.pending[0]
+		p.footer.pending = p.footer.pending[1:]
+
+		// Footer rows often have fewer columns than the records above them, so that isn't an error.
+		if footer.err != nil && !errors.Is(footer.err, csv.ErrFieldCount) {
+			return nil, footer.err
+		}
+
+		err = p.handleFooter(footer.record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, io.EOF
+}
+
+// handleFooter passes a footer row to the FooterHandler, if there is one, and otherwise skips it.
+func (p *Parser) handleFooter(record []string) (err error) {
+	p.line++
+
+	err = p.reconcileTrailer(record, p.line)
+	if err != nil {
+		return err
+	}
+
+	if p.options.FooterHandler == nil {
+		return nil
+	}
+
+	err = p.options.FooterHandler(record, p.line)
+	if err != nil {
+		return RecordError{
+			Line: p.line,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// readerOffset returns the offset of the start of the next record the parser will return, relative to where its reader started, and whether the reader can report it.
+func (p *Parser) readerOffset() (offset int64, ok bool) {
+	reader, ok := p.reader.(inputOffsetReader)
+	if !ok {
+		return 0, false
+	}
+
+	if offset, pending := p.dedupe.pendingOffset(); pending {
+		return offset, true
+	}
+
+	if p.footer != nil && len(p.footer.pending) > 0 {
+		return p.footer.pending[0].offset, true
+	}
+
+	return reader.InputOffset(), true
+}
@@ -0,0 +1,137 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type footerOrder struct {
+	ID    int     `csv:"header:id"`
+	Total float64 `csv:"header:total"`
+}
+
+var footerReport = "id,total\n1,9.5\n2,3\nTOTAL,12.5\n"
+
+func readFooterOrders(t *testing.T, p *Parser) (orders []footerOrder) {
+	t.Helper()
+
+	var order footerOrder
+	err := p.ParseHeader(&order)
+	if err != nil {
+		t.Fatalf("encountered error parsing the header: %v", err)
+	}
+
+	for {
+		err = p.ReadRecord(&order)
+		if err == io.EOF {
+			return orders
+		}
+		if err != nil {
+			t.Fatalf("encountered error reading line %d: %v", p.Line(), err)
+		}
+		orders = append(orders, order)
+	}
+}
+
+func TestFooterRows(t *testing.T) {
+	var footers [][]string
+	var lines []int
+	p := NewParser(strings.NewReader(footerReport+"generated 2024-01-31\n"), ParserOptions{
+		FooterRows: 2,
+		FooterHandler: func(record []string, line int) error {
+			footers = append(footers, record)
+			lines = append(lines, line)
+			return nil
+		},
+	})
+
+	orders := readFooterOrders(t, &p)
+	if len(orders) != 2 || orders[1] != (footerOrder{ID: 2, Total: 3}) {
+		t.Errorf("improperly read the records above the footer. Got '%+v'", orders)
+	}
+
+	if len(footers) != 2 || footers[0][0] != "TOTAL" || footers[1][0] != "generated 2024-01-31" {
+		t.Errorf("improperly handled the footer rows. Got '%v'", footers)
+	}
+
+	if len(lines) != 2 || lines[0] != 3 || lines[1] != 4 {
+		t.Errorf("expected footer rows on lines 3 and 4, but got %v", lines)
+	}
+}
+
+func TestFooterRowsShortFile(t *testing.T) {
+	p := NewParser(strings.NewReader("id,total\n1,9.5\n"), ParserOptions{FooterRows: 2})
+
+	orders := readFooterOrders(t, &p)
+	if len(orders) != 0 {
+		t.Errorf("expected every record of a file shorter than the footer to be a footer row, but got '%+v'", orders)
+	}
+}
+
+func TestIsFooter(t *testing.T) {
+	p := NewParser(strings.NewReader("id,total\n1,9.5\nTOTAL,9.5\n2,3\nTOTAL,3\n"), ParserOptions{
+		IsFooter: func(record []string) bool {
+			return record[0] == "TOTAL"
+		},
+	})
+
+	orders := readFooterOrders(t, &p)
+	if len(orders) != 2 || orders[1] != (footerOrder{ID: 2, Total: 3}) {
+		t.Errorf("improperly skipped the footer rows. Got '%+v'", orders)
+	}
+}
+
+func TestFooterHandlerError(t *testing.T) {
+	errMismatch := errors.New("total doesn't match")
+	p := NewParser(strings.NewReader(footerReport), ParserOptions{
+		FooterRows: 1,
+		FooterHandler: func(record []string, line int) error {
+			return errMismatch
+		},
+	})
+
+	var order footerOrder
+	err := p.ParseHeader(&order)
+	if err != nil {
+		t.Fatalf("encountered error parsing the header: %v", err)
+	}
+
+	for err == nil {
+		err = p.ReadRecord(&order)
+	}
+
+	var recordError RecordError
+	if !errors.As(err, &recordError) || !errors.Is(err, errMismatch) || recordError.Line != 3 {
+		t.Errorf("expected a RecordError on line 3 wrapping the handler's error, but got %v", err)
+	}
+}
+
+func TestFooterByteOffset(t *testing.T) {
+	p := NewParser(strings.NewReader(footerReport), ParserOptions{FooterRows: 1})
+
+	var order footerOrder
+	err := p.ParseHeader(&order)
+	if err != nil {
+		t.Fatalf("encountered error parsing the header: %v", err)
+	}
+
+	err = p.ReadRecord(&order)
+	if err != nil {
+		t.Fatalf("encountered error reading the first record: %v", err)
+	}
+
+	// Records read ahead to find the footer aren't counted as read.
+	offset, err := p.ByteOffset()
+	if err != nil || offset != int64(len("id,total\n1,9.5\n")) {
+		t.Errorf("expected the offset of the second record, but got %d and error %v", offset, err)
+	}
+}
+
+func TestFooterRowsValidate(t *testing.T) {
+	err := ParserOptions{FooterRows: -1}.Validate()
+	if !errors.Is(err, ErrorInvalidFooterRows) {
+		t.Errorf("expected to encounter ErrorInvalidFooterRows error, but got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func FuzzGetAttributesFromTag(f *testing.F) {
+	for _, tag := range []string{
+		"header:name",
+		"index:3;precision:2;min:-1;max:1e3",
+		"header:ssn;mask:last4;encrypted",
+		"pos:10-18;trim;omitempty",
+		"headerRegex:^q[0-9]+$",
+		"header:a;profile=v2:header:b",
+		"regex:(",
+		"pos:9999999999999999999-1",
+		"index:-1;;header:",
+		"header:e\u0301;\xff",
+	} {
+		f.Add(tag)
+	}
+
+	fieldTypes := []reflect.Type{
+		reflect.TypeOf(""),
+		reflect.TypeOf(float32(0)),
+		reflect.TypeOf(int8(0)),
+		reflect.TypeOf(uint(0)),
+		reflect.TypeOf(map[string]int{}),
+		reflect.TypeOf([]float64{}),
+	}
+
+	f.Fuzz(func(t *testing.T, tag string) {
+		_, _ = getAttributesFromTag(tag)
+		_, _ = ParseTag(tag)
+
+		// Bind the tag on a field of each type, as a struct declared with it would be.
+		for _, fieldType := range fieldTypes {
+			structType := reflect.StructOf([]reflect.StructField{{
+				Name: "Value",
+				Type: fieldType,
+				Tag:  reflect.StructTag("csv:" + strconv.Quote(tag)),
+			}})
+
+			_ = CheckStruct(reflect.New(structType).Interface())
+			_, _ = StructColumns(structType)
+
+			p := NewParser(strings.NewReader("Value,q1,x\n1,2,3\n\n4\n"), ParserOptions{})
+			if p.ParseHeader(reflect.New(structType).Interface()) == nil {
+				readFuzzRecords(&p, reflect.New(structType).Interface())
+			}
+
+			fp := NewFixedWidthParser(strings.NewReader("12345\n1\n"), ParserOptions{})
+			for i := 0; i < 3; i++ {
+				_ = fp.ReadRecord(reflect.New(structType).Interface())
+			}
+
+			w := NewWriter(io.Discard, WriterOptions{})
+			_ = w.WriteHeader(reflect.New(structType).Interface())
+			_ = w.WriteRecord(reflect.New(structType).Interface())
+		}
+	})
+}
+
+type fuzzHeaderRecord struct {
+	Name    string             `csv:"header:name;trim"`
+	Count   int                `csv:"header:count;min:0"`
+	Price   float64            `csv:"header:price;precision:2"`
+	Rate    float64            `csv:"header:rate;percent"`
+	Active  bool               `csv:"header:active;omitempty"`
+	Code    string             `csv:"header:code;regex:^[A-Z]+$"`
+	Answers map[string]float64 `csv:"headerRegex:^q[0-9]+$"`
+}
+
+type fuzzIndexRecord struct {
+	First string  `csv:"index:0"`
+	Third int8    `csv:"index:2"`
+	Fifth float32 `csv:"index:4"`
+	Tenth uint    `csv:"index:9"`
+}
+
+// raggedReader reads records split naively on commas and newlines, so that records may have any number of columns.
+type raggedReader struct {
+	lines []string
+}
+
+func (r *raggedReader) Read() (record []string, err error) {
+	if len(r.lines) == 0 {
+		return nil, io.EOF
+	}
+
+	line := r.lines[0]
+	r.lines = r.lines[1:]
+
+	return strings.Split(line, ","), nil
+}
+
+// readFuzzRecords reads records into structPointer until the end of the file, or until reading has failed a number of times.
+func readFuzzRecords(p *Parser, structPointer interface{}) {
+	for failures := 0; failures < 100; {
+		err := p.ReadRecord(structPointer)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			failures++
+		}
+	}
+}
+
+func FuzzReadRecord(f *testing.F) {
+	for _, file := range []string{
+		"name,count,price,rate,active,code,q1\nAnn,3,1.25,50%,true,ABC,1\n",
+		"name,count\nAnn\nBob,1,2,3\n",
+		"a,b,c,d,e,f,g,h,i,j\n1\n",
+		"\"" + strings.Repeat("x", 1<<12) + "\",1\n",
+		"name\n\"unterminated\n",
+		"\ufeffname,count\n\u202e\u0000,\xff\xfe\n",
+		"q1,q2,q1\n1,2\n",
+		",,,\n\n\n",
+	} {
+		f.Add(file)
+	}
+
+	f.Fuzz(func(t *testing.T, file string) {
+		p := NewParser(strings.NewReader(file), ParserOptions{})
+		var headerRecord fuzzHeaderRecord
+		if p.ParseHeader(&headerRecord) == nil {
+			readFuzzRecords(&p, &headerRecord)
+		}
+
+		p = NewParser(strings.NewReader(file), ParserOptions{})
+		readFuzzRecords(&p, &fuzzIndexRecord{})
+
+		p = NewRecordParser(&raggedReader{lines: strings.Split(file, "\n")}, ParserOptions{})
+		if p.ParseHeader(&headerRecord) == nil {
+			readFuzzRecords(&p, &headerRecord)
+		}
+
+		p = NewRecordParser(&raggedReader{lines: strings.Split(file, "\n")}, ParserOptions{PartialRecords: true, UnsafeFastPath: true})
+		readFuzzRecords(&p, &fuzzIndexRecord{})
+	})
+}
+
+// TestReadRecordInvalidArguments checks that reading into something other than a pointer to a struct is reported rather than panicking.
+func TestReadRecordInvalidArguments(t *testing.T) {
+	for _, argument := range []interface{}{nil, fuzzIndexRecord{}, (*fuzzIndexRecord)(nil), new(int)} {
+		p := NewParser(strings.NewReader("a,b,c,d,e\n"), ParserOptions{})
+		_ = p.ReadRecord(argument)
+	}
+}
+
+func TestReadRecordShortRecord(t *testing.T) {
+	p := NewParser(strings.NewReader("1,2,3\n"), ParserOptions{})
+
+	err := p.ReadRecord(&fuzzIndexRecord{})
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || !errors.Is(err, ErrorColumnOutOfRange) || setValueErr.Line != 1 || setValueErr.FieldName != "Fifth" {
+		t.Errorf("expected ErrorColumnOutOfRange for field Fifth on line 1 but got %v", err)
+	}
+
+	p = NewRecordParser(&raggedReader{lines: []string{"qty,name", "3"}}, ParserOptions{})
+	err = p.ParseHeader(&codecTest{})
+	if err != nil {
+		t.Fatalf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.ReadRecord(&codecTest{})
+	if !errors.Is(err, ErrorColumnOutOfRange) {
+		t.Errorf("expected ErrorColumnOutOfRange from a record decoder but got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// GenerateStruct writes the go source for a struct named typeName in package packageName, with a csv tagged field for each column of schema.
+// Columns bound by name are tagged with their header, unless the header can't be written in a tag, in which case they are tagged with their index.
+// The tag parser has no built in support for time values, so time columns are generated as strings, with their format noted in a comment.
+func GenerateStruct(w io.Writer, packageName string, typeName string, schema Schema) (err error) {
+	var src bytes.Buffer
+
+	fmt.Fprintf(&src, "// Code generated by csvgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", packageName)
+	fmt.Fprintf(&src, "type %s struct {\n", typeName)
+
+	fieldNames := make(map[string]bool, len(schema.Columns))
+	for i, column := range schema.Columns {
+		fieldName := goFieldName(column.Name)
+		for suffix := 2; fieldNames[fieldName]; suffix++ {
+			fieldName = fmt.Sprintf("%s%d", goFieldName(column.Name), suffix)
+		}
+		fieldNames[fieldName] = true
+
+		tag := fmt.Sprintf("%s:%s", headerAttr, quoteTagValue(column.Name))
+		if column.Index != nil || strings.ContainsAny(column.Name, "\"`") {
+			idx := i
+			if column.Index != nil {
+				idx = *column.Index
+			}
+			tag = fmt.Sprintf("%s:%d", indexAttr, idx)
+		}
+
+		fmt.Fprintf(&src, "\t%s %s `%s:\"%s\"`", fieldName, goTypeName(column.Type), tagName, tag)
+		if column.Type == ColumnTypeTime {
+			fmt.Fprintf(&src, " // time with format %s", column.Format)
+		}
+		fmt.Fprintf(&src, "\n")
+	}
+
+	fmt.Fprintf(&src, "}\n")
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(formatted)
+	return err
+}
+
+func goTypeName(columnType ColumnType) string {
+	switch columnType {
+	case ColumnTypeInt:
+		return "int64"
+	case ColumnTypeFloat:
+		return "float64"
+	case ColumnTypeBool:
+		return "bool"
+	}
+
+	return "string"
+}
+
+// goFieldName turns a header label like "order_id" or "Order ID" into an exported go identifier like "OrderId".
+func goFieldName(name string) string {
+	var fieldName strings.Builder
+
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		runes := []rune(word)
+		fieldName.WriteRune(unicode.ToUpper(runes[0]))
+		fieldName.WriteString(string(runes[1:]))
+	}
+
+	if fieldName.Len() == 0 {
+		return "Column"
+	}
+
+	if first := []rune(fieldName.String())[0]; !unicode.IsLetter(first) || !unicode.IsUpper(first) {
+		return "Column" + fieldName.String()
+	}
+
+	return fieldName.String()
+}
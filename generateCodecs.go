@@ -0,0 +1,267 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+var (
+	ErrorCodecTypeNotFound   = fmt.Errorf("struct type not found in source")
+	ErrorCodecUnsupported    = fmt.Errorf("field is not supported by generated codecs")
+	ErrorCodecNotStructField = fmt.Errorf("csv tags may not be set on embedded fields of a generated codec")
+)
+
+// codecField describes how generated codecs convert one csv tagged field.
+type codecField struct {
+	name       string
+	custom     bool
+	goType     string
+	parseExpr  string
+	formatExpr string
+}
+
+// codecConversions holds the parse and format expressions used by generated codecs for each supported field type.
+// In parse expressions %s is the csv value, and in format expressions %s is the field.
+var codecConversions = map[string][2]string{
+	"string":     {"%s", "%s"},
+	"bool":       {"strconv.ParseBool(%s)", "strconv.FormatBool(%s)"},
+	"int":        {"strconv.ParseInt(%s, 10, 0)", "strconv.FormatInt(int64(%s), 10)"},
+	"int8":       {"strconv.ParseInt(%s, 10, 8)", "strconv.FormatInt(int64(%s), 10)"},
+	"int16":      {"strconv.ParseInt(%s, 10, 16)", "strconv.FormatInt(int64(%s), 10)"},
+	"int32":      {"strconv.ParseInt(%s, 10, 32)", "strconv.FormatInt(int64(%s), 10)"},
+	"int64":      {"strconv.ParseInt(%s, 10, 64)", "strconv.FormatInt(%s, 10)"},
+	"uint":       {"strconv.ParseUint(%s, 10, 0)", "strconv.FormatUint(uint64(%s), 10)"},
+	"uint8":      {"strconv.ParseUint(%s, 10, 8)", "strconv.FormatUint(uint64(%s), 10)"},
+	"uint16":     {"strconv.ParseUint(%s, 10, 16)", "strconv.FormatUint(uint64(%s), 10)"},
+	"uint32":     {"strconv.ParseUint(%s, 10, 32)", "strconv.FormatUint(uint64(%s), 10)"},
+	"uint64":     {"strconv.ParseUint(%s, 10, 64)", "strconv.FormatUint(%s, 10)"},
+	"float32":    {"strconv.ParseFloat(%s, 32)", "strconv.FormatFloat(float64(%s), 'f', -1, 32)"},
+	"float64":    {"strconv.ParseFloat(%s, 64)", "strconv.FormatFloat(%s, 'f', -1, 64)"},
+	"complex64":  {"strconv.ParseComplex(%s, 64)", "strconv.FormatComplex(complex128(%s), 'f', -1, 64)"},
+	"complex128": {"strconv.ParseComplex(%s, 128)", "strconv.FormatComplex(%s, 'f', -1, 128)"},
+}
+
+// codecParseTypes are the field types returned as is by their parse expression, which need no conversion.
+var codecParseTypes = map[string]bool{
+	"bool":       true,
+	"int64":      true,
+	"uint64":     true,
+	"float64":    true,
+	"complex128": true,
+}
+
+// GenerateCodecs reads the go source in src, and writes the go source for DecodeCSVRecord and EncodeCSVRecord methods on each of the named struct types.
+// Parsers and writers prefer these methods over reflection when they are present. Fields using the useCustomSetter attribute call CustomSetter and CustomGetter,
+// so those structs must implement both. Fields using the precision, percent, currency, trim or omitempty attributes, or types that need a converter, are not supported.
+func GenerateCodecs(w io.Writer, src io.Reader, typeNames []string) (err error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return err
+	}
+
+	structs := make(map[string]*ast.StructType)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if typeSpec, ok := node.(*ast.TypeSpec); ok {
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				structs[typeSpec.Name.Name] = structType
+			}
+		}
+		return true
+	})
+
+	var body bytes.Buffer
+	var usesStrconv, usesCsv bool
+	for _, typeName := range typeNames {
+		structType, ok := structs[typeName]
+		if !ok {
+			return CodecGenError{
+				TypeName: typeName,
+				Err:      ErrorCodecTypeNotFound,
+			}
+		}
+
+		fields, err := getCodecFields(structType)
+		if err != nil {
+			return CodecGenError{
+				TypeName: typeName,
+				Err:      err,
+			}
+		}
+
+		for _, field := range fields {
+			usesStrconv = usesStrconv || (!field.custom && field.goType != "string")
+			usesCsv = usesCsv || field.custom || field.goType != "string"
+		}
+
+		writeDecoder(&body, typeName, fields)
+		writeEncoder(&body, typeName, fields)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by csvgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", file.Name.Name)
+	if usesStrconv || usesCsv {
+		fmt.Fprintf(&out, "import (\n")
+		if usesStrconv {
+			fmt.Fprintf(&out, "\t\"strconv\"\n\n")
+		}
+		if usesCsv {
+			fmt.Fprintf(&out, "\tcsv \"github.com/AidanJHMurphy/go-csv\"\n")
+		}
+		fmt.Fprintf(&out, ")\n\n")
+	}
+	out.Write(body.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(formatted)
+	return err
+}
+
+func getCodecFields(structType *ast.StructType) (fields []codecField, err error) {
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+
+		rawTag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		tag, scoped := selectProfile(reflect.StructTag(rawTag).Get(tagName), "")
+		if tag == "" {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			return nil, CsvTagDefError{
+				CsvTag: tag,
+				Err:    ErrorCodecNotStructField,
+			}
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				return nil, CsvTagDefError{
+					CsvTag:    tag,
+					FieldName: name.Name,
+					Err:       ErrorUnexportedField,
+				}
+			}
+
+			attrs, err := getAttributesFromTag(tag)
+			if err == ErrorMalformedCsvTag && scoped {
+				continue
+			}
+			if err != nil {
+				return nil, CsvTagDefError{
+					CsvTag:    tag,
+					FieldName: name.Name,
+					Err:       err,
+				}
+			}
+
+			ident, isIdent := field.Type.(*ast.Ident)
+			_, isSupported := codecConversions[identName(ident)]
+			if attrs.hasPrecision || attrs.percent || attrs.currency || attrs.trim || attrs.omitempty || attrs.mask != "" || attrs.encrypted || (!attrs.useCustomSetter && (!isIdent || !isSupported)) {
+				return nil, CsvTagDefError{
+					CsvTag:    tag,
+					FieldName: name.Name,
+					Err:       ErrorCodecUnsupported,
+				}
+			}
+
+			codec := codecField{
+				name:   name.Name,
+				custom: attrs.useCustomSetter,
+			}
+			if !codec.custom {
+				codec.goType = ident.Name
+				codec.parseExpr = codecConversions[ident.Name][0]
+				codec.formatExpr = codecConversions[ident.Name][1]
+			}
+
+			fields = append(fields, codec)
+		}
+	}
+
+	return fields, nil
+}
+
+func identName(ident *ast.Ident) string {
+	if ident == nil {
+		return ""
+	}
+	return ident.Name
+}
+
+func writeDecoder(w io.Writer, typeName string, fields []codecField) {
+	fmt.Fprintf(w, "// DecodeCSVRecord sets the csv tagged fields of r from record, which holds their values in field order.\n")
+	fmt.Fprintf(w, "func (r *%s) DecodeCSVRecord(record []string) (err error) {\n", typeName)
+
+	for i, field := range fields {
+		value := fmt.Sprintf("record[%d]", i)
+
+		if field.custom {
+			fmt.Fprintf(w, "\tif err = r.CustomSetter(%q, %s); err != nil {\n", field.name, value)
+			fmt.Fprintf(w, "\t\treturn csv.SetValueError{Value: %s, FieldName: %q, Err: err}\n\t}\n", value, field.name)
+			continue
+		}
+
+		if field.goType == "string" {
+			fmt.Fprintf(w, "\tr.%s = %s\n", field.name, value)
+			continue
+		}
+
+		fmt.Fprintf(w, "\t{\n\t\tv, err := %s\n", fmt.Sprintf(field.parseExpr, value))
+		fmt.Fprintf(w, "\t\tif err != nil {\n\t\t\treturn csv.SetValueError{Value: %s, FieldName: %q, Err: err}\n\t\t}\n", value, field.name)
+		if codecParseTypes[field.goType] {
+			fmt.Fprintf(w, "\t\tr.%s = v\n\t}\n", field.name)
+		} else {
+			fmt.Fprintf(w, "\t\tr.%s = %s(v)\n\t}\n", field.name, field.goType)
+		}
+	}
+
+	fmt.Fprintf(w, "\treturn nil\n}\n\n")
+}
+
+func writeEncoder(w io.Writer, typeName string, fields []codecField) {
+	fmt.Fprintf(w, "// EncodeCSVRecord formats the csv tagged fields of r in field order.\n")
+	fmt.Fprintf(w, "func (r *%s) EncodeCSVRecord() (record []string, err error) {\n", typeName)
+	fmt.Fprintf(w, "\trecord = make([]string, %d)\n", len(fields))
+
+	for i, field := range fields {
+		if field.custom {
+			fmt.Fprintf(w, "\tif record[%d], err = r.CustomGetter(%q); err != nil {\n", i, field.name)
+			fmt.Fprintf(w, "\t\treturn nil, csv.GetValueError{FieldName: %q, Err: err}\n\t}\n", field.name)
+			continue
+		}
+
+		fmt.Fprintf(w, "\trecord[%d] = %s\n", i, fmt.Sprintf(field.formatExpr, "r."+field.name))
+	}
+
+	fmt.Fprintf(w, "\treturn record, nil\n}\n\n")
+}
+
+type CodecGenError struct {
+	TypeName string
+	Err      error
+}
+
+func (e CodecGenError) Error() string {
+	return fmt.Sprintf("cannot generate codecs for type %s: %v", e.TypeName, e.Err)
+}
+
+func (e CodecGenError) Unwrap() error { return e.Err }
@@ -0,0 +1,93 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const codecTestSource = `package feeds
+
+type Feed struct {
+	Ignored int
+	Name    string  ` + "`csv:\"header:name\"`" + `
+	Qty     int32   ` + "`csv:\"header:qty\"`" + `
+	Price   float64 ` + "`csv:\"index:4\"`" + `
+	Note    string  ` + "`csv:\"header:note;useCustomSetter\"`" + `
+}
+
+type Rounded struct {
+	Price float64 ` + "`csv:\"header:price;precision:2\"`" + `
+}
+`
+
+func TestGenerateCodecs(t *testing.T) {
+	var buf bytes.Buffer
+	err := GenerateCodecs(&buf, strings.NewReader(codecTestSource), []string{"Feed"})
+	if err != nil {
+		t.Errorf("encountered error generating codecs: %v", err)
+	}
+
+	expected := `// Code generated by csvgen. DO NOT EDIT.
+
+package feeds
+
+import (
+	"strconv"
+
+	csv "github.com/AidanJHMurphy/go-csv"
+)
+
+// DecodeCSVRecord sets the csv tagged fields of r from record, which holds their values in field order.
+func (r *Feed) DecodeCSVRecord(record []string) (err error) {
+	r.Name = record[0]
+	{
+		v, err := strconv.ParseInt(record[1], 10, 32)
+		if err != nil {
+			return csv.SetValueError{Value: record[1], FieldName: "Qty", Err: err}
+		}
+		r.Qty = int32(v)
+	}
+	{
+		v, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return csv.SetValueError{Value: record[2], FieldName: "Price", Err: err}
+		}
+		r.Price = v
+	}
+	if err = r.CustomSetter("Note", record[3]); err != nil {
+		return csv.SetValueError{Value: record[3], FieldName: "Note", Err: err}
+	}
+	return nil
+}
+
+// EncodeCSVRecord formats the csv tagged fields of r in field order.
+func (r *Feed) EncodeCSVRecord() (record []string, err error) {
+	record = make([]string, 4)
+	record[0] = r.Name
+	record[1] = strconv.FormatInt(int64(r.Qty), 10)
+	record[2] = strconv.FormatFloat(r.Price, 'f', -1, 64)
+	if record[3], err = r.CustomGetter("Note"); err != nil {
+		return nil, csv.GetValueError{FieldName: "Note", Err: err}
+	}
+	return record, nil
+}
+`
+
+	if buf.String() != expected {
+		t.Errorf("improperly generated codecs. Got\n%s\nbut expected\n%s", buf.String(), expected)
+	}
+}
+
+func TestGenerateCodecsUnsupportedError(t *testing.T) {
+	err := GenerateCodecs(&bytes.Buffer{}, strings.NewReader(codecTestSource), []string{"Rounded"})
+	if !errors.Is(err, ErrorCodecUnsupported) {
+		t.Errorf("expected to encounter Codec Unsupported error, but got %v", err)
+	}
+
+	err = GenerateCodecs(&bytes.Buffer{}, strings.NewReader(codecTestSource), []string{"Missing"})
+	if !errors.Is(err, ErrorCodecTypeNotFound) {
+		t.Errorf("expected to encounter Codec Type Not Found error, but got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package csv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGenerateStruct(t *testing.T) {
+	schema, err := InferSchema(strings.NewReader(inferenceTestData), 10, ParserOptions{})
+	if err != nil {
+		t.Errorf("encountered error inferring schema: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = GenerateStruct(&buf, "feeds", "Feed", schema)
+	if err != nil {
+		t.Errorf("encountered error generating struct: %v", err)
+	}
+
+	expected := "// Code generated by csvgen. DO NOT EDIT.\n\n" +
+		"package feeds\n\n" +
+		"type Feed struct {\n" +
+		"\tId      int64   `csv:\"header:id\"`\n" +
+		"\tName    string  `csv:\"header:name\"`\n" +
+		"\tPrice   float64 `csv:\"header:price\"`\n" +
+		"\tActive  bool    `csv:\"header:active\"`\n" +
+		"\tSold    string  `csv:\"header:sold\"` // time with format 2006-01-02\n" +
+		"\tColumn5 string  `csv:\"index:5\"`\n" +
+		"\tNotes   string  `csv:\"header:notes\"`\n" +
+		"}\n"
+
+	if buf.String() != expected {
+		t.Errorf("improperly generated struct. Got\n%s\nbut expected\n%s", buf.String(), expected)
+	}
+}
+
+func TestGoFieldName(t *testing.T) {
+	names := map[string]string{
+		"order_id":  "OrderId",
+		"Order ID":  "OrderID",
+		"2nd value": "Column2ndValue",
+		"":          "Column",
+	}
+
+	for name, expected := range names {
+		if fieldName := goFieldName(name); fieldName != expected {
+			t.Errorf("improperly generated field name for '%s'. Got '%s' but expected '%s'", name, fieldName, expected)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package csv
+
+import (
+	"strings"
+	"unicode"
+)
+
+// HeaderDiff describes how the header b differs from the header a, as reported by CompareHeaders.
+type HeaderDiff struct {
+	// Missing lists the columns of a that aren't in b.
+	Missing []string
+	// Extra lists the columns of b that aren't in a.
+	Extra []string
+	// Reordered lists the columns found in both headers that were moved relative to the others, in the order of a.
+	Reordered []string
+	// Renamed pairs columns of a with the columns of b that appear to have replaced them, which are left out of Missing and Extra.
+	Renamed []HeaderRename
+}
+
+// HeaderRename is a column that appears to have been renamed from From to To.
+type HeaderRename struct {
+	From string
+	To   string
+}
+
+// Equal reports whether the headers compared were the same.
+func (d HeaderDiff) Equal() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Reordered) == 0 && len(d.Renamed) == 0
+}
+
+// CompareHeaders reports how the header b differs from the header a, such as a vendor's new header from the one a pipeline was built for.
+// A missing column and an extra column are taken to be a rename when their labels are the same apart from case, spaces and punctuation, such as customer_id and Customer ID,
+// or otherwise when they are at the same position. Columns are reported as reordered when they have to be moved for the columns the headers share to be in the same order.
+func CompareHeaders(a, b []string) (diff HeaderDiff) {
+	return compareHeaders(a, b, true)
+}
+
+// compareHeaders compares the headers as CompareHeaders does, but only pairs renamed columns by position when renamedByPosition is set.
+func compareHeaders(a, b []string, renamedByPosition bool) (diff HeaderDiff) {
+	inA := make(map[string]bool, len(a))
+	for _, column := range a {
+		inA[column] = true
+	}
+	positionsB := make(map[string]int, len(b))
+	for idx, column := range b {
+		if _, found := positionsB[column]; !found {
+			positionsB[column] = idx
+		}
+	}
+
+	var missingIdx, extraIdx []int
+	for idx, column := range a {
+		if _, found := positionsB[column]; !found {
+			missingIdx = append(missingIdx, idx)
+		}
+	}
+	for idx, column := range b {
+		if !inA[column] {
+			extraIdx = append(extraIdx, idx)
+		}
+	}
+
+	renamedA := make(map[int]bool)
+	renamedB := make(map[int]bool)
+	pair := func(matches func(i, j int) bool) {
+		for _, i := range missingIdx {
+			for _, j := range extraIdx {
+				if !renamedA[i] && !renamedB[j] && matches(i, j) {
+					renamedA[i], renamedB[j] = true, true
+					diff.Renamed = append(diff.Renamed, HeaderRename{From: a[i], To: b[j]})
+				}
+			}
+		}
+	}
+	pair(func(i, j int) bool { return normalizeHeader(a[i]) == normalizeHeader(b[j]) })
+	if renamedByPosition {
+		pair(func(i, j int) bool { return i == j })
+	}
+
+	for _, i := range missingIdx {
+		if !renamedA[i] {
+			diff.Missing = append(diff.Missing, a[i])
+		}
+	}
+	for _, j := range extraIdx {
+		if !renamedB[j] {
+			diff.Extra = append(diff.Extra, b[j])
+		}
+	}
+
+	// The shared columns that are kept in place are the longest run of them that is in the same order in both headers, and the rest were moved.
+	var shared []string
+	var positions []int
+	for _, column := range a {
+		if idx, found := positionsB[column]; found {
+			shared = append(shared, column)
+			positions = append(positions, idx)
+		}
+	}
+	kept := longestIncreasing(positions)
+	for idx, column := range shared {
+		if !kept[idx] {
+			diff.Reordered = append(diff.Reordered, column)
+		}
+	}
+
+	return diff
+}
+
+// normalizeHeader reduces a header label to its lower case letters and digits, so that labels differing only in case, spacing or punctuation compare equal.
+func normalizeHeader(label string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, label)
+}
+
+// longestIncreasing marks the elements of a longest strictly increasing subsequence of values.
+func longestIncreasing(values []int) (kept map[int]bool) {
+	lengths := make([]int, len(values))
+	previous := make([]int, len(values))
+	best := -1
+
+	for i := range values {
+		lengths[i], previous[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if values[j] < values[i] && lengths[j]+1 > lengths[i] {
+				lengths[i], previous[i] = lengths[j]+1, j
+			}
+		}
+		if best == -1 || lengths[i] > lengths[best] {
+			best = i
+		}
+	}
+
+	kept = make(map[int]bool, len(values))
+	for i := best; i >= 0; i = previous[i] {
+		kept[i] = true
+	}
+
+	return kept
+}
+
+// HeaderReport describes how the parsed header of a file compares to the header names of a struct's csv tags, as reported by ValidateHeaderAgainst.
+type HeaderReport struct {
+	// HeaderDiff compares the header names of the struct's fields, in the order the fields are defined, to the parsed header.
+	// Renamed columns are only paired when their labels are the same apart from case, spaces and punctuation. Columns bound by the index of a field without a header name, or by headerRegex, aren't reported as extra.
+	HeaderDiff
+	// MissingFields names the fields that can't be read, since their header name isn't in the parsed header and they have no index to fall back to.
+	MissingFields []string
+}
+
+// ValidateHeaderAgainst compares the parsed header to the header names of the csv tags defined on structPointer, so that a pipeline can alert when a file's layout changes
+// before reading any records. It returns ErrorHeaderNotParsed if no header has been parsed.
+func (p *Parser) ValidateHeaderAgainst(structPointer interface{}) (report HeaderReport, err error) {
+	if p.header == nil {
+		return report, ErrorHeaderNotParsed
+	}
+
+	err = checkStructPointer(structPointer)
+	if err != nil {
+		return report, err
+	}
+
+	csvAttrs, err := getCsvAttributes(structPointer, p.tagOptions())
+	if err != nil {
+		return report, err
+	}
+
+	var expected []string
+	boundWithoutName := make(map[string]bool)
+	for _, fieldName := range getFieldOrder(csvAttrs) {
+		attrs := csvAttrs[fieldName]
+		if attrs.headerPattern != nil {
+			for _, column := range p.header {
+				if attrs.headerPattern.MatchString(column) {
+					boundWithoutName[column] = true
+				}
+			}
+			continue
+		}
+
+		if !attrs.hasHeader {
+			if attrs.columnIndex < len(p.header) {
+				boundWithoutName[p.header[attrs.columnIndex]] = true
+			}
+			continue
+		}
+
+		expected = append(expected, attrs.headerName)
+		if _, found := findHeaderIndex(p.header, attrs.headerName); !found && !attrs.hasIndex {
+			report.MissingFields = append(report.MissingFields, fieldName)
+		}
+	}
+
+	// The fields of a struct needn't be in the same order as the columns, so renamed columns are only paired by their labels.
+	report.HeaderDiff = compareHeaders(expected, p.header, false)
+
+	extra := report.Extra[:0]
+	for _, column := range report.Extra {
+		if !boundWithoutName[column] {
+			extra = append(extra, column)
+		}
+	}
+	report.Extra = extra
+
+	return report, nil
+}
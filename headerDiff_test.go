@@ -0,0 +1,89 @@
+package csv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompareHeaders(t *testing.T) {
+	tests := []struct {
+		a, b     []string
+		expected HeaderDiff
+	}{
+		{
+			a: []string{"id", "name", "price"},
+			b: []string{"id", "name", "price"},
+		},
+		{
+			a:        []string{"id", "name", "price"},
+			b:        []string{"id", "price"},
+			expected: HeaderDiff{Missing: []string{"name"}},
+		},
+		{
+			a:        []string{"id", "name"},
+			b:        []string{"id", "name", "discount"},
+			expected: HeaderDiff{Extra: []string{"discount"}},
+		},
+		{
+			a:        []string{"id", "name", "price", "sku"},
+			b:        []string{"id", "price", "sku", "name"},
+			expected: HeaderDiff{Reordered: []string{"name"}},
+		},
+		{
+			a:        []string{"customer_id", "name", "price"},
+			b:        []string{"name", "Customer ID", "cost"},
+			expected: HeaderDiff{Renamed: []HeaderRename{{From: "customer_id", To: "Customer ID"}, {From: "price", To: "cost"}}},
+		},
+	}
+
+	for _, test := range tests {
+		diff := CompareHeaders(test.a, test.b)
+		if !reflect.DeepEqual(diff, test.expected) {
+			t.Errorf("expected comparing %v to %v to give %+v, but got %+v", test.a, test.b, test.expected, diff)
+		}
+		if diff.Equal() != reflect.DeepEqual(test.expected, HeaderDiff{}) {
+			t.Errorf("expected Equal to report whether %v and %v are the same", test.a, test.b)
+		}
+	}
+}
+
+type headerReportTest struct {
+	ID     int    `csv:"header:customer_id"`
+	Name   string `csv:"header:name"`
+	Price  int    `csv:"header:price;index:3"`
+	Region string `csv:"header:region"`
+	Note   string `csv:"index:4"`
+}
+
+func TestValidateHeaderAgainst(t *testing.T) {
+	p := NewParser(strings.NewReader("Customer ID,name,discount,cost,note"), ParserOptions{})
+
+	_, err := p.ValidateHeaderAgainst(&headerReportTest{})
+	if !errors.Is(err, ErrorHeaderNotParsed) {
+		t.Errorf("expected to encounter ErrorHeaderNotParsed error, but got %v", err)
+	}
+
+	_, err = p.readHeader()
+	if err != nil {
+		t.Errorf("encountered error reading csv header: %v", err)
+	}
+
+	report, err := p.ValidateHeaderAgainst(&headerReportTest{})
+	if err != nil {
+		t.Errorf("encountered error validating csv header: %v", err)
+	}
+
+	expected := HeaderReport{
+		HeaderDiff: HeaderDiff{
+			Missing: []string{"price", "region"},
+			Extra:   []string{"discount", "cost"},
+			Renamed: []HeaderRename{{From: "customer_id", To: "Customer ID"}},
+		},
+		MissingFields: []string{"ID", "Region"},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("expected header report %+v, but got %+v", expected, report)
+	}
+}
@@ -0,0 +1,134 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrorHeaderRegexNotWritable = fmt.Errorf("fields bound by headerRegex can't be written")
+	ErrorNotMeltable            = fmt.Errorf("only fields bound by headerRegex can be melted")
+)
+
+// setPatternField sets a field bound by headerRegex from every column matching its pattern. A slice is given the values in column order,
+// and a map is given the values keyed by column label. Each value is converted and validated as the value of any other field would be.
+func (p *Parser) setPatternField(structPointer interface{}, fieldName string, attrs csvAttributes, readRecord []string) (err error) {
+	field := reflect.ValueOf(structPointer).Elem().Field(attrs.fieldIndex)
+	isSlice := field.Kind() == reflect.Slice
+
+	var collection reflect.Value
+	if isSlice {
+		collection = reflect.MakeSlice(field.Type(), len(attrs.patternColumns), len(attrs.patternColumns))
+	} else {
+		collection = reflect.MakeMapWithSize(field.Type(), len(attrs.patternColumns))
+	}
+	element := reflect.New(field.Type().Elem()).Elem()
+
+	for i, idx := range attrs.patternColumns {
+		var value string
+		if idx < len(readRecord) {
+			value, err = p.cellValue(fieldName, attrs, idx, readRecord)
+			if err != nil {
+				return err
+			}
+		}
+
+		target := element
+		if isSlice {
+			target = collection.Index(i)
+		}
+
+		if p.isNull(attrs, value) {
+			target.Set(reflect.Zero(target.Type()))
+		} else {
+			err = p.setValue(target, attrs, value)
+			if err != nil {
+				return SetValueError{
+					Line:      p.line,
+					Value:     value,
+					FieldName: fieldName,
+					Err:       err,
+				}
+			}
+		}
+
+		rule, err := validateValue(target, attrs, value)
+		if err != nil {
+			return ValidationError{
+				Line:      p.line,
+				Value:     value,
+				FieldName: fieldName,
+				Rule:      rule,
+				Err:       err,
+			}
+		}
+
+		if !isSlice {
+			collection.SetMapIndex(reflect.ValueOf(p.header[idx]).Convert(field.Type().Key()), target)
+		}
+	}
+
+	field.Set(collection)
+
+	return nil
+}
+
+// MeltRow is one value of a field bound by headerRegex, in long form. Column is the header label of the value's column, and Key is the part of the label
+// matched by the first capture group of the headerRegex, such as jan for amt_jan and ^amt_(.+)$, or the whole label when the regex has no capture group.
+type MeltRow struct {
+	Column string
+	Key    string
+	Value  interface{}
+}
+
+// Melt reshapes the named field of structPointer, which must be bound by headerRegex and hold the most recently read record, from wide form into one row per matched column, in column order.
+// Use it to normalize pivoted files during ingestion, such as turning a record with sales for each month into a (month, sales) row for each.
+func (p *Parser) Melt(structPointer interface{}, fieldName string) (rows []MeltRow, err error) {
+	err = checkStructPointer(structPointer)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs, ok := p.csvAttrs[fieldName]
+	if !ok || attrs.headerPattern == nil {
+		return nil, FieldNotFoundError{
+			FieldName: fieldName,
+			Err:       ErrorNotMeltable,
+		}
+	}
+
+	if p.header == nil {
+		return nil, ErrorHeaderNotParsed
+	}
+
+	field := reflect.ValueOf(structPointer).Elem().Field(attrs.fieldIndex)
+	for i, idx := range attrs.patternColumns {
+		label := p.header[idx]
+
+		var value reflect.Value
+		if field.Kind() == reflect.Slice {
+			if i >= field.Len() {
+				break
+			}
+			value = field.Index(i)
+		} else {
+			value = field.MapIndex(reflect.ValueOf(label).Convert(field.Type().Key()))
+			if !value.IsValid() {
+				continue
+			}
+		}
+
+		key := label
+		if match := attrs.headerPattern.FindStringSubmatch(label); len(match) > 1 {
+			key = match[1]
+		}
+
+		rows = append(rows, MeltRow{
+			Column: label,
+			Key:    key,
+			Value:  value.Interface(),
+		})
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,141 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type headerRegexTest struct {
+	Region  string             `csv:"header:region"`
+	Amounts []int              `csv:"headerRegex:^amt_"`
+	Rates   map[string]float64 `csv:"headerRegex:_rate$;percent"`
+}
+
+func TestHeaderRegex(t *testing.T) {
+	data := "region,amt_jan,eu_rate,amt_feb,us_rate,amt_mar\nEU,10,5%,20,7%,30"
+	p := NewParser(strings.NewReader(data), ParserOptions{})
+
+	var record headerRegexTest
+	err := p.ReadRecord(&record)
+	if !errors.Is(err, ErrorHeaderNotParsed) {
+		t.Errorf("expected to encounter ErrorHeaderNotParsed error, but got %v", err)
+	}
+
+	err = p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with headerRegex fields: %v", err)
+	}
+
+	expected := headerRegexTest{
+		Region:  "EU",
+		Amounts: []int{10, 20, 30},
+		Rates:   map[string]float64{"eu_rate": 0.05, "us_rate": 0.07},
+	}
+	if !reflect.DeepEqual(record, expected) {
+		t.Errorf("expected %+v, but got %+v", expected, record)
+	}
+}
+
+func TestHeaderRegexSetValueError(t *testing.T) {
+	p := NewParser(strings.NewReader("region,amt_jan,amt_feb\nEU,10,ten"), ParserOptions{})
+
+	var record headerRegexTest
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	var setErr SetValueError
+	if !errors.As(err, &setErr) || setErr.FieldName != "Amounts" || setErr.Value != "ten" {
+		t.Errorf("expected a SetValueError for the value ten of field Amounts, but got %v", err)
+	}
+}
+
+type headerRegexInvalidTest struct {
+	Amount int `csv:"headerRegex:^amt_"`
+}
+
+func TestHeaderRegexFieldError(t *testing.T) {
+	p := NewParser(strings.NewReader("amt_jan\n1"), ParserOptions{})
+
+	err := p.ParseHeader(&headerRegexInvalidTest{})
+	if !errors.Is(err, ErrorHeaderRegexField) {
+		t.Errorf("expected to encounter ErrorHeaderRegexField error, but got %v", err)
+	}
+
+	w := NewWriter(&bytes.Buffer{}, WriterOptions{})
+	err = w.WriteRecord(&headerRegexTest{})
+	if !errors.Is(err, ErrorHeaderRegexNotWritable) {
+		t.Errorf("expected to encounter ErrorHeaderRegexNotWritable error, but got %v", err)
+	}
+}
+
+type meltTest struct {
+	Region string `csv:"header:region"`
+	Sales  []int  `csv:"headerRegex:^amt_(.+)$"`
+}
+
+func TestMelt(t *testing.T) {
+	p := NewParser(strings.NewReader("region,amt_jan,amt_feb,note,amt_mar\nEU,10,20,x,30"), ParserOptions{})
+
+	var record meltTest
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with headerRegex fields: %v", err)
+	}
+
+	rows, err := p.Melt(&record, "Sales")
+	if err != nil {
+		t.Errorf("encountered error melting record: %v", err)
+	}
+
+	expected := []MeltRow{
+		{Column: "amt_jan", Key: "jan", Value: 10},
+		{Column: "amt_feb", Key: "feb", Value: 20},
+		{Column: "amt_mar", Key: "mar", Value: 30},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected melted rows %+v, but got %+v", expected, rows)
+	}
+
+	_, err = p.Melt(&record, "Region")
+	if !errors.Is(err, ErrorNotMeltable) {
+		t.Errorf("expected to encounter ErrorNotMeltable error, but got %v", err)
+	}
+}
+
+func TestMeltMap(t *testing.T) {
+	p := NewParser(strings.NewReader("region,amt_jan,eu_rate,amt_feb,us_rate\nEU,10,5%,20,7%"), ParserOptions{})
+
+	var record headerRegexTest
+	err := p.ParseHeader(&record)
+	if err == nil {
+		err = p.ReadRecord(&record)
+	}
+	if err != nil {
+		t.Errorf("encountered error parsing csv with headerRegex fields: %v", err)
+	}
+
+	rows, err := p.Melt(&record, "Rates")
+	expected := []MeltRow{
+		{Column: "eu_rate", Key: "eu_rate", Value: 0.05},
+		{Column: "us_rate", Key: "us_rate", Value: 0.07},
+	}
+	if err != nil || !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected melted rows %+v, but got %+v and error %v", expected, rows, err)
+	}
+}
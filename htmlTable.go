@@ -0,0 +1,180 @@
+package csv
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// HTMLTableReader reads the rows of a table in an html document as records. It implements RecordReader.
+type HTMLTableReader struct {
+	decoder *xml.Decoder
+	table   int
+	found   bool
+	done    bool
+	nested  int
+	width   int
+	cell    strings.Builder
+	inCell  bool
+	colspan int
+	record  []string
+}
+
+// NewHTMLTableReader creates a reader of the rows of the table at index table of document, counting tables from 0 in the order they start, so that they can be bound to structs with NewRecordParser.
+// Each row is read from its td and th cells, with the text of a cell collapsed to single spaces. A cell spanning several columns is followed by empty values for the columns it covers,
+// and rows are padded to the width of the first row. Tables nested in a cell are left out of its text.
+// The document is read with the non-strict mode of encoding/xml, which handles generated reports and most hand written tables, including unclosed cells and rows, but not every document a browser accepts.
+func NewHTMLTableReader(document io.Reader, table int) (r *HTMLTableReader) {
+	r = &HTMLTableReader{table: table}
+	r.decoder = xml.NewDecoder(document)
+	r.decoder.Strict = false
+	r.decoder.AutoClose = xml.HTMLAutoClose
+	r.decoder.Entity = xml.HTMLEntity
+
+	return r
+}
+
+// Read returns the next row of the table that has any cells, or io.EOF after the last row. It returns io.EOF if the document has no table at the index.
+func (r *HTMLTableReader) Read() (record []string, err error) {
+	if r.done {
+		return nil, io.EOF
+	}
+
+	if !r.found {
+		err = r.findTable()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		token, err := r.decoder.Token()
+		if err == io.EOF {
+			r.done = true
+			return r.finishRow()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(element.Name.Local)
+			if r.nested > 0 || name == "table" {
+				if name == "table" {
+					r.nested++
+				}
+				continue
+			}
+
+			switch name {
+			case "tr":
+				// A row that wasn't closed ends where the next one starts.
+				r.finishCell()
+				if len(r.record) > 0 {
+					return r.finishRow()
+				}
+			case "td", "th":
+				r.finishCell()
+				r.inCell = true
+				r.colspan = 1
+				for _, attr := range element.Attr {
+					if strings.ToLower(attr.Name.Local) == "colspan" {
+						if span, err := strconv.Atoi(attr.Value); err == nil && span > 1 && span <= 1000 {
+							r.colspan = span
+						}
+					}
+				}
+			case "br", "p", "div", "li":
+				if r.inCell {
+					r.cell.WriteByte(' ')
+				}
+			}
+		case xml.EndElement:
+			name := strings.ToLower(element.Name.Local)
+			if r.nested > 0 {
+				if name == "table" {
+					r.nested--
+				}
+				continue
+			}
+
+			switch name {
+			case "td", "th":
+				r.finishCell()
+			case "tr":
+				r.finishCell()
+				if len(r.record) > 0 {
+					return r.finishRow()
+				}
+			case "table":
+				r.done = true
+				return r.finishRow()
+			}
+		case xml.CharData:
+			if r.inCell && r.nested == 0 {
+				r.cell.Write(element)
+			}
+		}
+	}
+}
+
+// findTable reads the document up to the start of the table at the reader's index.
+func (r *HTMLTableReader) findTable() (err error) {
+	count := 0
+	for {
+		token, err := r.decoder.Token()
+		if err == io.EOF {
+			r.done = true
+			return io.EOF
+		}
+		if err != nil {
+			return err
+		}
+
+		if start, ok := token.(xml.StartElement); ok && strings.ToLower(start.Name.Local) == "table" {
+			if count == r.table {
+				r.found = true
+				return nil
+			}
+			count++
+		}
+	}
+}
+
+// finishCell adds the text of the open cell to the record, followed by an empty value for each further column it spans.
+func (r *HTMLTableReader) finishCell() {
+	if !r.inCell {
+		return
+	}
+
+	r.record = append(r.record, strings.Join(strings.Fields(r.cell.String()), " "))
+	for i := 1; i < r.colspan; i++ {
+		r.record = append(r.record, "")
+	}
+
+	r.cell.Reset()
+	r.inCell = false
+}
+
+// finishRow returns the open row padded to the width of the first row, or io.EOF if it has no cells, and starts a new row.
+func (r *HTMLTableReader) finishRow() (record []string, err error) {
+	r.finishCell()
+
+	record = r.record
+	r.record = nil
+
+	if len(record) == 0 {
+		return nil, io.EOF
+	}
+
+	if r.width == 0 {
+		r.width = len(record)
+	}
+	for len(record) < r.width {
+		record = append(record, "")
+	}
+
+	return record, nil
+}
@@ -0,0 +1,99 @@
+package csv
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var htmlTableTestData = `<!DOCTYPE html>
+<html>
+<head><title>Report</title></head>
+<body>
+<table id="summary"><tr><td>Total</td><td>21.5</td></tr></table>
+<table id="prices">
+  <thead>
+    <tr><th>Name</th><th>Price</th><th>Note</th></tr>
+  </thead>
+  <tbody>
+    <tr><td> Widget &amp; <b>Co</b> </td><td>9.5</td><td>first<br>run</td></tr>
+    <tr><td>Gadget&nbsp;2<td>12<td colspan=2>none</tr>
+    <tr><td>Gizmo</td><td>3</td><td><table><tr><td>nested</td></tr></table>kit</td></tr>
+    <tr><td>Doohickey</td></tr>
+  </tbody>
+</table>
+</body>
+</html>`
+
+func TestHTMLTableReader(t *testing.T) {
+	r := NewHTMLTableReader(strings.NewReader(htmlTableTestData), 1)
+
+	expected := [][]string{
+		{"Name", "Price", "Note"},
+		{"Widget & Co", "9.5", "first run"},
+		{"Gadget 2", "12", "none", ""},
+		{"Gizmo", "3", "kit"},
+		{"Doohickey", "", ""},
+	}
+
+	for i := 0; true; i++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			if i != len(expected) {
+				t.Errorf("expected %d rows, but got %d", len(expected), i)
+			}
+			break
+		}
+		if err != nil {
+			t.Errorf("encountered error reading table row: %v", err)
+			break
+		}
+
+		if i >= len(expected) || !reflect.DeepEqual(record, expected[i]) {
+			t.Errorf("improperly read table row %d. Got '%q'", i, record)
+		}
+	}
+}
+
+func TestHTMLTableReaderParser(t *testing.T) {
+	p := NewRecordParser(NewHTMLTableReader(strings.NewReader(htmlTableTestData), 1), ParserOptions{})
+
+	type priceRow struct {
+		Name  string  `csv:"header:Name"`
+		Price float64 `csv:"header:Price;omitempty"`
+	}
+
+	var record priceRow
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing table header: %v", err)
+	}
+
+	var records []priceRow
+	for {
+		err = p.ReadRecord(&record)
+		if err != nil {
+			break
+		}
+		records = append(records, record)
+	}
+
+	if err != io.EOF {
+		t.Errorf("encountered error reading table record: %v", err)
+	}
+
+	expected := []priceRow{{"Widget & Co", 9.5}, {"Gadget 2", 12}, {"Gizmo", 3}, {"Doohickey", 0}}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("improperly read table records. Got '%+v' but expected '%+v'", records, expected)
+	}
+}
+
+func TestHTMLTableReaderNoTable(t *testing.T) {
+	r := NewHTMLTableReader(strings.NewReader(htmlTableTestData), 3)
+
+	_, err := r.Read()
+	if err != io.EOF {
+		t.Errorf("expected io.EOF for a missing table, but got %v", err)
+	}
+}
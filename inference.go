@@ -0,0 +1,165 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// inferenceTimeLayouts are the time layouts InferSchema recognizes, in order of preference.
+var inferenceTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+}
+
+type columnInference struct {
+	seen       bool
+	couldInt   bool
+	couldFloat bool
+	couldBool  bool
+	couldTime  bool
+	timeLayout string
+}
+
+func newColumnInference() columnInference {
+	return columnInference{
+		couldInt:   true,
+		couldFloat: true,
+		couldBool:  true,
+		couldTime:  true,
+	}
+}
+
+func (ci *columnInference) observe(value string) {
+	if value == "" {
+		return
+	}
+
+	if ci.couldInt {
+		_, err := strconv.ParseInt(value, 10, 64)
+		ci.couldInt = err == nil
+	}
+
+	// NaN and Inf parse as floats, but have no JSON or SQL literal, so a column holding them is inferred as text.
+	if ci.couldFloat {
+		floatValue, err := strconv.ParseFloat(value, 64)
+		ci.couldFloat = err == nil && !math.IsNaN(floatValue) && !math.IsInf(floatValue, 0)
+	}
+
+	if ci.couldBool {
+		_, err := strconv.ParseBool(value)
+		ci.couldBool = err == nil
+	}
+
+	if ci.couldTime {
+		if !ci.seen {
+			ci.timeLayout = ""
+			for _, layout := range inferenceTimeLayouts {
+				if _, err := time.Parse(layout, value); err == nil {
+					ci.timeLayout = layout
+					break
+				}
+			}
+			ci.couldTime = ci.timeLayout != ""
+		} else {
+			_, err := time.Parse(ci.timeLayout, value)
+			ci.couldTime = err == nil
+		}
+	}
+
+	ci.seen = true
+}
+
+func (ci columnInference) column(name string) (column SchemaColumn) {
+	column.Name = name
+	column.Type = ColumnTypeString
+
+	switch {
+	case !ci.seen:
+	case ci.couldInt:
+		column.Type = ColumnTypeInt
+	case ci.couldFloat:
+		column.Type = ColumnTypeFloat
+	case ci.couldBool:
+		column.Type = ColumnTypeBool
+	case ci.couldTime:
+		column.Type = ColumnTypeTime
+		column.Format = ci.timeLayout
+	}
+
+	return column
+}
+
+// schemaInference infers the column types of a csv file from the records it observes.
+type schemaInference struct {
+	header     []string
+	inferences []columnInference
+}
+
+func newSchemaInference(header []string) (si schemaInference) {
+	si.header = header
+	si.inferences = make([]columnInference, len(header))
+	for i := range si.inferences {
+		si.inferences[i] = newColumnInference()
+	}
+
+	return si
+}
+
+func (si *schemaInference) observe(record []string) {
+	for i, value := range record {
+		if i < len(si.inferences) {
+			si.inferences[i].observe(value)
+		}
+	}
+}
+
+func (si *schemaInference) schema() (schema Schema) {
+	names := make(map[string]bool, len(si.header))
+	for i, inference := range si.inferences {
+		column := inference.column(si.header[i])
+
+		if si.header[i] == "" || names[si.header[i]] {
+			idx := i
+			column.Name = fmt.Sprintf("column%d", i)
+			column.Index = &idx
+		}
+		names[si.header[i]] = true
+
+		schema.Columns = append(schema.Columns, column)
+	}
+
+	return schema
+}
+
+// InferSchema reads the header and up to sampleRows records of the provided file, and infers the type of each column from the sampled values.
+// Columns are recognized as int, float, bool or time, and fall back to string. Empty values are ignored, and a column with no values is a string.
+// Columns with an empty or repeated header label are bound by index. If sampleRows is not positive, every record in the file is sampled.
+func InferSchema(file io.Reader, sampleRows int, options ParserOptions) (schema Schema, err error) {
+	p := NewParser(file, options)
+
+	header, err := p.readHeader()
+	if err != nil {
+		return schema, err
+	}
+
+	inference := newSchemaInference(header)
+
+	for sampleRows <= 0 || p.line < sampleRows {
+		record, err := p.readNextRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return schema, err
+		}
+
+		inference.observe(record)
+	}
+
+	return inference.schema(), nil
+}
@@ -0,0 +1,63 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+)
+
+const inferenceTestData = `id,name,price,active,sold,,notes
+1,widget,12.5,true,2022-03-04,a,
+2,gadget,3,false,2022-03-05,b,
+3,gizmo,1,true,2022-03-06,c,`
+
+func TestInferSchema(t *testing.T) {
+	schema, err := InferSchema(strings.NewReader(inferenceTestData), 10, ParserOptions{})
+	if err != nil {
+		t.Errorf("encountered error inferring schema: %v", err)
+	}
+
+	expected := []SchemaColumn{
+		{Name: "id", Type: ColumnTypeInt},
+		{Name: "name", Type: ColumnTypeString},
+		{Name: "price", Type: ColumnTypeFloat},
+		{Name: "active", Type: ColumnTypeBool},
+		{Name: "sold", Type: ColumnTypeTime, Format: "2006-01-02"},
+		{Name: "column5", Type: ColumnTypeString},
+		{Name: "notes", Type: ColumnTypeString},
+	}
+
+	if len(schema.Columns) != len(expected) {
+		t.Errorf("inferred %d columns but expected %d", len(schema.Columns), len(expected))
+		return
+	}
+
+	for i, column := range schema.Columns {
+		if column.Name != expected[i].Name || column.Type != expected[i].Type || column.Format != expected[i].Format {
+			t.Errorf("improperly inferred column %d. Got '%v' but expected '%v'", i, column, expected[i])
+		}
+	}
+
+	if schema.Columns[5].Index == nil || *schema.Columns[5].Index != 5 {
+		t.Errorf("column without a header label should be bound by index 5")
+	}
+
+	sp := NewSchemaParser(strings.NewReader(inferenceTestData), schema, ParserOptions{})
+	record, err := sp.ReadRecord()
+	if err != nil {
+		t.Errorf("encountered error parsing csv with inferred schema: %v", err)
+	}
+	if record["price"] != 12.5 {
+		t.Errorf("improperly parsed price with inferred schema. Got '%v' but expected '12.5'", record["price"])
+	}
+}
+
+func TestInferSchemaSampleRows(t *testing.T) {
+	schema, err := InferSchema(strings.NewReader("value\n1\n2\nthree"), 2, ParserOptions{})
+	if err != nil {
+		t.Errorf("encountered error inferring schema: %v", err)
+	}
+
+	if schema.Columns[0].Type != ColumnTypeInt {
+		t.Errorf("inference should only sample 2 rows. Got type '%s' but expected 'int'", schema.Columns[0].Type)
+	}
+}
@@ -0,0 +1,425 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrorInvalidJoinType = fmt.Errorf("join type must be JoinInner, JoinLeft, JoinRight or JoinOuter")
+	ErrorNoJoinColumns   = fmt.Errorf("join needs at least one column to join on")
+	ErrorJoinNotSorted   = fmt.Errorf("records are not sorted by the join columns")
+)
+
+// JoinType chooses which records a join returns when a record of one side has no match on the other.
+type JoinType int
+
+const (
+	// JoinInner returns only the records that match a record of the other side.
+	JoinInner JoinType = iota
+	// JoinLeft also returns the records of the left side without a match, with empty right values.
+	JoinLeft
+	// JoinRight also returns the records of the right side without a match, with empty left values apart from the join columns.
+	JoinRight
+	// JoinOuter returns the records of both sides without a match.
+	JoinOuter
+)
+
+// JoinOptions sets how Join combines two parsers.
+type JoinOptions struct {
+	Type JoinType
+	// Sorted merges the records of both sides in a single pass, which needs both to be sorted in ascending order by the join columns, compared as text.
+	// Otherwise the records of the right side are read into memory first, so the records of either side may be in any order.
+	Sorted bool
+	// RightPrefix is added to the labels of columns of the right side that have the same label as a column of the left side. It defaults to right_.
+	RightPrefix string
+}
+
+// JoinReader reads the records of two parsers joined on the values of some of their columns, as created by Join. Each joined record holds the columns of the left side,
+// followed by the columns of the right side apart from the join columns.
+type JoinReader struct {
+	left, right     *Parser
+	options         JoinOptions
+	header          []string
+	leftWidth       int
+	leftKeys        []int
+	rightKeys       []int
+	rightKept       []int
+	headerRead      bool
+	pending         [][]string
+	index           map[string][][]string
+	matched         map[string]bool
+	rightOrder      []string
+	leftDone        bool
+	leftRecord      []string
+	group           [][]string
+	groupKey        []string
+	groupMatched    bool
+	nextRight       []string
+	rightDone       bool
+	previousLeftKey []string
+}
+
+// Join joins the records of left and right on the values of the columns labeled on, which both must have, such as to enrich orders with the details of their customers.
+// Both parsers are read from their current positions, and their headers are read first if they haven't been parsed. A record matching several records of the other side
+// is returned once for each of them. Read the joined records as maps with ReadRecordMap, or into structs by creating a parser over the JoinReader with NewRecordParser.
+func Join(left, right *Parser, on []string, options JoinOptions) (j *JoinReader, err error) {
+	if options.Type < JoinInner || options.Type > JoinOuter {
+		return nil, OptionError{
+			Option: "Type",
+			Err:    ErrorInvalidJoinType,
+		}
+	}
+
+	if len(on) == 0 {
+		return nil, ErrorNoJoinColumns
+	}
+
+	if options.RightPrefix == "" {
+		options.RightPrefix = "right_"
+	}
+
+	j = &JoinReader{left: left, right: right, options: options}
+
+	leftHeader, err := joinHeader(left)
+	if err != nil {
+		return nil, err
+	}
+	rightHeader, err := joinHeader(right)
+	if err != nil {
+		return nil, err
+	}
+
+	j.leftKeys, err = joinColumns(leftHeader, on)
+	if err != nil {
+		return nil, err
+	}
+	j.rightKeys, err = joinColumns(rightHeader, on)
+	if err != nil {
+		return nil, err
+	}
+
+	j.leftWidth = len(leftHeader)
+	j.header = append(j.header, leftHeader...)
+
+	isKey := make(map[int]bool, len(j.rightKeys))
+	for _, idx := range j.rightKeys {
+		isKey[idx] = true
+	}
+	inLeft := make(map[string]bool, len(leftHeader))
+	for _, label := range leftHeader {
+		inLeft[label] = true
+	}
+	for idx, label := range rightHeader {
+		if isKey[idx] {
+			continue
+		}
+		if inLeft[label] {
+			label = options.RightPrefix + label
+		}
+		j.rightKept = append(j.rightKept, idx)
+		j.header = append(j.header, label)
+	}
+
+	if !options.Sorted {
+		err = j.indexRight()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return j, nil
+}
+
+// joinHeader returns the header of p, reading it if it hasn't been parsed.
+func joinHeader(p *Parser) (header []string, err error) {
+	if p.header != nil {
+		return p.header, nil
+	}
+
+	return p.readHeader()
+}
+
+// joinColumns returns the index of each of the columns labeled on in header.
+func joinColumns(header []string, on []string) (columns []int, err error) {
+	for _, label := range on {
+		idx, found := findHeaderIndex(header, label)
+		if !found {
+			return nil, FieldNotFoundError{
+				FieldName:  label,
+				HeaderName: label,
+				Err:        ErrorFieldNotFound,
+			}
+		}
+		columns = append(columns, idx)
+	}
+
+	return columns, nil
+}
+
+// joinKey returns the values of the columns of record at indexes.
+func joinKey(record []string, indexes []int) (key []string) {
+	key = make([]string, len(indexes))
+	for i, idx := range indexes {
+		if idx < len(record) {
+			key[i] = record[idx]
+		}
+	}
+
+	return key
+}
+
+// compareJoinKeys compares two keys column by column as text.
+func compareJoinKeys(a []string, b []string) int {
+	for idx := range a {
+		if result := strings.Compare(a[idx], b[idx]); result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+// Header returns the labels of the columns of the joined records. Columns of the right side whose labels clash with the left side carry the RightPrefix.
+func (j *JoinReader) Header() []string {
+	return append([]string(nil), j.header...)
+}
+
+// Read returns the header, followed by the joined records, so that the JoinReader can be read by a parser created with NewRecordParser. It returns io.EOF after the last record.
+func (j *JoinReader) Read() (record []string, err error) {
+	if !j.headerRead {
+		j.headerRead = true
+		return j.Header(), nil
+	}
+
+	return j.next()
+}
+
+// ReadRecordMap returns the next joined record keyed by the labels returned by Header. It returns io.EOF after the last record.
+func (j *JoinReader) ReadRecordMap() (record map[string]string, err error) {
+	values, err := j.next()
+	if err != nil {
+		return nil, err
+	}
+
+	record = make(map[string]string, len(j.header))
+	for idx, label := range j.header {
+		if _, ok := record[label]; !ok {
+			record[label] = values[idx]
+		}
+	}
+
+	return record, nil
+}
+
+// combine joins a record of each side into one. Either may be nil when a record has no match, in which case its values are left empty,
+// apart from the join columns of a missing left record, which are taken from the right record.
+func (j *JoinReader) combine(left []string, right []string) (record []string) {
+	record = make([]string, len(j.header))
+	if left != nil {
+		copy(record[:j.leftWidth], left)
+	} else {
+		for i, idx := range j.leftKeys {
+			if j.rightKeys[i] < len(right) {
+				record[idx] = right[j.rightKeys[i]]
+			}
+		}
+	}
+
+	if right != nil {
+		for i, idx := range j.rightKept {
+			if idx < len(right) {
+				record[j.leftWidth+i] = right[idx]
+			}
+		}
+	}
+
+	return record
+}
+
+// includesLeft reports whether records of the left side without a match are returned.
+func (j *JoinReader) includesLeft() bool {
+	return j.options.Type == JoinLeft || j.options.Type == JoinOuter
+}
+
+// includesRight reports whether records of the right side without a match are returned.
+func (j *JoinReader) includesRight() bool {
+	return j.options.Type == JoinRight || j.options.Type == JoinOuter
+}
+
+// next returns the next joined record.
+func (j *JoinReader) next() (record []string, err error) {
+	for len(j.pending) == 0 {
+		if j.options.Sorted {
+			err = j.mergeStep()
+		} else {
+			err = j.hashStep()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	record = j.pending[0]
+	j.pending = j.pending[1:]
+
+	return record, nil
+}
+
+// indexRight reads every record of the right side into memory, indexed by its join columns.
+func (j *JoinReader) indexRight() (err error) {
+	j.index = make(map[string][][]string)
+	j.matched = make(map[string]bool)
+
+	for {
+		record, err := j.right.readNextRecord()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		id := strings.Join(joinKey(record, j.rightKeys), "\x00")
+		if _, seen := j.index[id]; !seen {
+			j.rightOrder = append(j.rightOrder, id)
+		}
+		j.index[id] = append(j.index[id], append([]string(nil), record...))
+	}
+}
+
+// hashStep joins the next record of the left side with the records of the right side held in memory, or once the left side is exhausted,
+// queues the records of the right side that weren't matched.
+func (j *JoinReader) hashStep() (err error) {
+	if j.leftDone {
+		if !j.includesRight() || len(j.rightOrder) == 0 {
+			return io.EOF
+		}
+
+		id := j.rightOrder[0]
+		j.rightOrder = j.rightOrder[1:]
+		if !j.matched[id] {
+			for _, right := range j.index[id] {
+				j.pending = append(j.pending, j.combine(nil, right))
+			}
+		}
+		return nil
+	}
+
+	left, err := j.left.readNextRecord()
+	if err == io.EOF {
+		j.leftDone = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	id := strings.Join(joinKey(left, j.leftKeys), "\x00")
+	matches := j.index[id]
+	if len(matches) == 0 {
+		if j.includesLeft() {
+			j.pending = append(j.pending, j.combine(left, nil))
+		}
+		return nil
+	}
+
+	j.matched[id] = true
+	for _, right := range matches {
+		j.pending = append(j.pending, j.combine(left, right))
+	}
+
+	return nil
+}
+
+// readRightGroup reads the next run of records of the right side with the same join columns.
+func (j *JoinReader) readRightGroup() (err error) {
+	j.group, j.groupKey, j.groupMatched = nil, nil, false
+
+	for !j.rightDone {
+		if j.nextRight == nil {
+			record, err := j.right.readNextRecord()
+			if err == io.EOF {
+				j.rightDone = true
+				break
+			}
+			if err != nil {
+				return err
+			}
+			j.nextRight = append([]string(nil), record...)
+		}
+
+		key := joinKey(j.nextRight, j.rightKeys)
+		if j.groupKey == nil {
+			j.groupKey = key
+		} else if result := compareJoinKeys(key, j.groupKey); result < 0 {
+			return RecordError{
+				Line: j.right.line,
+				Err:  ErrorJoinNotSorted,
+			}
+		} else if result > 0 {
+			break
+		}
+
+		j.group = append(j.group, j.nextRight)
+		j.nextRight = nil
+	}
+
+	return nil
+}
+
+// mergeStep advances whichever side has the lesser join columns, joining the current record of the left side with the current group of the right side when they match.
+func (j *JoinReader) mergeStep() (err error) {
+	if j.groupKey == nil && !j.rightDone {
+		err = j.readRightGroup()
+		if err != nil {
+			return err
+		}
+	}
+
+	if j.leftRecord == nil && !j.leftDone {
+		left, err := j.left.readNextRecord()
+		if err == io.EOF {
+			j.leftDone = true
+		} else if err != nil {
+			return err
+		} else {
+			j.leftRecord = append([]string(nil), left...)
+
+			key := joinKey(j.leftRecord, j.leftKeys)
+			if j.previousLeftKey != nil && compareJoinKeys(key, j.previousLeftKey) < 0 {
+				return RecordError{
+					Line: j.left.line,
+					Err:  ErrorJoinNotSorted,
+				}
+			}
+			j.previousLeftKey = key
+		}
+	}
+
+	switch {
+	case j.leftRecord == nil && j.groupKey == nil:
+		return io.EOF
+	case j.groupKey == nil || (j.leftRecord != nil && compareJoinKeys(j.previousLeftKey, j.groupKey) < 0):
+		if j.includesLeft() {
+			j.pending = append(j.pending, j.combine(j.leftRecord, nil))
+		}
+		j.leftRecord = nil
+	case j.leftRecord == nil || compareJoinKeys(j.previousLeftKey, j.groupKey) > 0:
+		if j.includesRight() && !j.groupMatched {
+			for _, right := range j.group {
+				j.pending = append(j.pending, j.combine(nil, right))
+			}
+		}
+		j.groupKey = nil
+	default:
+		for _, right := range j.group {
+			j.pending = append(j.pending, j.combine(j.leftRecord, right))
+		}
+		j.groupMatched = true
+		j.leftRecord = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const joinOrders = "order,customer,total\n1,a,10\n2,b,20\n3,a,30\n4,d,40\n"
+const joinCustomers = "customer,name,total\na,Ann,100\nb,Bob,200\nc,Cat,300\n"
+
+func readJoined(t *testing.T, left string, right string, options JoinOptions) (records []string) {
+	t.Helper()
+
+	leftParser := NewParser(strings.NewReader(left), ParserOptions{})
+	rightParser := NewParser(strings.NewReader(right), ParserOptions{})
+
+	j, err := Join(&leftParser, &rightParser, []string{"customer"}, options)
+	if err != nil {
+		t.Fatalf("encountered error joining: %v", err)
+	}
+
+	for {
+		record, err := j.next()
+		if err == io.EOF {
+			return records
+		}
+		if err != nil {
+			t.Fatalf("encountered error reading joined records: %v", err)
+		}
+		records = append(records, strings.Join(record, ","))
+	}
+}
+
+func TestJoinTypes(t *testing.T) {
+	tests := []struct {
+		joinType JoinType
+		expected []string
+	}{
+		{JoinInner, []string{"1,a,10,Ann,100", "2,b,20,Bob,200", "3,a,30,Ann,100"}},
+		{JoinLeft, []string{"1,a,10,Ann,100", "2,b,20,Bob,200", "3,a,30,Ann,100", "4,d,40,,"}},
+		{JoinRight, []string{"1,a,10,Ann,100", "2,b,20,Bob,200", "3,a,30,Ann,100", ",c,,Cat,300"}},
+		{JoinOuter, []string{"1,a,10,Ann,100", "2,b,20,Bob,200", "3,a,30,Ann,100", "4,d,40,,", ",c,,Cat,300"}},
+	}
+
+	sortedOrders := "order,customer,total\n1,a,10\n3,a,30\n2,b,20\n4,d,40\n"
+
+	for _, test := range tests {
+		got := readJoined(t, joinOrders, joinCustomers, JoinOptions{Type: test.joinType})
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("improperly hash joined records for join type %d.\nGot      %q\nexpected %q", test.joinType, got, test.expected)
+		}
+
+		merged := readJoined(t, sortedOrders, joinCustomers, JoinOptions{Type: test.joinType, Sorted: true})
+		sort.Strings(merged)
+		expected := append([]string(nil), test.expected...)
+		sort.Strings(expected)
+		if !reflect.DeepEqual(merged, expected) {
+			t.Errorf("improperly merge joined records for join type %d.\nGot      %q\nexpected %q", test.joinType, merged, expected)
+		}
+	}
+}
+
+func TestJoinManyToMany(t *testing.T) {
+	left := "customer,x\na,1\na,2\n"
+	right := "customer,y\na,3\na,4\n"
+
+	for _, sorted := range []bool{false, true} {
+		got := readJoined(t, left, right, JoinOptions{Sorted: sorted})
+		expected := []string{"a,1,3", "a,1,4", "a,2,3", "a,2,4"}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected every pair of matching records when sorted is %v. Got %q", sorted, got)
+		}
+	}
+}
+
+type joinedOrder struct {
+	Order         int    `csv:"header:order"`
+	Name          string `csv:"header:name"`
+	CustomerTotal int    `csv:"header:right_total"`
+}
+
+func TestJoinIntoStructs(t *testing.T) {
+	leftParser := NewParser(strings.NewReader(joinOrders), ParserOptions{})
+	rightParser := NewParser(strings.NewReader(joinCustomers), ParserOptions{})
+
+	j, err := Join(&leftParser, &rightParser, []string{"customer"}, JoinOptions{})
+	if err != nil {
+		t.Fatalf("encountered error joining: %v", err)
+	}
+
+	p := NewRecordParser(j, ParserOptions{})
+	err = p.ParseHeader(&joinedOrder{})
+	if err != nil {
+		t.Fatalf("encountered error parsing joined header: %v", err)
+	}
+
+	var record joinedOrder
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Fatalf("encountered error reading joined record: %v", err)
+	}
+
+	expected := joinedOrder{Order: 1, Name: "Ann", CustomerTotal: 100}
+	if record != expected {
+		t.Errorf("improperly read joined record. Got %+v but expected %+v", record, expected)
+	}
+}
+
+func TestJoinReadRecordMap(t *testing.T) {
+	leftParser := NewParser(strings.NewReader(joinOrders), ParserOptions{})
+	rightParser := NewParser(strings.NewReader(joinCustomers), ParserOptions{})
+
+	j, err := Join(&leftParser, &rightParser, []string{"customer"}, JoinOptions{})
+	if err != nil {
+		t.Fatalf("encountered error joining: %v", err)
+	}
+
+	record, err := j.ReadRecordMap()
+	if err != nil {
+		t.Fatalf("encountered error reading joined record: %v", err)
+	}
+
+	expected := map[string]string{"order": "1", "customer": "a", "total": "10", "name": "Ann", "right_total": "100"}
+	if !reflect.DeepEqual(record, expected) {
+		t.Errorf("improperly read joined map. Got %v but expected %v", record, expected)
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	newParsers := func(left string, right string) (*Parser, *Parser) {
+		leftParser := NewParser(strings.NewReader(left), ParserOptions{})
+		rightParser := NewParser(strings.NewReader(right), ParserOptions{})
+		return &leftParser, &rightParser
+	}
+
+	left, right := newParsers(joinOrders, joinCustomers)
+	_, err := Join(left, right, []string{"missing"}, JoinOptions{})
+	if !errors.Is(err, ErrorFieldNotFound) {
+		t.Errorf("expected %v, but got %v", ErrorFieldNotFound, err)
+	}
+
+	left, right = newParsers(joinOrders, joinCustomers)
+	_, err = Join(left, right, []string{"customer"}, JoinOptions{Type: JoinType(9)})
+	if !errors.Is(err, ErrorInvalidJoinType) {
+		t.Errorf("expected %v, but got %v", ErrorInvalidJoinType, err)
+	}
+
+	left, right = newParsers(joinOrders, joinCustomers)
+	j, err := Join(left, right, []string{"customer"}, JoinOptions{Sorted: true})
+	if err != nil {
+		t.Fatalf("encountered error joining: %v", err)
+	}
+	for err == nil {
+		_, err = j.ReadRecordMap()
+	}
+	if !errors.Is(err, ErrorJoinNotSorted) {
+		t.Errorf("expected %v for unsorted input, but got %v", ErrorJoinNotSorted, err)
+	}
+}
@@ -0,0 +1,410 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+var (
+	ErrorJSONNotObject = fmt.Errorf("JSON value is not an object")
+)
+
+const defaultJSONSampleRows = 100
+
+// JSONOptions controls how ConvertToJSON reads csv data and writes JSON.
+type JSONOptions struct {
+	ParserOptions ParserOptions
+	// NDJSON writes one JSON object per line instead of a JSON array.
+	NDJSON bool
+	// InferTypes writes numbers, booleans and times as JSON values rather than strings, using the column types inferred from the first SampleRows records.
+	// Empty values in typed columns are written as null.
+	InferTypes bool
+	// SampleRows is the number of records used to infer column types, and defaults to 100.
+	SampleRows int
+}
+
+// ConvertToJSON streams the csv data in file to w as JSON objects keyed by header label, with keys in header order.
+// The records are written as a JSON array, or as newline delimited JSON if the NDJSON option is set.
+func ConvertToJSON(file io.Reader, w io.Writer, options JSONOptions) (err error) {
+	p := NewParser(file, options.ParserOptions)
+
+	header, err := p.readHeader()
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	var sample [][]string
+	inference := newSchemaInference(header)
+	if options.InferTypes {
+		sampleRows := options.SampleRows
+		if sampleRows <= 0 {
+			sampleRows = defaultJSONSampleRows
+		}
+
+		for len(sample) < sampleRows {
+			record, err := p.readNextRecord()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			inference.observe(record)
+			sample = append(sample, append([]string(nil), record...))
+		}
+	}
+
+	// Without any observed records, every column is inferred to be a string.
+	columns := inference.schema().Columns
+
+	keys := make([][]byte, len(header))
+	for i, headerLabel := range header {
+		keys[i], err = json.Marshal(headerLabel)
+		if err != nil {
+			return err
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	jw := jsonRecordWriter{
+		out:     out,
+		parser:  &p,
+		keys:    keys,
+		columns: columns,
+		ndjson:  options.NDJSON,
+	}
+
+	if !options.NDJSON {
+		out.WriteString("[")
+	}
+
+	for i, record := range sample {
+		err = jw.write(record, i+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		record, err := p.readNextRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		err = jw.write(record, p.line)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !options.NDJSON {
+		out.WriteString("]\n")
+	}
+
+	return out.Flush()
+}
+
+type jsonRecordWriter struct {
+	out     *bufio.Writer
+	parser  *Parser
+	keys    [][]byte
+	columns []SchemaColumn
+	ndjson  bool
+	written int
+}
+
+func (jw *jsonRecordWriter) write(record []string, line int) (err error) {
+	if !jw.ndjson && jw.written > 0 {
+		jw.out.WriteString(",")
+	}
+	jw.written++
+
+	jw.out.WriteString("{")
+	for i, key := range jw.keys {
+		if i > 0 {
+			jw.out.WriteString(",")
+		}
+		jw.out.Write(key)
+		jw.out.WriteString(":")
+
+		var value string
+		if i < len(record) {
+			value = record[i]
+		}
+
+		var converted interface{}
+		if value != "" || jw.columns[i].Type == ColumnTypeString {
+			converted, err = convertSchemaValue(jw.parser, jw.columns[i], value)
+			if err != nil {
+				return SetValueError{
+					Line:      line,
+					Value:     value,
+					FieldName: jw.columns[i].Name,
+					Err:       err,
+				}
+			}
+		}
+
+		encoded, err := json.Marshal(converted)
+		if err != nil {
+			return SetValueError{
+				Line:      line,
+				Value:     value,
+				FieldName: jw.columns[i].Name,
+				Err:       err,
+			}
+		}
+		jw.out.Write(encoded)
+	}
+	jw.out.WriteString("}")
+
+	if jw.ndjson {
+		jw.out.WriteString("\n")
+	}
+
+	return nil
+}
+
+// ColumnOrder decides the order of the columns ConvertFromJSON derives from the keys of the JSON objects.
+type ColumnOrder int
+
+const (
+	// ColumnOrderFirstSeen orders columns by when their key is first seen, reading each object's keys in order.
+	ColumnOrderFirstSeen ColumnOrder = iota
+	// ColumnOrderSorted orders columns alphabetically by key.
+	ColumnOrderSorted
+)
+
+// FromJSONOptions controls how ConvertFromJSON reads JSON and writes csv data.
+type FromJSONOptions struct {
+	WriterOptions WriterOptions
+	// StructPointer is a pointer to a struct with csv tags. When set, each JSON object is decoded into the struct with encoding/json,
+	// and written with the struct's csv tags, which decide the columns.
+	StructPointer interface{}
+	// Columns lists the keys to write as columns, in order. Keys of the JSON objects that aren't listed are ignored.
+	Columns []string
+	// ColumnOrder orders the columns when neither StructPointer nor Columns is set, and the columns are the union of the keys of every object.
+	ColumnOrder ColumnOrder
+}
+
+// ConvertFromJSON streams a JSON array of objects, or newline delimited JSON objects, from file to w as csv data with a header.
+// When neither StructPointer nor Columns is set in the options, every object is read before writing so that the columns can cover the keys of all of them.
+// Strings are written without quotes, null is written as an empty value, and nested arrays and objects are written as JSON.
+func ConvertFromJSON(file io.Reader, w io.Writer, options FromJSONOptions) (err error) {
+	in := bufio.NewReader(file)
+	isArray, err := startsWithArray(in)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(in)
+	if isArray {
+		_, err = dec.Token()
+		if err != nil {
+			return err
+		}
+	}
+
+	// More reports whether another value follows, both within an array and for a top level stream of values.
+	next := dec.More
+
+	writer := NewWriter(w, options.WriterOptions)
+
+	if options.StructPointer != nil {
+		err = convertJSONStructs(dec, &writer, options.StructPointer, next)
+	} else {
+		err = convertJSONObjects(dec, &writer, options, next)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// startsWithArray reports whether the first non space byte of in opens a JSON array, without consuming it.
+func startsWithArray(in *bufio.Reader) (isArray bool, err error) {
+	for {
+		b, err := in.Peek(1)
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			in.ReadByte()
+		default:
+			return b[0] == '[', nil
+		}
+	}
+}
+
+func convertJSONStructs(dec *json.Decoder, writer *Writer, structPointer interface{}, next func() bool) (err error) {
+	err = writer.WriteHeader(structPointer)
+	if err != nil {
+		return err
+	}
+
+	structType := reflect.TypeOf(structPointer).Elem()
+	for next() {
+		record := reflect.New(structType).Interface()
+		err = dec.Decode(record)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		err = writer.WriteRecord(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convertJSONObjects(dec *json.Decoder, writer *Writer, options FromJSONOptions, next func() bool) (err error) {
+	columns := options.Columns
+	streaming := columns != nil
+
+	if streaming {
+		err = writer.writeRaw(columns)
+		if err != nil {
+			return err
+		}
+	}
+
+	var buffered []map[string]string
+	seen := make(map[string]bool)
+	for next() {
+		keys, object, err := decodeJSONObject(dec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if streaming {
+			err = writeJSONObject(writer, columns, object)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, key := range keys {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+		buffered = append(buffered, object)
+	}
+
+	if streaming {
+		return nil
+	}
+
+	if len(columns) == 0 {
+		return nil
+	}
+
+	if options.ColumnOrder == ColumnOrderSorted {
+		sort.Strings(columns)
+	}
+
+	err = writer.writeRaw(columns)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range buffered {
+		err = writeJSONObject(writer, columns, object)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeJSONObject(writer *Writer, columns []string, object map[string]string) (err error) {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = object[column]
+	}
+
+	return writer.writeRaw(record)
+}
+
+// decodeJSONObject reads the next JSON object from dec, returning its keys in order and its values formatted as csv values.
+func decodeJSONObject(dec *json.Decoder) (keys []string, object map[string]string, err error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, nil, ErrorJSONNotObject
+	}
+
+	object = make(map[string]string)
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := token.(string)
+
+		var raw json.RawMessage
+		err = dec.Decode(&raw)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, ok := object[key]; !ok {
+			keys = append(keys, key)
+		}
+		object[key], err = formatJSONValue(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	_, err = dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return keys, object, nil
+}
+
+func formatJSONValue(raw json.RawMessage) (value string, err error) {
+	switch {
+	case string(raw) == "null":
+		return "", nil
+	case raw[0] == '"':
+		err = json.Unmarshal(raw, &value)
+		return value, err
+	case raw[0] == '{' || raw[0] == '[':
+		var compacted bytes.Buffer
+		err = json.Compact(&compacted, raw)
+		return compacted.String(), err
+	}
+
+	return string(raw), nil
+}
@@ -0,0 +1,120 @@
+package csv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const jsonTestData = `id,name,price,sold
+1,widget,12.5,2022-03-04
+2,"gadget ""deluxe""",,2022-03-05`
+
+func TestConvertToJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := ConvertToJSON(strings.NewReader(jsonTestData), &buf, JSONOptions{})
+	if err != nil {
+		t.Errorf("encountered error converting csv to json: %v", err)
+	}
+
+	expected := `[{"id":"1","name":"widget","price":"12.5","sold":"2022-03-04"},{"id":"2","name":"gadget \"deluxe\"","price":"","sold":"2022-03-05"}]` + "\n"
+	if buf.String() != expected {
+		t.Errorf("improperly converted csv to json. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+func TestConvertToNDJSONWithTypes(t *testing.T) {
+	var buf bytes.Buffer
+	err := ConvertToJSON(strings.NewReader(jsonTestData), &buf, JSONOptions{NDJSON: true, InferTypes: true, SampleRows: 1})
+	if err != nil {
+		t.Errorf("encountered error converting csv to ndjson: %v", err)
+	}
+
+	expected := `{"id":1,"name":"widget","price":12.5,"sold":"2022-03-04T00:00:00Z"}` + "\n" +
+		`{"id":2,"name":"gadget \"deluxe\"","price":null,"sold":"2022-03-05T00:00:00Z"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("improperly converted csv to ndjson. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+func TestConvertToJSONInferenceError(t *testing.T) {
+	err := ConvertToJSON(strings.NewReader("id\n1\ntwo"), &bytes.Buffer{}, JSONOptions{InferTypes: true, SampleRows: 1})
+	if err == nil {
+		t.Errorf("expected to encounter an error converting a value that doesn't match the inferred type, but got none")
+	}
+}
+
+func TestConvertToJSONNonFinite(t *testing.T) {
+	var buf bytes.Buffer
+	err := ConvertToJSON(strings.NewReader("x,y\n1.5,2\nNaN,-Inf"), &buf, JSONOptions{NDJSON: true, InferTypes: true})
+	if err != nil {
+		t.Errorf("encountered error converting csv with non-finite floats to ndjson: %v", err)
+	}
+
+	expected := `{"x":"1.5","y":"2"}` + "\n" + `{"x":"NaN","y":"-Inf"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("improperly converted non-finite floats to ndjson. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+func TestConvertFromJSONArray(t *testing.T) {
+	input := `[
+		{"name": "widget", "price": 12.5, "tags": ["a", "b"]},
+		{"price": 3, "name": "gadget, deluxe", "sold": true, "note": null}
+	]`
+
+	var buf bytes.Buffer
+	err := ConvertFromJSON(strings.NewReader(input), &buf, FromJSONOptions{})
+	if err != nil {
+		t.Errorf("encountered error converting json to csv: %v", err)
+	}
+
+	expected := "name,price,tags,sold,note\nwidget,12.5,\"[\"\"a\"\",\"\"b\"\"]\",,\n\"gadget, deluxe\",3,,true,\n"
+	if buf.String() != expected {
+		t.Errorf("improperly converted json to csv. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+
+	buf.Reset()
+	err = ConvertFromJSON(strings.NewReader(input), &buf, FromJSONOptions{ColumnOrder: ColumnOrderSorted})
+	if err != nil {
+		t.Errorf("encountered error converting json to csv: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "name,note,price,sold,tags\n") {
+		t.Errorf("columns were not sorted. Got '%s'", buf.String())
+	}
+}
+
+func TestConvertFromNDJSONColumns(t *testing.T) {
+	input := "{\"name\": \"widget\", \"price\": 12.5}\n{\"name\": \"gadget\", \"ignored\": 1}\n"
+
+	var buf bytes.Buffer
+	err := ConvertFromJSON(strings.NewReader(input), &buf, FromJSONOptions{Columns: []string{"price", "name"}})
+	if err != nil {
+		t.Errorf("encountered error converting ndjson to csv: %v", err)
+	}
+
+	expected := "price,name\n12.5,widget\n,gadget\n"
+	if buf.String() != expected {
+		t.Errorf("improperly converted ndjson to csv. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+type fromJSONStruct struct {
+	Name  string  `json:"name" csv:"header:product"`
+	Price float64 `json:"price" csv:"header:cost;precision:2"`
+}
+
+func TestConvertFromJSONStruct(t *testing.T) {
+	input := `[{"name": "widget", "price": 12.5}, {"name": "gadget", "price": 3}]`
+
+	var buf bytes.Buffer
+	err := ConvertFromJSON(strings.NewReader(input), &buf, FromJSONOptions{StructPointer: &fromJSONStruct{}})
+	if err != nil {
+		t.Errorf("encountered error converting json to csv with struct: %v", err)
+	}
+
+	expected := "product,cost\nwidget,12.50\ngadget,3.00\n"
+	if buf.String() != expected {
+		t.Errorf("improperly converted json to csv with struct. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
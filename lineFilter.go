@@ -0,0 +1,292 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrorBlankLine              = fmt.Errorf("blank lines are not allowed")
+	ErrorEndOfSection           = fmt.Errorf("a blank line ended the section")
+	ErrorInvalidBlankLinePolicy = fmt.Errorf("blank line policy must be BlankLineSkip, BlankLineEndSection or BlankLineError")
+	ErrorInvalidCommentPrefix   = fmt.Errorf("comment prefix may not contain a quote, a line break or the delimiter")
+	ErrorCommentPrefixAndChar   = fmt.Errorf("comment prefix may not be used with a comment character")
+	ErrorInlineCommentsPrefix   = fmt.Errorf("inline comments need a comment prefix or comment character")
+)
+
+// BlankLinePolicy decides what the parser does with blank lines, when ParserOptions.BlankLines is set.
+type BlankLinePolicy int
+
+const (
+	// BlankLineSkip skips blank lines, as encoding/csv does.
+	BlankLineSkip BlankLinePolicy = iota
+	// BlankLineEndSection makes the first read after one or more blank lines return ErrorEndOfSection, so that the sections of a report can be told apart. Reading continues with the next section.
+	BlankLineEndSection
+	// BlankLineError makes the first read after one or more blank lines return an UnexpectedBlankLineError. Reading continues with the next record.
+	BlankLineError
+)
+
+// UnexpectedBlankLineError is returned by reads of a parser with the BlankLineError policy when blank lines come before the next record. Line is the line of the file the first of them is on.
+type UnexpectedBlankLineError struct {
+	Line int
+	Err  error
+}
+
+func (e UnexpectedBlankLineError) Error() string {
+	return fmt.Sprintf("blank line on line %d of the file: %v", e.Line, e.Err)
+}
+
+func (e UnexpectedBlankLineError) Unwrap() error { return e.Err }
+
+// needsLineFilter reports whether options use any of the blank line or comment policies that the standard csv reader doesn't support.
+func needsLineFilter(options ParserOptions) bool {
+	return options.BlankLines != BlankLineSkip || options.CommentPrefix != "" || options.InlineComments || options.Dialect == DialectExcel
+}
+
+// newParserReader creates the record reader of a parser for file, which reads through a line filter when the options need one.
+func newParserReader(file io.Reader, options ParserOptions) (reader RecordReader) {
+	options = options.withDialect()
+	if !needsLineFilter(options) {
+		return newRecordReader(file, options)
+	}
+
+	filter := newLineFilter(file, options)
+
+	return &lineFilterReader{
+		reader: newRecordReader(filter, options),
+		filter: filter,
+		policy: options.BlankLines,
+	}
+}
+
+// lineFilter removes comments from the lines of a csv file before they are read by the standard csv reader, and notes the lines that are blank.
+// Comments are removed without removing the line breaks that end them, so that line numbers reported by the csv reader still match the file.
+// It follows quotes across lines, so that quoted values are never mistaken for comments or blank lines.
+type lineFilter struct {
+	source         *bufio.Reader
+	prefix         []byte
+	quote          []byte
+	escape         []byte
+	continued      bool
+	skipBOM        bool
+	fullLine       bool
+	inline         bool
+	trackBlanks    bool
+	quoted         bool
+	line           int
+	blankLines     []int
+	pending        []byte
+	err            error
+	filteredOffset int64
+	removed        int64
+	shifts         []offsetShift
+}
+
+// offsetShift records that bytes of the filtered file from offset on are removed bytes behind the same bytes of the original file.
+type offsetShift struct {
+	offset  int64
+	removed int64
+}
+
+func newLineFilter(file io.Reader, options ParserOptions) (filter *lineFilter) {
+	filter = &lineFilter{
+		source:      bufio.NewReader(file),
+		fullLine:    options.CommentPrefix != "",
+		inline:      options.InlineComments,
+		trackBlanks: options.BlankLines != BlankLineSkip,
+	}
+
+	filter.skipBOM = options.Dialect == DialectExcel
+	if options.QuoteChar != 0 {
+		filter.quote = []byte(string(options.QuoteChar))
+	} else if !options.Dialect.unquoted() {
+		filter.quote = []byte(`"`)
+	}
+	if options.EscapeChar != 0 {
+		filter.escape = []byte(string(options.EscapeChar))
+	}
+
+	filter.prefix = []byte(options.CommentPrefix)
+	if len(filter.prefix) == 0 && options.CommentChar != 0 {
+		// Whole line comments are left to the csv reader, and only inline comments are removed here.
+		filter.prefix = []byte(string(options.CommentChar))
+	}
+
+	return filter
+}
+
+func (f *lineFilter) Read(b []byte) (n int, err error) {
+	for len(f.pending) == 0 {
+		if f.err != nil {
+			return 0, f.err
+		}
+
+		line, err := f.source.ReadBytes('\n')
+		if err != nil {
+			f.err = err
+		}
+		if len(line) > 0 {
+			f.pending = f.filterLine(line)
+		}
+	}
+
+	n = copy(b, f.pending)
+	f.pending = f.pending[n:]
+	f.filteredOffset += int64(n)
+
+	return n, nil
+}
+
+// filterLine returns line with any comment removed, and notes it if it is blank.
+func (f *lineFilter) filterLine(line []byte) (filtered []byte) {
+	f.line++
+
+	if f.line == 1 && f.skipBOM && bytes.HasPrefix(line, utf8BOM) {
+		line = f.remove(line[len(utf8BOM):], 0, len(utf8BOM))
+	}
+
+	content := bytes.TrimRight(line, "\r\n")
+	ending := line[len(content):]
+
+	// A line that an escaped line break continues onto is part of the record before it, like a quoted line.
+	continued := f.continued
+	f.continued = false
+
+	if !f.quoted && !continued {
+		if len(content) == 0 {
+			if f.trackBlanks {
+				f.blankLines = append(f.blankLines, f.line)
+			}
+			return line
+		}
+
+		if len(f.prefix) > 0 && bytes.HasPrefix(content, f.prefix) {
+			if !f.fullLine {
+				// Comment lines of the comment character are skipped by the csv reader, and their quotes don't start quoted values.
+				return line
+			}
+			return f.remove(ending, 0, len(content))
+		}
+	}
+
+	for i := 0; i < len(content); i++ {
+		if len(f.escape) > 0 && bytes.HasPrefix(content[i:], f.escape) {
+			i += len(f.escape)
+			if i >= len(content) {
+				f.continued = len(ending) > 0
+			}
+			continue
+		}
+
+		if len(f.quote) > 0 && bytes.HasPrefix(content[i:], f.quote) {
+			f.quoted = !f.quoted
+			i += len(f.quote) - 1
+			continue
+		}
+
+		if f.inline && !f.quoted && bytes.HasPrefix(content[i:], f.prefix) {
+			kept := bytes.TrimRight(content[:i], " \t")
+			return f.remove(append(kept[:len(kept):len(kept)], ending...), len(kept), len(content)-len(kept))
+		}
+	}
+
+	return line
+}
+
+// remove returns filtered, which keeps the first kept bytes of a line that count bytes were removed from, and records the removal so that offsets can be mapped back to the file.
+func (f *lineFilter) remove(filtered []byte, kept int, count int) []byte {
+	f.removed += int64(count)
+	f.shifts = append(f.shifts, offsetShift{offset: f.filteredOffset + int64(kept), removed: f.removed})
+
+	return filtered
+}
+
+// fileOffset maps an offset of the filtered file to the offset of the same byte of the original file.
+func (f *lineFilter) fileOffset(offset int64) int64 {
+	removed := int64(0)
+	for _, shift := range f.shifts {
+		if shift.offset > offset {
+			break
+		}
+		removed = shift.removed
+	}
+
+	return offset + removed
+}
+
+// takeBlankLine returns the first blank line noted before line, or 0 if there is none, and forgets every blank line before line.
+func (f *lineFilter) takeBlankLine(line int) (blank int) {
+	taken := 0
+	for taken < len(f.blankLines) && f.blankLines[taken] < line {
+		taken++
+	}
+
+	if taken > 0 {
+		blank = f.blankLines[0]
+		f.blankLines = f.blankLines[taken:]
+	}
+
+	return blank
+}
+
+// lineFilterReader reads records through a line filter, and applies the blank line policy to the blank lines the filter notes between records.
+type lineFilterReader struct {
+	reader        filteredRecordReader
+	filter        *lineFilter
+	policy        BlankLinePolicy
+	started       bool
+	pending       []string
+	hasPending    bool
+	pendingOffset int64
+}
+
+func (r *lineFilterReader) Read() (record []string, err error) {
+	if r.hasPending {
+		r.hasPending = false
+		return r.pending, nil
+	}
+
+	offset := r.reader.InputOffset()
+	record, err = r.reader.Read()
+	if err != nil {
+		return record, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	blank := r.filter.takeBlankLine(line)
+
+	// Blank lines before the first record don't end a section, since none has started.
+	if blank == 0 || !r.started || r.policy == BlankLineSkip {
+		r.started = true
+		return record, nil
+	}
+
+	r.pending = record
+	r.hasPending = true
+	r.pendingOffset = offset
+
+	if r.policy == BlankLineEndSection {
+		return nil, ErrorEndOfSection
+	}
+
+	return nil, UnexpectedBlankLineError{
+		Line: blank,
+		Err:  ErrorBlankLine,
+	}
+}
+
+// InputOffset returns the offset in the original file of the start of the next record, so that checkpoints and seeking work on filtered files.
+func (r *lineFilterReader) InputOffset() int64 {
+	if r.hasPending {
+		return r.filter.fileOffset(r.pendingOffset)
+	}
+	return r.filter.fileOffset(r.reader.InputOffset())
+}
+
+// validCommentPrefix reports whether prefix can be used to start comments with the delimiter.
+func validCommentPrefix(prefix string, delimiter rune) bool {
+	return !strings.ContainsAny(prefix, "\"\r\n") && !strings.ContainsRune(prefix, delimiter)
+}
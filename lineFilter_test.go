@@ -0,0 +1,153 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type lineFilterTest struct {
+	Name  string `csv:"index:0"`
+	Notes string `csv:"index:1"`
+}
+
+func readLineFilterTest(p *Parser) (records []lineFilterTest, errs []error) {
+	for {
+		var record lineFilterTest
+		err := p.ReadRecord(&record)
+		if err == io.EOF {
+			return records, errs
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		records = append(records, record)
+	}
+}
+
+func TestCommentPrefix(t *testing.T) {
+	data := "// generated report\nWidget,\"a // not a comment\"\n// totals follow\nGadget,b\n"
+	p := NewParser(strings.NewReader(data), ParserOptions{CommentPrefix: "//"})
+
+	records, errs := readLineFilterTest(&p)
+	expected := []lineFilterTest{{Name: "Widget", Notes: "a // not a comment"}, {Name: "Gadget", Notes: "b"}}
+	if len(errs) != 0 || len(records) != 2 || records[0] != expected[0] || records[1] != expected[1] {
+		t.Errorf("improperly read records with comment prefix. Got '%+v' and errors %v", records, errs)
+	}
+}
+
+func TestInlineComments(t *testing.T) {
+	data := "Widget,blue   # the usual\nGadget,\"#1 \"\"seller\"\"\"  #best\n# whole line\nGizmo,red\n"
+	p := NewParser(strings.NewReader(data), ParserOptions{CommentChar: '#', InlineComments: true})
+
+	records, errs := readLineFilterTest(&p)
+	expected := []lineFilterTest{{Name: "Widget", Notes: "blue"}, {Name: "Gadget", Notes: "#1 \"seller\""}, {Name: "Gizmo", Notes: "red"}}
+	if len(errs) != 0 || len(records) != 3 {
+		t.Errorf("improperly read records with inline comments. Got '%+v' and errors %v", records, errs)
+		return
+	}
+	for i := range expected {
+		if records[i] != expected[i] {
+			t.Errorf("improperly read record %d with inline comments. Got '%+v' but expected '%+v'", i, records[i], expected[i])
+		}
+	}
+}
+
+func TestBlankLineError(t *testing.T) {
+	data := "\nWidget,\"a\n\nb\"\n\n\nGadget,c\n\n"
+	p := NewParser(strings.NewReader(data), ParserOptions{BlankLines: BlankLineError})
+
+	records, errs := readLineFilterTest(&p)
+	if len(records) != 2 || records[0].Notes != "a\n\nb" || records[1].Name != "Gadget" {
+		t.Errorf("improperly read records around blank lines. Got '%+v'", records)
+	}
+
+	var blankErr UnexpectedBlankLineError
+	if len(errs) != 1 || !errors.Is(errs[0], ErrorBlankLine) || !errors.As(errs[0], &blankErr) || blankErr.Line != 5 {
+		t.Errorf("expected one ErrorBlankLine error on line 5, but got %v", errs)
+	}
+
+	p = NewParser(strings.NewReader(data), ParserOptions{BlankLines: BlankLineError})
+	var record lineFilterTest
+	for i := 0; i < 3; i++ {
+		p.ReadRecord(&record)
+	}
+	if record.Name != "Gadget" || p.Line() != 2 {
+		t.Errorf("expected blank lines not to be counted as records, but %s was read as line %d", record.Name, p.Line())
+	}
+}
+
+func TestBlankLineEndSection(t *testing.T) {
+	data := "Widget,a\nGadget,b\n\nTotal,2\n"
+	p := NewParser(strings.NewReader(data), ParserOptions{BlankLines: BlankLineEndSection})
+
+	var record lineFilterTest
+	for i := 0; i < 2; i++ {
+		err := p.ReadRecord(&record)
+		if err != nil {
+			t.Errorf("encountered error reading first section: %v", err)
+		}
+	}
+
+	offset, err := p.ByteOffset()
+	if err != nil || offset != 18 {
+		t.Errorf("expected the next record to start at offset 18, but got %d and error %v", offset, err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != ErrorEndOfSection {
+		t.Errorf("expected to encounter ErrorEndOfSection error, but got %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != nil || record.Name != "Total" {
+		t.Errorf("expected to read the next section after the end of the first. Got '%+v' and error %v", record, err)
+	}
+}
+
+func TestCommentByteOffset(t *testing.T) {
+	data := "Widget,a # first\n// note\nGadget,b\n"
+	p := NewParser(strings.NewReader(data), ParserOptions{CommentPrefix: "//"})
+
+	var record lineFilterTest
+	err := p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading record: %v", err)
+	}
+
+	offset, err := p.ByteOffset()
+	if err != nil || offset != 17 {
+		t.Errorf("expected the offset to count the comments of the file, but got %d and error %v", offset, err)
+	}
+
+	data = "Widget,a # first\nGadget,b # second\n"
+	p = NewParser(strings.NewReader(data), ParserOptions{CommentPrefix: "#", InlineComments: true})
+	p.ReadRecord(&record)
+	p.ReadRecord(&record)
+	offset, err = p.ByteOffset()
+	if err != nil || offset != int64(len(data)) {
+		t.Errorf("expected the offset to count removed inline comments, but got %d and error %v", offset, err)
+	}
+}
+
+func TestCommentOptionsValidate(t *testing.T) {
+	tests := []struct {
+		options  ParserOptions
+		expected error
+	}{
+		{options: ParserOptions{CommentPrefix: "REM "}},
+		{options: ParserOptions{CommentPrefix: "//", CommentChar: '#'}, expected: ErrorCommentPrefixAndChar},
+		{options: ParserOptions{CommentPrefix: "#,"}, expected: ErrorInvalidCommentPrefix},
+		{options: ParserOptions{InlineComments: true}, expected: ErrorInlineCommentsPrefix},
+		{options: ParserOptions{BlankLines: BlankLinePolicy(3)}, expected: ErrorInvalidBlankLinePolicy},
+	}
+
+	for _, test := range tests {
+		err := test.options.Validate()
+		if !errors.Is(err, test.expected) {
+			t.Errorf("expected options '%+v' to be reported as %v, but got %v", test.options, test.expected, err)
+		}
+	}
+}
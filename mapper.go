@@ -0,0 +1,183 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorColumnOutOfRange = fmt.Errorf("record has no value at the bound column index")
+)
+
+// Mapper binds csv columns to a type with functions rather than struct tags. Use it when the type can't be modified,
+// such as generated code or types from another package, or when the column mapping is only known at runtime.
+type Mapper[T any] struct {
+	bindings []mapperBinding[T]
+}
+
+type mapperBinding[T any] struct {
+	headerName  string
+	hasHeader   bool
+	columnIndex int
+	set         func(record *T, value string) (err error)
+}
+
+// NewMapper creates an empty mapper for T. Add column bindings with Bind and BindIndex.
+func NewMapper[T any]() (m *Mapper[T]) {
+	return &Mapper[T]{}
+}
+
+// Bind maps the column with the given header label to set. The column is resolved when the header is parsed.
+func (m *Mapper[T]) Bind(headerName string, set func(record *T, value string) (err error)) *Mapper[T] {
+	m.bindings = append(m.bindings, mapperBinding[T]{
+		headerName: headerName,
+		hasHeader:  true,
+		set:        set,
+	})
+
+	return m
+}
+
+// BindIndex maps the zero-indexed column to set.
+func (m *Mapper[T]) BindIndex(columnIndex int, set func(record *T, value string) (err error)) *Mapper[T] {
+	m.bindings = append(m.bindings, mapperBinding[T]{
+		columnIndex: columnIndex,
+		set:         set,
+	})
+
+	return m
+}
+
+// MappedParser reads csv records into values of T using the bindings of a Mapper.
+type MappedParser[T any] struct {
+	parser       Parser
+	mapper       *Mapper[T]
+	columns      []int
+	headerParsed bool
+	needsHeader  bool
+}
+
+// NewParser creates a new csv parser for the provided file that sets values using the mapper's bindings.
+// Use ParserOptions to specify any desired changed from the default behavior as defined in the standard csv parser library.
+func (m *Mapper[T]) NewParser(file io.Reader, options ParserOptions) (mp MappedParser[T]) {
+	mp.parser = NewParser(file, options)
+	mp.mapper = m
+	mp.columns = make([]int, len(m.bindings))
+
+	for i, binding := range m.bindings {
+		mp.columns[i] = binding.columnIndex
+		if binding.hasHeader {
+			mp.needsHeader = true
+		}
+	}
+
+	return mp
+}
+
+// ParseHeader reads the first line of the parser's csv file and resolves the columns of the mapper's header bindings.
+// If the mapper has header bindings and ParseHeader has not been called, the first call to ReadRecord will call it.
+func (mp *MappedParser[T]) ParseHeader() (err error) {
+	header, err := mp.parser.readHeader()
+	if err != nil {
+		return err
+	}
+
+	mp.headerParsed = true
+
+	for i, binding := range mp.mapper.bindings {
+		if !binding.hasHeader {
+			continue
+		}
+
+		idx, foundIdx := findHeaderIndex(header, binding.headerName)
+		if !foundIdx {
+			return FieldNotFoundError{
+				FieldName:  binding.headerName,
+				HeaderName: binding.headerName,
+				Err:        ErrorFieldNotFound,
+			}
+		}
+
+		mp.columns[i] = idx
+	}
+
+	return nil
+}
+
+// ReadRecord reads the next line of the parser's csv file and calls the mapper's bindings to set the data on record.
+func (mp *MappedParser[T]) ReadRecord(record *T) (err error) {
+	if mp.needsHeader && !mp.headerParsed {
+		err = mp.ParseHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	readRecord, err := mp.parser.readNextRecord()
+	if err != nil {
+		return err
+	}
+
+	if mp.parser.options.StrictColumns {
+		boundColumns := 0
+		for _, idx := range mp.columns {
+			if idx >= boundColumns {
+				boundColumns = idx + 1
+			}
+		}
+
+		err = mp.parser.checkExtraColumns(readRecord, boundColumns)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, binding := range mp.mapper.bindings {
+		fieldName := binding.headerName
+		if !binding.hasHeader {
+			fieldName = fmt.Sprintf("column %d", binding.columnIndex)
+		}
+
+		idx := mp.columns[i]
+		if idx < 0 || idx >= len(readRecord) {
+			return SetValueError{
+				Line:      mp.parser.line,
+				FieldName: fieldName,
+				Err:       ErrorColumnOutOfRange,
+			}
+		}
+
+		value := readRecord[idx]
+		err := binding.set(record, value)
+		if err != nil {
+			return SetValueError{
+				Line:      mp.parser.line,
+				Value:     value,
+				FieldName: fieldName,
+				Err:       err,
+			}
+		}
+	}
+
+	if hook, ok := interface{}(record).(AfterCsvRecordHook); ok {
+		err = hook.AfterCsvRecord(mp.parser.line)
+		if err != nil {
+			return RecordError{
+				Line: mp.parser.line,
+				Err:  err,
+			}
+		}
+	}
+
+	if mp.parser.options.Validator != nil {
+		err = mp.parser.options.Validator(record, mp.parser.line)
+		if err != nil {
+			return RecordError{
+				Line: mp.parser.line,
+				Err:  err,
+			}
+		}
+	}
+
+	return nil
+}
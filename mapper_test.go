@@ -0,0 +1,80 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// order stands in for a type that can't be given csv tags.
+type order struct {
+	ID       string
+	Quantity int
+	Note     string
+}
+
+func newOrderMapper() *Mapper[order] {
+	return NewMapper[order]().
+		Bind("order_id", func(o *order, value string) (err error) {
+			o.ID = value
+			return nil
+		}).
+		Bind("qty", func(o *order, value string) (err error) {
+			o.Quantity, err = strconv.Atoi(value)
+			return err
+		}).
+		BindIndex(3, func(o *order, value string) (err error) {
+			o.Note = value
+			return nil
+		})
+}
+
+func TestMapper(t *testing.T) {
+	mp := newOrderMapper().NewParser(strings.NewReader("qty,order_id,ignored,note\n2,A-1,x,first\n5,A-2,y,second"), ParserOptions{})
+
+	expected := []order{
+		{ID: "A-1", Quantity: 2, Note: "first"},
+		{ID: "A-2", Quantity: 5, Note: "second"},
+	}
+
+	for i := 0; true; i++ {
+		data := order{}
+		err := mp.ReadRecord(&data)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Errorf("encountered error parsing csv with mapper: %v", err)
+			break
+		}
+
+		if data != expected[i] {
+			t.Errorf("improperly parsed data with mapper. Got '%v' but expected '%v'", data, expected[i])
+		}
+	}
+}
+
+func TestMapperSetValueError(t *testing.T) {
+	mp := newOrderMapper().NewParser(strings.NewReader("qty,order_id,ignored,note\nmany,A-1,x,first"), ParserOptions{})
+
+	err := mp.ReadRecord(&order{})
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) {
+		t.Errorf("expected to encounter a SetValueError, but got %v", err)
+	}
+	if setValueErr.FieldName != "qty" || setValueErr.Line != 1 {
+		t.Errorf("expected a SetValueError for qty on line 1, but got %v", err)
+	}
+}
+
+func TestMapperFieldNotFoundError(t *testing.T) {
+	mp := newOrderMapper().NewParser(strings.NewReader("quantity,order_id,ignored,note\n2,A-1,x,first"), ParserOptions{})
+
+	err := mp.ParseHeader()
+	if !errors.Is(err, ErrorFieldNotFound) {
+		t.Errorf("expected to encounter Field Not Found error, but got %v", err)
+	}
+}
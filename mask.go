@@ -0,0 +1,83 @@
+package csv
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var (
+	ErrorInvalidMask  = fmt.Errorf("mask must name a masker")
+	ErrorUnknownMask  = fmt.Errorf("mask doesn't name a built in or registered masker")
+	maskersMutex      sync.RWMutex
+	registeredMaskers = map[string]Masker{}
+)
+
+// redactedValue replaces values masked with the redact masker.
+const redactedValue = "REDACTED"
+
+// Masker replaces a sensitive value, such as a social security number, with a masked form that is safe to share. It is applied to values named by the mask attribute of a field,
+// such as csv:"header:ssn;mask:last4", and isn't called for empty values.
+type Masker func(value string) (masked string)
+
+// builtinMaskers are the maskers that can be named by the mask attribute without being registered.
+var builtinMaskers = map[string]Masker{
+	// last4 keeps the last four characters, replacing the rest with asterisks, so that 123-45-6789 is masked as *******6789.
+	"last4": func(value string) string {
+		count := utf8.RuneCountInString(value)
+		if count <= 4 {
+			return value
+		}
+
+		runes := []rune(value)
+		return strings.Repeat("*", count-4) + string(runes[count-4:])
+	},
+	// redact replaces the value with REDACTED.
+	"redact": func(value string) string {
+		return redactedValue
+	},
+	// hash replaces the value with its hex encoded SHA-256 hash, so that masked values can still be joined or counted.
+	"hash": func(value string) string {
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:])
+	},
+}
+
+// RegisterMasker makes masker available to the mask attribute of every parser and writer in the process under name, replacing any masker registered with the same name,
+// and taking precedence over a built in masker. Maskers should be registered before any parser or writer binds a struct using them, as bindings are cached.
+func RegisterMasker(name string, masker Masker) {
+	maskersMutex.Lock()
+	defer maskersMutex.Unlock()
+
+	registeredMaskers[name] = masker
+}
+
+// lookupMasker finds the masker named name, preferring the overrides passed in over the registered maskers, and those over the built in maskers.
+func lookupMasker(name string, overrides map[string]Masker) (masker Masker, ok bool) {
+	masker, ok = overrides[name]
+	if ok {
+		return masker, ok
+	}
+
+	maskersMutex.RLock()
+	masker, ok = registeredMaskers[name]
+	maskersMutex.RUnlock()
+	if ok {
+		return masker, ok
+	}
+
+	masker, ok = builtinMaskers[name]
+	return masker, ok
+}
+
+// applyMask masks value with the masker of attrs, if it has one. Empty values are left empty.
+func (attrs csvAttributes) applyMask(value string) string {
+	if attrs.masker == nil || value == "" {
+		return value
+	}
+
+	return attrs.masker(value)
+}
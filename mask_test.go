@@ -0,0 +1,96 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type maskTest struct {
+	Name  string `csv:"header:name"`
+	SSN   string `csv:"header:ssn;mask:last4"`
+	Email string `csv:"header:email;mask:hash"`
+	Notes string `csv:"header:notes;mask:redact"`
+}
+
+func TestMaskOnWrite(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+
+	err := w.WriteRecord(&maskTest{Name: "Ann", SSN: "123-45-6789", Email: "ann@example.com"})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing csv writer: %v", err)
+	}
+
+	expected := "Ann,*******6789," + builtinMaskers["hash"]("ann@example.com") + ",\n"
+	if buf.String() != expected {
+		t.Errorf("improperly masked record. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+func TestMaskOnRead(t *testing.T) {
+	file := "name,ssn,email,notes\nAnn,123-45-6789,ann@example.com,private\n"
+
+	for _, maskOnRead := range []bool{false, true} {
+		p := NewParser(strings.NewReader(file), ParserOptions{MaskOnRead: maskOnRead})
+
+		var record maskTest
+		err := p.ParseHeader(&record)
+		if err != nil {
+			t.Fatalf("encountered error parsing csv header: %v", err)
+		}
+
+		err = p.ReadRecord(&record)
+		if err != nil {
+			t.Fatalf("encountered error reading csv record: %v", err)
+		}
+
+		expected := maskTest{Name: "Ann", SSN: "123-45-6789", Email: "ann@example.com", Notes: "private"}
+		if maskOnRead {
+			expected = maskTest{Name: "Ann", SSN: "*******6789", Email: builtinMaskers["hash"]("ann@example.com"), Notes: "REDACTED"}
+		}
+		if record != expected {
+			t.Errorf("improperly read record with MaskOnRead %v. Got %+v but expected %+v", maskOnRead, record, expected)
+		}
+	}
+}
+
+type customMaskTest struct {
+	Card string `csv:"index:0;mask:initials"`
+}
+
+func TestCustomMasker(t *testing.T) {
+	initials := func(value string) string { return value[:1] + "." }
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{Maskers: map[string]Masker{"initials": initials}})
+
+	err := w.WriteRecord(&customMaskTest{Card: "Smith"})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+	_ = w.Flush()
+
+	if buf.String() != "S.\n" {
+		t.Errorf("expected the writer's masker to be used, but got '%s'", buf.String())
+	}
+
+	w = NewWriter(&bytes.Buffer{}, WriterOptions{})
+	err = w.WriteRecord(&customMaskTest{Card: "Smith"})
+	if !errors.Is(err, ErrorUnknownMask) {
+		t.Errorf("expected %v for a masker that isn't registered, but got %v", ErrorUnknownMask, err)
+	}
+}
+
+func TestInvalidMask(t *testing.T) {
+	_, err := ParseTag("header:ssn;mask")
+	if !errors.Is(err, ErrorInvalidMask) {
+		t.Errorf("expected %v for a mask without a masker, but got %v", ErrorInvalidMask, err)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package csv
+
+import "os"
+
+// mmapFile doesn't map files on this platform, so NewFileParser reads them as usual.
+func mmapFile(file *os.File) (data []byte, err error) {
+	return nil, nil
+}
+
+func munmapFile(data []byte) (err error) {
+	return nil
+}
@@ -0,0 +1,27 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package csv
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps the whole of file into memory for reading. It returns nil without an error when there is nothing to map, or file isn't a regular file.
+func mmapFile(file *os.File) (data []byte, err error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := info.Size()
+	if !info.Mode().IsRegular() || size <= 0 || int64(int(size)) != size {
+		return nil, nil
+	}
+
+	return syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+func munmapFile(data []byte) (err error) {
+	return syscall.Munmap(data)
+}
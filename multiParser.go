@@ -0,0 +1,120 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorHeaderMismatch = fmt.Errorf("header does not match the header of the first part")
+)
+
+// headerReader may be implemented by a record reader that reads headers differently from records.
+type headerReader interface {
+	readHeader() (header []string, err error)
+}
+
+// multiReader reads the records of several csv files, one after the other.
+type multiReader struct {
+	readers []RecordReader
+	current int
+	header  []string
+	started bool
+}
+
+// NewMultiParser creates a new parser that reads the records of every reader in turn as a single file, such as the daily part files of a sharded export.
+// If ParseHeader is called, the header is parsed from the first part, and the first line of every later part is read as its header and checked to match, or a HeaderMismatchError is returned.
+// If it isn't, the parts are simply read one after the other. Line numbers continue from one part to the next.
+func NewMultiParser(readers []io.Reader, options ParserOptions) (p Parser) {
+	mr := &multiReader{}
+	for _, reader := range readers {
+		mr.readers = append(mr.readers, newParserReader(reader, options))
+	}
+
+	p = NewRecordParser(mr, options)
+
+	err := options.Validate()
+	if err != nil {
+		p.reader = errorReader{err: err}
+	}
+
+	return p
+}
+
+func (mr *multiReader) readHeader() (header []string, err error) {
+	if mr.started || len(mr.readers) == 0 {
+		return mr.Read()
+	}
+
+	mr.started = true
+	header, err = mr.readers[0].Read()
+	if err != nil {
+		return header, err
+	}
+
+	mr.header = append([]string(nil), header...)
+
+	return header, nil
+}
+
+func (mr *multiReader) Read() (record []string, err error) {
+	mr.started = true
+
+	for mr.current < len(mr.readers) {
+		record, err = mr.readers[mr.current].Read()
+		if err != io.EOF {
+			return record, err
+		}
+
+		mr.current++
+		if mr.header == nil || mr.current == len(mr.readers) {
+			continue
+		}
+
+		err = mr.checkHeader()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, io.EOF
+}
+
+// checkHeader reads the first line of the current part, and checks that it matches the header of the first part. An empty part has no header to check.
+func (mr *multiReader) checkHeader() (err error) {
+	header, err := mr.readers[mr.current].Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(header) == len(mr.header) {
+		matches := true
+		for i := range header {
+			matches = matches && header[i] == mr.header[i]
+		}
+		if matches {
+			return nil
+		}
+	}
+
+	return HeaderMismatchError{
+		Part:   mr.current,
+		Header: append([]string(nil), header...),
+		Err:    ErrorHeaderMismatch,
+	}
+}
+
+type HeaderMismatchError struct {
+	Part   int
+	Header []string
+	Err    error
+}
+
+func (e HeaderMismatchError) Error() string {
+	return fmt.Sprintf("part %d: header %v: %v", e.Part, e.Header, e.Err)
+}
+
+func (e HeaderMismatchError) Unwrap() error { return e.Err }
@@ -0,0 +1,94 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMultiParser(t *testing.T) {
+	parts := []io.Reader{
+		strings.NewReader("region,id,price\nEU,1,10\nUS,1,20\n"),
+		strings.NewReader(""),
+		strings.NewReader("region,id,price\nEU,2,30\n"),
+		strings.NewReader("region,id,price\n"),
+		strings.NewReader("region,id,price\nUS,2,40"),
+	}
+
+	p := NewMultiParser(parts, ParserOptions{})
+	err := p.ParseHeader(&dedupeTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	var records []dedupeTest
+	for {
+		var record dedupeTest
+		err := p.ReadRecord(&record)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Errorf("encountered error parsing csv parts: %v", err)
+			break
+		}
+		records = append(records, record)
+	}
+
+	expected := []dedupeTest{{"EU", 1, 10}, {"US", 1, 20}, {"EU", 2, 30}, {"US", 2, 40}}
+	if len(records) != len(expected) {
+		t.Errorf("expected records %+v, but got %+v", expected, records)
+		return
+	}
+	for i := range expected {
+		if records[i] != expected[i] {
+			t.Errorf("expected record %+v, but got %+v", expected[i], records[i])
+		}
+	}
+
+	if p.Line() != 5 {
+		t.Errorf("expected line numbers to continue across parts, but the last line was %d", p.Line())
+	}
+}
+
+func TestMultiParserWithoutHeader(t *testing.T) {
+	parts := []io.Reader{
+		strings.NewReader("a,1\n"),
+		strings.NewReader("b,2\n"),
+	}
+
+	p := NewMultiParser(parts, ParserOptions{})
+	var first, second peekTest
+	err := p.ReadRecord(&first)
+	if err == nil {
+		err = p.ReadRecord(&second)
+	}
+	if err != nil || first.Section != "a" || second.Section != "b" {
+		t.Errorf("expected records from both parts, but got %+v, %+v and error %v", first, second, err)
+	}
+}
+
+func TestMultiParserHeaderMismatch(t *testing.T) {
+	parts := []io.Reader{
+		strings.NewReader("region,id,price\nEU,1,10\n"),
+		strings.NewReader("region,price,id\nEU,30,2\n"),
+	}
+
+	p := NewMultiParser(parts, ParserOptions{})
+	err := p.ParseHeader(&dedupeTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.ReadRecord(&dedupeTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv parts: %v", err)
+	}
+
+	err = p.ReadRecord(&dedupeTest{})
+	var mismatchErr HeaderMismatchError
+	if !errors.As(err, &mismatchErr) || !errors.Is(err, ErrorHeaderMismatch) || mismatchErr.Part != 1 {
+		t.Errorf("expected to encounter ErrorHeaderMismatch error for part 1, but got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+var (
+	ErrorUnknownRecordType    = fmt.Errorf("record type is not registered")
+	ErrorRecordTypeRegistered = fmt.Errorf("record type is already registered")
+	ErrorRecordTypeHeader     = fmt.Errorf("fields of registered record types must be bound by index")
+	ErrorMissingRecordType    = fmt.Errorf("record has no value in the record type column")
+)
+
+// MultiTypeParser reads files whose lines hold different kinds of records, such as the header, detail and trailer records of a bank or EDI file,
+// where the first column of each line says which kind of record it is. Each kind is registered with the struct type its lines are read into.
+type MultiTypeParser struct {
+	parser Parser
+	types  map[string]*recordType
+}
+
+// recordType is a registered kind of record, with its own parser binding.
+type recordType struct {
+	structType reflect.Type
+	parser     Parser
+}
+
+// NewMultiTypeParser creates a new parser for the provided file, whose records are read into the struct type registered for the value of their first column.
+// Lines may have different numbers of columns. Use ParserOptions to specify any desired changed from the default behavior as defined in the standard csv parser library.
+func NewMultiTypeParser(file io.Reader, options ParserOptions) (mp *MultiTypeParser) {
+	mp = &MultiTypeParser{
+		parser: NewParser(file, options),
+		types:  make(map[string]*recordType),
+	}
+
+	allowRaggedRecords(mp.parser.reader)
+
+	return mp
+}
+
+// Register binds lines whose first column is typeValue to the type of structPointer, such as Register("D", &Detail{}). Records of the type are read into new values of it.
+// Fields must be bound by index, since files like these have no header, and the first column may be bound like any other to keep the record type.
+func (mp *MultiTypeParser) Register(typeValue string, structPointer interface{}) (err error) {
+	if _, ok := mp.types[typeValue]; ok {
+		return RecordTypeError{
+			RecordType: typeValue,
+			Err:        ErrorRecordTypeRegistered,
+		}
+	}
+
+	registered := &recordType{
+		structType: reflect.TypeOf(structPointer),
+		parser:     NewRecordParser(errorReader{err: io.EOF}, mp.parser.options),
+	}
+
+	err = registered.parser.bind(structPointer)
+	if err != nil {
+		return err
+	}
+
+	if registered.parser.needsHeader {
+		return RecordTypeError{
+			RecordType: typeValue,
+			Err:        ErrorRecordTypeHeader,
+		}
+	}
+
+	mp.types[typeValue] = registered
+
+	return nil
+}
+
+// ReadRecord reads the next line of the parser's csv file into a new value of the struct type registered for its first column,
+// and returns the record type along with a pointer to the value. Lines of a type that isn't registered are reported as a RecordTypeError.
+func (mp *MultiTypeParser) ReadRecord() (typeValue string, record interface{}, err error) {
+	readRecord, err := mp.parser.readFilteredRecord()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(readRecord) == 0 || (len(readRecord) == 1 && readRecord[0] == "") {
+		return "", nil, RecordTypeError{
+			Line: mp.parser.line,
+			Err:  ErrorMissingRecordType,
+		}
+	}
+
+	typeValue = readRecord[0]
+	registered, ok := mp.types[typeValue]
+	if !ok {
+		return typeValue, nil, RecordTypeError{
+			Line:       mp.parser.line,
+			RecordType: typeValue,
+			Err:        ErrorUnknownRecordType,
+		}
+	}
+
+	for _, fieldName := range registered.parser.fieldOrder {
+		if registered.parser.csvAttrs[fieldName].columnIndex >= len(readRecord) {
+			return typeValue, nil, SetValueError{
+				Line:      mp.parser.line,
+				FieldName: fieldName,
+				Err:       ErrorColumnOutOfRange,
+			}
+		}
+	}
+
+	record = reflect.New(registered.structType.Elem()).Interface()
+	registered.parser.line = mp.parser.line
+	err = registered.parser.setRecord(record, readRecord)
+
+	return typeValue, record, err
+}
+
+// Parse reads every remaining line of the parser's csv file, and calls handle with the record type and a pointer to the record read from each.
+// It stops at the first error reading a line or returned by handle, and returns it, or nil once the file has been read.
+func (mp *MultiTypeParser) Parse(handle func(typeValue string, record interface{}) error) (err error) {
+	for {
+		typeValue, record, err := mp.ReadRecord()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = handle(typeValue, record)
+		if err != nil {
+			return RecordError{
+				Line: mp.parser.line,
+				Err:  err,
+			}
+		}
+	}
+}
+
+// Line returns the line number of the most recently read record, as Parser.Line does.
+func (mp *MultiTypeParser) Line() int {
+	return mp.parser.line
+}
+
+type RecordTypeError struct {
+	Line       int
+	RecordType string
+	Err        error
+}
+
+func (e RecordTypeError) Error() string {
+	return fmt.Sprintf("record on line %d: problem with record type %s: %v", e.Line, e.RecordType, e.Err)
+}
+
+func (e RecordTypeError) Unwrap() error { return e.Err }
@@ -0,0 +1,101 @@
+package csv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type multiTypeHeader struct {
+	BatchID string `csv:"index:1"`
+	Date    string `csv:"index:2"`
+}
+
+type multiTypeDetail struct {
+	Account string  `csv:"index:1"`
+	Amount  float64 `csv:"index:2"`
+	Memo    string  `csv:"index:3"`
+}
+
+type multiTypeTrailer struct {
+	Type  string `csv:"index:0"`
+	Count int    `csv:"index:1"`
+}
+
+func newMultiTypeTest(data string) (mp *MultiTypeParser) {
+	mp = NewMultiTypeParser(strings.NewReader(data), ParserOptions{})
+	mp.Register("H", &multiTypeHeader{})
+	mp.Register("D", &multiTypeDetail{})
+	mp.Register("T", &multiTypeTrailer{})
+
+	return mp
+}
+
+func TestMultiTypeParser(t *testing.T) {
+	mp := newMultiTypeTest("H,B1,2024-01-31\nD,1001,9.5,rent\nD,1002,3,\nT,2\n")
+
+	var details []multiTypeDetail
+	var trailer *multiTypeTrailer
+	err := mp.Parse(func(typeValue string, record interface{}) error {
+		switch r := record.(type) {
+		case *multiTypeHeader:
+			if typeValue != "H" || r.BatchID != "B1" {
+				t.Errorf("improperly read header record. Got '%+v'", r)
+			}
+		case *multiTypeDetail:
+			details = append(details, *r)
+		case *multiTypeTrailer:
+			trailer = r
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("encountered error parsing multi type file: %v", err)
+	}
+
+	if len(details) != 2 || details[0] != (multiTypeDetail{Account: "1001", Amount: 9.5, Memo: "rent"}) || details[1].Account != "1002" {
+		t.Errorf("improperly read detail records. Got '%+v'", details)
+	}
+
+	if trailer == nil || *trailer != (multiTypeTrailer{Type: "T", Count: 2}) {
+		t.Errorf("improperly read trailer record. Got '%+v'", trailer)
+	}
+}
+
+func TestMultiTypeParserErrors(t *testing.T) {
+	mp := newMultiTypeTest("X,1\nD,1001\nD,1001,abc,x\n")
+
+	var typeErr RecordTypeError
+	_, _, err := mp.ReadRecord()
+	if !errors.Is(err, ErrorUnknownRecordType) || !errors.As(err, &typeErr) || typeErr.RecordType != "X" || typeErr.Line != 1 {
+		t.Errorf("expected to encounter ErrorUnknownRecordType error for type X, but got %v", err)
+	}
+
+	_, _, err = mp.ReadRecord()
+	if !errors.Is(err, ErrorColumnOutOfRange) {
+		t.Errorf("expected to encounter ErrorColumnOutOfRange error for a short record, but got %v", err)
+	}
+
+	var setErr SetValueError
+	_, _, err = mp.ReadRecord()
+	if !errors.As(err, &setErr) || setErr.Line != 3 || setErr.FieldName != "Amount" {
+		t.Errorf("expected a SetValueError for field Amount on line 3, but got %v", err)
+	}
+
+	err = mp.Register("D", &multiTypeDetail{})
+	if !errors.Is(err, ErrorRecordTypeRegistered) {
+		t.Errorf("expected to encounter ErrorRecordTypeRegistered error, but got %v", err)
+	}
+
+	err = mp.Register("S", &sharedBinding{})
+	if !errors.Is(err, ErrorRecordTypeHeader) {
+		t.Errorf("expected to encounter ErrorRecordTypeHeader error, but got %v", err)
+	}
+
+	handlerErr := errors.New("stop")
+	mp = newMultiTypeTest("T,0\n")
+	err = mp.Parse(func(typeValue string, record interface{}) error { return handlerErr })
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("expected the handler error to be returned, but got %v", err)
+	}
+}
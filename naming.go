@@ -0,0 +1,146 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrorInvalidNamingStrategy = fmt.Errorf("naming strategy must be NameFieldName, NameSnakeCase, NameCamelCase or NameScreamingSnakeCase")
+	ErrorInvalidHeaderCase     = fmt.Errorf("header case must be HeaderCaseUnchanged, HeaderCaseTitle, HeaderCaseUpper or HeaderCaseLower")
+)
+
+// FieldNamer derives the header name of a field, for fields tagged with a header attribute without a value, and for untagged fields when DeriveHeaders is set.
+// Implement it to apply an organization's own naming conventions to every struct, such as prefixes or a table of abbreviations, in place of a NamingStrategy.
+// Returning an empty name leaves a field that is bound only by its derived header unbound.
+type FieldNamer interface {
+	HeaderFor(field reflect.StructField) string
+}
+
+// NamingStrategy decides how a header name is derived from a field name, for fields tagged with a header attribute without a value, such as csv:"header",
+// and for untagged fields when DeriveHeaders is set.
+type NamingStrategy int
+
+const (
+	// NameFieldName uses the field name as it is, such as UnitPrice.
+	NameFieldName NamingStrategy = iota
+	// NameSnakeCase joins the words of the field name in lower case with underscores, such as unit_price.
+	NameSnakeCase
+	// NameCamelCase joins the words of the field name with the first in lower case and the rest capitalized, such as unitPrice.
+	NameCamelCase
+	// NameScreamingSnakeCase joins the words of the field name in upper case with underscores, such as UNIT_PRICE.
+	NameScreamingSnakeCase
+)
+
+// HeaderFor derives the header name of field from its field name. It makes a NamingStrategy a FieldNamer, so that a FieldNamer can build on one.
+func (s NamingStrategy) HeaderFor(field reflect.StructField) string {
+	return s.name(field.Name)
+}
+
+// name derives a header name from fieldName.
+func (s NamingStrategy) name(fieldName string) string {
+	if s == NameFieldName {
+		return fieldName
+	}
+
+	words := splitWords(fieldName)
+	switch s {
+	case NameSnakeCase:
+		return strings.ToLower(strings.Join(words, "_"))
+	case NameScreamingSnakeCase:
+		return strings.ToUpper(strings.Join(words, "_"))
+	case NameCamelCase:
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i > 0 {
+				word = capitalize(word)
+			}
+			words[i] = word
+		}
+		return strings.Join(words, "")
+	}
+
+	return fieldName
+}
+
+// HeaderCase transforms the case of the header names a Writer writes, such as to make the headers of a report readable.
+type HeaderCase int
+
+const (
+	// HeaderCaseUnchanged writes header names as they are.
+	HeaderCaseUnchanged HeaderCase = iota
+	// HeaderCaseTitle writes the words of header names capitalized and separated by spaces, keeping initialisms in upper case, so that unit_price is written as Unit Price and HTTPServerID as HTTP Server ID.
+	HeaderCaseTitle
+	// HeaderCaseUpper writes header names in upper case.
+	HeaderCaseUpper
+	// HeaderCaseLower writes header names in lower case.
+	HeaderCaseLower
+)
+
+// apply transforms the case of header name.
+func (c HeaderCase) apply(name string) string {
+	switch c {
+	case HeaderCaseTitle:
+		words := splitWords(name)
+		for i, word := range words {
+			if strings.ToUpper(word) != word || len([]rune(word)) == 1 {
+				word = capitalize(strings.ToLower(word))
+			}
+			words[i] = word
+		}
+		return strings.Join(words, " ")
+	case HeaderCaseUpper:
+		return strings.ToUpper(name)
+	case HeaderCaseLower:
+		return strings.ToLower(name)
+	}
+
+	return name
+}
+
+// capitalize returns word with its first letter in upper case.
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// splitWords splits a Go identifier into words where its case changes, keeping initialisms together, so that HTTPServerID is split into HTTP, Server and ID.
+// Digits stay with the word before them, and underscores, hyphens and spaces separate words.
+func splitWords(name string) (words []string) {
+	runes := []rune(name)
+	start := 0
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '_' || runes[i] == '-' || unicode.IsSpace(runes[i]) {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+
+		if i == start || !unicode.IsUpper(runes[i]) {
+			continue
+		}
+
+		previous := runes[i-1]
+		nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
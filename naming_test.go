@@ -0,0 +1,185 @@
+package csv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNamingStrategy(t *testing.T) {
+	tests := []struct {
+		fieldName string
+		strategy  NamingStrategy
+		expected  string
+	}{
+		{fieldName: "UnitPrice", strategy: NameFieldName, expected: "UnitPrice"},
+		{fieldName: "UnitPrice", strategy: NameSnakeCase, expected: "unit_price"},
+		{fieldName: "UnitPrice", strategy: NameCamelCase, expected: "unitPrice"},
+		{fieldName: "UnitPrice", strategy: NameScreamingSnakeCase, expected: "UNIT_PRICE"},
+		{fieldName: "HTTPServerID", strategy: NameSnakeCase, expected: "http_server_id"},
+		{fieldName: "HTTPServerID", strategy: NameCamelCase, expected: "httpServerId"},
+		{fieldName: "Address2Line", strategy: NameSnakeCase, expected: "address2_line"},
+		{fieldName: "Legacy_Code", strategy: NameScreamingSnakeCase, expected: "LEGACY_CODE"},
+		{fieldName: "ID", strategy: NameCamelCase, expected: "id"},
+	}
+
+	for _, test := range tests {
+		name := test.strategy.name(test.fieldName)
+		if name != test.expected {
+			t.Errorf("improperly derived header name of %s. Got '%s' but expected '%s'", test.fieldName, name, test.expected)
+		}
+	}
+}
+
+type derivedHeaders struct {
+	OrderID   int
+	UnitPrice float64 `csv:"header"`
+	Customer  string  `csv:"header:customer name"`
+	Internal  string  `csv:"-"`
+	notes     string
+}
+
+func TestDeriveHeaders(t *testing.T) {
+	p := NewParser(strings.NewReader("customer name,unit_price,order_id\nAcme,9.5,7"), ParserOptions{HeaderNaming: NameSnakeCase, DeriveHeaders: true})
+
+	var record derivedHeaders
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	expected := derivedHeaders{OrderID: 7, UnitPrice: 9.5, Customer: "Acme"}
+	if err != nil || record != expected {
+		t.Errorf("improperly read record with derived headers. Got '%+v' and error %v", record, err)
+	}
+
+	var output strings.Builder
+	w := NewWriter(&output, WriterOptions{HeaderNaming: NameScreamingSnakeCase, DeriveHeaders: true})
+	err = w.WriteHeader(&record)
+	w.Flush()
+	if err != nil || output.String() != "ORDER_ID,UNIT_PRICE,customer name\n" {
+		t.Errorf("improperly wrote derived headers. Got '%v' and error %v", output.String(), err)
+	}
+}
+
+type headerWithoutValue struct {
+	UnitPrice float64 `csv:"header"`
+	Other     string
+}
+
+func TestHeaderWithoutValue(t *testing.T) {
+	p := NewParser(strings.NewReader("UnitPrice,Other\n9.5,x"), ParserOptions{})
+
+	var record headerWithoutValue
+	err := p.ParseHeader(&record)
+	if err == nil {
+		err = p.ReadRecord(&record)
+	}
+
+	expected := headerWithoutValue{UnitPrice: 9.5}
+	if err != nil || record != expected {
+		t.Errorf("expected a header attribute without a value to bind the field name, and untagged fields to be left unbound. Got '%+v' and error %v", record, err)
+	}
+}
+
+// prefixNamer names fields with a prefix on top of snake case, and leaves fields tagged as internal unbound.
+type prefixNamer struct {
+	prefix string
+}
+
+func (n prefixNamer) HeaderFor(field reflect.StructField) string {
+	if field.Tag.Get("internal") != "" {
+		return ""
+	}
+	return n.prefix + NameSnakeCase.HeaderFor(field)
+}
+
+type namedFields struct {
+	OrderID   int
+	UnitPrice float64 `csv:"header"`
+	Secret    string  `internal:"true"`
+}
+
+func TestFieldNamer(t *testing.T) {
+	options := ParserOptions{FieldNamer: prefixNamer{prefix: "po_"}, HeaderNaming: NameCamelCase, DeriveHeaders: true}
+	p := NewParser(strings.NewReader("po_unit_price,po_order_id,Secret\n9.5,7,x"), options)
+
+	var record namedFields
+	err := p.ParseHeader(&record)
+	if err == nil {
+		err = p.ReadRecord(&record)
+	}
+
+	expected := namedFields{OrderID: 7, UnitPrice: 9.5}
+	if err != nil || record != expected {
+		t.Errorf("improperly read record with a field namer. Got '%+v' and error %v", record, err)
+	}
+
+	var output strings.Builder
+	w := NewWriter(&output, WriterOptions{FieldNamer: prefixNamer{prefix: "po_"}, DeriveHeaders: true})
+	err = w.WriteHeader(&record)
+	w.Flush()
+	if err != nil || output.String() != "po_order_id,po_unit_price\n" {
+		t.Errorf("improperly wrote headers with a field namer. Got '%v' and error %v", output.String(), err)
+	}
+}
+
+func TestHeaderCase(t *testing.T) {
+	tests := []struct {
+		name       string
+		headerCase HeaderCase
+		expected   string
+	}{
+		{name: "unit_price", headerCase: HeaderCaseUnchanged, expected: "unit_price"},
+		{name: "unit_price", headerCase: HeaderCaseTitle, expected: "Unit Price"},
+		{name: "HTTPServerID", headerCase: HeaderCaseTitle, expected: "HTTP Server ID"},
+		{name: "order-date", headerCase: HeaderCaseTitle, expected: "Order Date"},
+		{name: "customer name", headerCase: HeaderCaseUpper, expected: "CUSTOMER NAME"},
+		{name: "UnitPrice", headerCase: HeaderCaseLower, expected: "unitprice"},
+	}
+
+	for _, test := range tests {
+		name := test.headerCase.apply(test.name)
+		if name != test.expected {
+			t.Errorf("improperly transformed the case of %s. Got '%s' but expected '%s'", test.name, name, test.expected)
+		}
+	}
+}
+
+type reportHeaders struct {
+	OrderID   int     `csv:"index:0"`
+	UnitPrice float64 `csv:"header:unit_price"`
+}
+
+func TestWriteHeaderCase(t *testing.T) {
+	var output strings.Builder
+	w := NewWriter(&output, WriterOptions{DeriveHeaders: true, HeaderCase: HeaderCaseTitle})
+	err := w.WriteHeader(&reportHeaders{})
+	w.Flush()
+	if err != nil || output.String() != "Order ID,Unit Price\n" {
+		t.Errorf("improperly wrote title case headers. Got '%v' and error %v", output.String(), err)
+	}
+
+	output.Reset()
+	w = NewWriter(&output, WriterOptions{})
+	err = w.WriteHeader(&reportHeaders{})
+	w.Flush()
+	if err != nil || output.String() != ",unit_price\n" {
+		t.Errorf("expected a column bound only by index to keep an empty header without a naming strategy. Got '%v' and error %v", output.String(), err)
+	}
+
+	output.Reset()
+	w = NewWriter(&output, WriterOptions{Columns: []string{"unit_price", "order_date"}, HeaderCase: HeaderCaseUpper})
+	err = w.WriteHeaderMap()
+	w.Flush()
+	if err != nil || output.String() != "UNIT_PRICE,ORDER_DATE\n" {
+		t.Errorf("improperly wrote upper case map headers. Got '%v' and error %v", output.String(), err)
+	}
+
+	err = WriterOptions{HeaderCase: HeaderCase(-1)}.Validate()
+	if !errors.Is(err, ErrorInvalidHeaderCase) {
+		t.Errorf("expected to encounter ErrorInvalidHeaderCase error, but got %v", err)
+	}
+}
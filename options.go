@@ -0,0 +1,269 @@
+package csv
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+var (
+	ErrorInvalidDelimiter   = fmt.Errorf("delimiter may not be a quote, a line break or an invalid rune")
+	ErrorInvalidCommentChar = fmt.Errorf("comment character may not be a quote, a line break or an invalid rune")
+	ErrorCommentIsDelimiter = fmt.Errorf("comment character may not be the same as the delimiter")
+	ErrorInvalidTagName     = fmt.Errorf("tag name may not contain spaces, quotes, colons or control characters")
+	ErrorInvalidIndexBase   = fmt.Errorf("index base must be 0 or 1")
+)
+
+// validOptionRune reports whether r can be used as a delimiter or comment character, which follows the rules of the standard csv library.
+func validOptionRune(r rune) bool {
+	return r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+// validQuoteRune reports whether r can be used as a quote or escape character.
+func validQuoteRune(r rune) bool {
+	return r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+// validTagName reports whether name can be used as a struct tag key, which follows the conventions of reflect.StructTag.
+func validTagName(name string) bool {
+	for _, r := range name {
+		if r <= ' ' || r == 0x7f || r == '"' || r == ':' || r == utf8.RuneError {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate reports the first problem with the options as an OptionError. NewParser doesn't return an error,
+// so a parser created with invalid options returns the same error from every read instead.
+func (o ParserOptions) Validate() (err error) {
+	if o.Dialect < DialectDefault || o.Dialect > DialectMySQLOutfile {
+		return OptionError{
+			Option: "Dialect",
+			Err:    ErrorInvalidDialect,
+		}
+	}
+	o = o.withDialect()
+
+	if o.Delimiter != 0 && !validOptionRune(o.Delimiter) {
+		return OptionError{
+			Option: "Delimiter",
+			Err:    ErrorInvalidDelimiter,
+		}
+	}
+
+	if o.CommentChar != 0 && !validOptionRune(o.CommentChar) {
+		return OptionError{
+			Option: "CommentChar",
+			Err:    ErrorInvalidCommentChar,
+		}
+	}
+
+	delimiter := o.Delimiter
+	if delimiter == 0 {
+		delimiter = ','
+	}
+	if o.CommentChar == delimiter {
+		return OptionError{
+			Option: "CommentChar",
+			Err:    ErrorCommentIsDelimiter,
+		}
+	}
+
+	quote := o.QuoteChar
+	if quote == 0 {
+		quote = '"'
+	}
+	if o.QuoteChar != 0 && (o.QuoteChar == delimiter || o.QuoteChar == o.CommentChar || !validQuoteRune(o.QuoteChar)) {
+		return OptionError{
+			Option: "QuoteChar",
+			Err:    ErrorInvalidQuoteChar,
+		}
+	}
+
+	if o.EscapeChar != 0 && (o.EscapeChar == delimiter || o.EscapeChar == o.CommentChar || o.EscapeChar == quote || !validQuoteRune(o.EscapeChar)) {
+		return OptionError{
+			Option: "EscapeChar",
+			Err:    ErrorInvalidEscapeChar,
+		}
+	}
+
+	if o.BlankLines < BlankLineSkip || o.BlankLines > BlankLineError {
+		return OptionError{
+			Option: "BlankLines",
+			Err:    ErrorInvalidBlankLinePolicy,
+		}
+	}
+
+	if o.CommentPrefix != "" && o.CommentChar != 0 {
+		return OptionError{
+			Option: "CommentPrefix",
+			Err:    ErrorCommentPrefixAndChar,
+		}
+	}
+
+	if !validCommentPrefix(o.CommentPrefix, delimiter) {
+		return OptionError{
+			Option: "CommentPrefix",
+			Err:    ErrorInvalidCommentPrefix,
+		}
+	}
+
+	if o.InlineComments && o.CommentPrefix == "" && o.CommentChar == 0 {
+		return OptionError{
+			Option: "InlineComments",
+			Err:    ErrorInlineCommentsPrefix,
+		}
+	}
+
+	if o.FooterRows < 0 {
+		return OptionError{
+			Option: "FooterRows",
+			Err:    ErrorInvalidFooterRows,
+		}
+	}
+
+	if o.ExtraColumnTolerance < 0 {
+		return OptionError{
+			Option: "ExtraColumnTolerance",
+			Err:    ErrorInvalidColumnTolerance,
+		}
+	}
+
+	if o.Reconcile != nil {
+		err = o.Reconcile.validate(o)
+		if err != nil {
+			return OptionError{
+				Option: "Reconcile",
+				Err:    err,
+			}
+		}
+	}
+
+	if o.InvalidUTF8 < UTF8PassThrough || o.InvalidUTF8 > UTF8Error {
+		return OptionError{
+			Option: "InvalidUTF8",
+			Err:    ErrorInvalidUTF8Policy,
+		}
+	}
+
+	if o.DedupePolicy < DedupeKeepFirst || o.DedupePolicy > DedupeError {
+		return OptionError{
+			Option: "DedupePolicy",
+			Err:    ErrorInvalidDedupePolicy,
+		}
+	}
+
+	if !validTagName(o.TagName) {
+		return OptionError{
+			Option: "TagName",
+			Err:    ErrorInvalidTagName,
+		}
+	}
+
+	if o.IndexBase != 0 && o.IndexBase != 1 {
+		return OptionError{
+			Option: "IndexBase",
+			Err:    ErrorInvalidIndexBase,
+		}
+	}
+
+	if o.HeaderNaming < NameFieldName || o.HeaderNaming > NameScreamingSnakeCase {
+		return OptionError{
+			Option: "HeaderNaming",
+			Err:    ErrorInvalidNamingStrategy,
+		}
+	}
+
+	return nil
+}
+
+// Validate reports the first problem with the options as an OptionError. NewWriter doesn't return an error,
+// so a writer created with invalid options returns the same error from every write instead.
+func (o WriterOptions) Validate() (err error) {
+	if o.Delimiter != 0 && !validOptionRune(o.Delimiter) {
+		return OptionError{
+			Option: "Delimiter",
+			Err:    ErrorInvalidDelimiter,
+		}
+	}
+
+	if !validTagName(o.TagName) {
+		return OptionError{
+			Option: "TagName",
+			Err:    ErrorInvalidTagName,
+		}
+	}
+
+	if o.IndexBase != 0 && o.IndexBase != 1 {
+		return OptionError{
+			Option: "IndexBase",
+			Err:    ErrorInvalidIndexBase,
+		}
+	}
+
+	if o.HeaderNaming < NameFieldName || o.HeaderNaming > NameScreamingSnakeCase {
+		return OptionError{
+			Option: "HeaderNaming",
+			Err:    ErrorInvalidNamingStrategy,
+		}
+	}
+
+	if o.HeaderCase < HeaderCaseUnchanged || o.HeaderCase > HeaderCaseLower {
+		return OptionError{
+			Option: "HeaderCase",
+			Err:    ErrorInvalidHeaderCase,
+		}
+	}
+
+	if o.Compression < CompressionNone || o.Compression > CompressionGzip {
+		return OptionError{
+			Option: "Compression",
+			Err:    ErrorInvalidCompression,
+		}
+	}
+
+	if !validCompressionLevel(o.CompressionLevel) {
+		return OptionError{
+			Option: "CompressionLevel",
+			Err:    ErrorInvalidCompressionLevel,
+		}
+	}
+
+	for _, key := range o.SortBy {
+		if key.Field == "" {
+			return OptionError{
+				Option: "SortBy",
+				Err:    ErrorInvalidSortKey,
+			}
+		}
+	}
+
+	if o.SortMemoryLimit < 0 {
+		return OptionError{
+			Option: "SortMemoryLimit",
+			Err:    ErrorInvalidSortMemoryLimit,
+		}
+	}
+
+	return nil
+}
+
+// errorReader is the record reader of a parser created with invalid options, which fails every read.
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read() (record []string, err error) {
+	return nil, r.err
+}
+
+type OptionError struct {
+	Option string
+	Err    error
+}
+
+func (e OptionError) Error() string {
+	return fmt.Sprintf("invalid %s option: %v", e.Option, e.Err)
+}
+
+func (e OptionError) Unwrap() error { return e.Err }
@@ -0,0 +1,56 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParserOptionsValidate(t *testing.T) {
+	tests := []struct {
+		options  ParserOptions
+		expected error
+	}{
+		{options: ParserOptions{}},
+		{options: ParserOptions{Delimiter: '\t', CommentChar: '#'}},
+		{options: ParserOptions{Delimiter: '\n'}, expected: ErrorInvalidDelimiter},
+		{options: ParserOptions{Delimiter: '"'}, expected: ErrorInvalidDelimiter},
+		{options: ParserOptions{CommentChar: '\r'}, expected: ErrorInvalidCommentChar},
+		{options: ParserOptions{CommentChar: ','}, expected: ErrorCommentIsDelimiter},
+		{options: ParserOptions{Delimiter: ';', CommentChar: ';'}, expected: ErrorCommentIsDelimiter},
+		{options: ParserOptions{DedupePolicy: DedupePolicy(7)}, expected: ErrorInvalidDedupePolicy},
+		{options: ParserOptions{InvalidUTF8: UTF8Policy(-1)}, expected: ErrorInvalidUTF8Policy},
+		{options: ParserOptions{TagName: "tsv"}},
+		{options: ParserOptions{TagName: "csv:"}, expected: ErrorInvalidTagName},
+		{options: ParserOptions{TagName: "import tag"}, expected: ErrorInvalidTagName},
+		{options: ParserOptions{IndexBase: 1}},
+		{options: ParserOptions{IndexBase: 2}, expected: ErrorInvalidIndexBase},
+		{options: ParserOptions{HeaderNaming: NameScreamingSnakeCase}},
+		{options: ParserOptions{HeaderNaming: NamingStrategy(9)}, expected: ErrorInvalidNamingStrategy},
+	}
+
+	for _, test := range tests {
+		err := test.options.Validate()
+		if !errors.Is(err, test.expected) {
+			t.Errorf("expected options '%+v' to be reported as %v, but got %v", test.options, test.expected, err)
+		}
+	}
+}
+
+func TestInvalidOptionsError(t *testing.T) {
+	p := NewParser(strings.NewReader("a,1"), ParserOptions{Delimiter: '\n'})
+
+	err := p.ReadRecord(&peekTest{})
+	var optionErr OptionError
+	if !errors.As(err, &optionErr) || optionErr.Option != "Delimiter" {
+		t.Errorf("expected an OptionError for the Delimiter when reading, but got %v", err)
+	}
+
+	w := NewWriter(&bytes.Buffer{}, WriterOptions{Delimiter: '"'})
+
+	err = w.WriteRecord(&peekTest{})
+	if !errors.Is(err, ErrorInvalidDelimiter) {
+		t.Errorf("expected to encounter Invalid Delimiter error when writing, but got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+package csv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+var (
+	ErrorNotParallel        = fmt.Errorf("option can't be used when parsing in parallel, since it needs to see every record in order")
+	ErrorQuotingNotParallel = fmt.Errorf("option can't be used when parsing in parallel, since files are split into chunks where double quoted values end")
+)
+
+// parallelChunk is a part of a file that starts on a record boundary, along with the number of records before it.
+type parallelChunk struct {
+	offset int64
+	end    int64
+	line   int
+}
+
+// ParseParallel reads every record of file into a slice of T, as Parse does, but splits the file into chunks on record boundaries and parses the chunks concurrently with the given number of workers.
+// It suits batch jobs on large seekable files, such as an *os.File along with its size. If workers is not positive, one worker is used per CPU.
+// Records are returned in file order, and line numbers in errors count records from the start of the file. If a record can't be read, the records before it are returned along with the error.
+// Validator, Filter and CellTransform may be called concurrently, so they must be safe for concurrent use. DedupeBy, Profiler, RejectWriter, AutoDetectHeader, FooterRows, FooterHandler and Reconcile need to see every record in order,
+// and QuoteChar and EscapeChar change where records end, so they return an OptionError.
+func ParseParallel[T any](file io.ReaderAt, size int64, workers int, options ParserOptions) (records []T, err error) {
+	options = options.withDialect()
+	err = checkParallelOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	p := NewParser(io.NewSectionReader(file, 0, size), options)
+	var record T
+	err = p.bind(&record)
+	if err != nil {
+		return nil, err
+	}
+
+	var start int64
+	if p.needsHeader {
+		err = p.ParseHeader(&record)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		start = p.reader.(inputOffsetReader).InputOffset()
+	}
+
+	chunks, err := findParallelChunks(io.NewSectionReader(file, start, size-start), start, size, workers, options)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]T, len(chunks))
+	errs := make([]error, len(chunks))
+
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk parallelChunk) {
+			defer wg.Done()
+			results[i], errs[i] = parseChunk[T](file, chunk, p.header, options)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	for i := range chunks {
+		records = append(records, results[i]...)
+		if errs[i] != nil {
+			return records, errs[i]
+		}
+	}
+
+	return records, nil
+}
+
+func checkParallelOptions(options ParserOptions) (err error) {
+	err = options.Validate()
+	if err != nil {
+		return err
+	}
+
+	option := ""
+	switch {
+	case len(options.DedupeBy) > 0:
+		option = "DedupeBy"
+	case options.Profiler != nil:
+		option = "Profiler"
+	case options.RejectWriter != nil:
+		option = "RejectWriter"
+	case options.AutoDetectHeader:
+		option = "AutoDetectHeader"
+	case options.FooterRows > 0:
+		option = "FooterRows"
+	case options.FooterHandler != nil:
+		option = "FooterHandler"
+	case options.Reconcile != nil:
+		option = "Reconcile"
+	}
+
+	if option != "" {
+		return OptionError{
+			Option: option,
+			Err:    ErrorNotParallel,
+		}
+	}
+
+	if customQuoting(options) {
+		return OptionError{
+			Option: "QuoteChar",
+			Err:    ErrorQuotingNotParallel,
+		}
+	}
+
+	return nil
+}
+
+// parseChunk reads every record of chunk, starting its line numbers from the records before it, and binding fields by header to the header parsed from the start of the file.
+func parseChunk[T any](file io.ReaderAt, chunk parallelChunk, header []string, options ParserOptions) (records []T, err error) {
+	p := NewParser(io.NewSectionReader(file, chunk.offset, chunk.end-chunk.offset), options)
+	p.line = chunk.line
+	if header != nil {
+		p.header = header
+	}
+
+	for {
+		var record T
+		err = p.ReadRecord(&record)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+
+		records = append(records, record)
+	}
+}
+
+// findParallelChunks scans the file from start to end for record boundaries near where it would split into equal chunks, and counts the records before each boundary.
+// It follows the quoting rules of the standard csv library, so a line break inside a quoted value is never taken as a boundary, and it skips blank and comment lines just as the reader does, so that the counts match line numbers.
+func findParallelChunks(r io.Reader, start int64, end int64, chunks int, options ParserOptions) (found []parallelChunk, err error) {
+	delimiter := []byte(string(parserDelimiter(options)))
+	var comment []byte
+	if options.CommentChar != 0 {
+		comment = []byte(string(options.CommentChar))
+	}
+
+	reader := bufio.NewReader(r)
+	target := func(n int) int64 { return start + (end-start)*int64(n)/int64(chunks) }
+
+	found = append(found, parallelChunk{offset: start, line: 0})
+	offset := start
+	records := 0
+	lineStart := true
+	fieldStart := true
+	inQuotes := false
+
+scan:
+	for {
+		if lineStart && !inQuotes {
+			if len(found) < chunks && offset >= target(len(found)) && offset > found[len(found)-1].offset {
+				found = append(found, parallelChunk{offset: offset, line: records})
+			}
+
+			if next, _ := reader.Peek(2); len(next) > 0 {
+				switch {
+				case next[0] == '\n' || (next[0] == '\r' && len(next) > 1 && next[1] == '\n'):
+					// A blank line isn't a record.
+				case comment != nil && hasBytePrefix(reader, comment):
+					line, err := reader.ReadSlice('\n')
+					for err == bufio.ErrBufferFull {
+						offset += int64(len(line))
+						line, err = reader.ReadSlice('\n')
+					}
+					offset += int64(len(line))
+					if err == io.EOF {
+						break scan
+					}
+					if err != nil {
+						return nil, err
+					}
+					continue
+				default:
+					records++
+				}
+			}
+			lineStart = false
+			fieldStart = true
+		}
+
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		offset++
+
+		switch {
+		case inQuotes:
+			if c == '"' {
+				if next, _ := reader.Peek(1); len(next) == 1 && next[0] == '"' {
+					reader.ReadByte()
+					offset++
+				} else {
+					inQuotes = false
+				}
+			}
+		case c == '\n':
+			lineStart = true
+		case fieldStart && c == '"':
+			inQuotes = true
+			fieldStart = false
+		case c == delimiter[0] && (len(delimiter) == 1 || hasBytePrefix(reader, delimiter[1:])):
+			for i := 1; i < len(delimiter); i++ {
+				reader.ReadByte()
+				offset++
+			}
+			fieldStart = true
+		default:
+			fieldStart = false
+		}
+	}
+
+	for i := range found {
+		found[i].end = end
+		if i+1 < len(found) {
+			found[i].end = found[i+1].offset
+		}
+	}
+
+	return found, nil
+}
+
+// hasBytePrefix reports whether the next bytes of reader are prefix, without consuming them.
+func hasBytePrefix(reader *bufio.Reader, prefix []byte) bool {
+	next, _ := reader.Peek(len(prefix))
+	return string(next) == string(prefix)
+}
+
+// parserDelimiter returns the delimiter the csv reader of a parser created with options uses.
+func parserDelimiter(options ParserOptions) rune {
+	if legalDelimiter(options.Delimiter) {
+		return options.Delimiter
+	}
+
+	return ','
+}
@@ -0,0 +1,88 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type parallelTest struct {
+	ID   int    `csv:"header:id"`
+	Note string `csv:"header:note"`
+}
+
+// parallelTestData holds quoted line breaks, blank lines and comments, so that a naive split on line breaks would land inside a record or miscount lines.
+func parallelTestData(rows int, badRow int) string {
+	var data strings.Builder
+	data.WriteString("note,id\n")
+	for i := 1; i <= rows; i++ {
+		switch i % 5 {
+		case 0:
+			fmt.Fprintf(&data, "\"multi\nline \"\"%d\"\"\",%d\n", i, i)
+		case 1:
+			fmt.Fprintf(&data, "\n# comment, \"with a quote\n%s,%d\n", "plain", i)
+		default:
+			fmt.Fprintf(&data, "\"quoted, %d\",%d\r\n", i, i)
+		}
+		if i == badRow {
+			data.WriteString("bad,notanumber\n")
+		}
+	}
+	return data.String()
+}
+
+func TestParseParallel(t *testing.T) {
+	data := parallelTestData(500, 0)
+	options := ParserOptions{CommentChar: '#'}
+
+	expected, err := Parse[parallelTest](strings.NewReader(data), options)
+	if err != nil {
+		t.Errorf("encountered error parsing csv: %v", err)
+		return
+	}
+
+	for _, workers := range []int{1, 2, 3, 8, 64} {
+		records, err := ParseParallel[parallelTest](strings.NewReader(data), int64(len(data)), workers, options)
+		if err != nil {
+			t.Errorf("encountered error parsing csv with %d workers: %v", workers, err)
+			continue
+		}
+
+		if len(records) != len(expected) {
+			t.Errorf("expected %d records with %d workers, but got %d", len(expected), workers, len(records))
+			continue
+		}
+
+		for i := range expected {
+			if records[i] != expected[i] {
+				t.Errorf("expected record %d to be %+v with %d workers, but got %+v", i, expected[i], workers, records[i])
+				break
+			}
+		}
+	}
+}
+
+func TestParseParallelErrorLine(t *testing.T) {
+	data := parallelTestData(500, 377)
+
+	records, err := ParseParallel[parallelTest](strings.NewReader(data), int64(len(data)), 4, ParserOptions{CommentChar: '#'})
+
+	var setErr SetValueError
+	if !errors.As(err, &setErr) || setErr.Line != 378 {
+		t.Errorf("expected a SetValueError on line 378, but got %v", err)
+	}
+
+	if len(records) != 377 {
+		t.Errorf("expected the 377 records before the error to be returned, but got %d", len(records))
+	}
+}
+
+func TestParseParallelOptions(t *testing.T) {
+	_, err := ParseParallel[parallelTest](strings.NewReader(""), 0, 2, ParserOptions{DedupeBy: []string{"ID"}})
+
+	var optionErr OptionError
+	if !errors.As(err, &optionErr) || !errors.Is(err, ErrorNotParallel) || optionErr.Option != "DedupeBy" {
+		t.Errorf("expected to encounter ErrorNotParallel error for DedupeBy, but got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package csv
+
+import (
+	"io"
+)
+
+// Parse reads every record of file into a slice of T, where *T has csv decorator tags applied.
+// The header is parsed first when any field is bound only by header, and not when every field is bound by index, so it can't be forgotten.
+// If a record can't be read, the records before it are returned along with the error.
+func Parse[T any](file io.Reader, options ParserOptions) (records []T, err error) {
+	p := NewParser(file, options)
+
+	var record T
+	err = p.bind(&record)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.needsHeader && !options.AutoDetectHeader {
+		err = p.ParseHeader(&record)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		var record T
+		err = p.ReadRecord(&record)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+
+		records = append(records, record)
+	}
+}
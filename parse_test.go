@@ -0,0 +1,49 @@
+package csv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	headerRecords, err := Parse[headerTest](strings.NewReader(headerTestData), ParserOptions{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv with header: %v", err)
+	}
+	if len(headerRecords) != len(headerTestResults) {
+		t.Errorf("parsed %d records from csv with header, but expected %d", len(headerRecords), len(headerTestResults))
+	}
+	for i := range headerRecords {
+		// IgnoredField isn't tagged, so it is left as its zero value.
+		headerRecords[i].IgnoredField = headerTestResults[i].IgnoredField
+		if headerRecords[i] != headerTestResults[i] {
+			t.Errorf("improperly parsed csv with header. Got '%+v' but expected '%+v'", headerRecords[i], headerTestResults[i])
+		}
+	}
+
+	indexRecords, err := Parse[peekTest](strings.NewReader("a,1\nb,2"), ParserOptions{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv without header: %v", err)
+	}
+	if len(indexRecords) != 2 || indexRecords[1] != (peekTest{Section: "b", Count: 2}) {
+		t.Errorf("improperly parsed csv without header. Got '%+v'", indexRecords)
+	}
+
+	emptyRecords, err := Parse[headerTest](strings.NewReader(""), ParserOptions{})
+	if err != nil || len(emptyRecords) != 0 {
+		t.Errorf("expected no records and no error from an empty file, but got '%+v' and %v", emptyRecords, err)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	records, err := Parse[peekTest](strings.NewReader("a,1\nb,x\nc,3"), ParserOptions{})
+
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 2 {
+		t.Errorf("expected a SetValueError on line 2, but got %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("expected the records before the error to be returned, but got '%+v'", records)
+	}
+}
@@ -0,0 +1,311 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorMissingPartitionField = fmt.Errorf("partitioned writer needs a field to partition records by")
+	ErrorInvalidMaxOpen        = fmt.Errorf("most open partitions must not be negative")
+)
+
+// PartitionOptions sets how a PartitionedWriter routes records to partitions.
+type PartitionOptions struct {
+	// Field is the field whose value picks the partition of each record, named by header name or field name. Its value is formatted as it is written,
+	// so a field with a precision attribute is partitioned by its rounded value. For maps written with WriteRecordMap, Field is the key of the value.
+	Field string
+	// MaxOpen is the most partition files kept open at once. When another partition is needed, the least recently written one is closed,
+	// and reopened by calling create again if more of its records follow, so create should then append to the file. Zero keeps every partition open.
+	MaxOpen int
+}
+
+// PartitionedWriter writes structs with csv decorator tags as csv records to one file per value of a field, such as one file per country code for a fan-out export.
+// The header is written at the start of every partition. A PartitionedWriter is not safe for concurrent use.
+type PartitionedWriter struct {
+	writer     Writer
+	pending    bytes.Buffer
+	header     []byte
+	create     func(key string) (io.WriteCloser, error)
+	partition  PartitionOptions
+	options    WriterOptions
+	fieldName  string
+	partitions map[string]*partFile
+	keys       []string
+	open       []string
+	closed     bool
+	err        error
+}
+
+// NewPartitionedWriter creates a csv writer that writes each record to the partition created by create for the value of the record's partition field.
+// Partitions are created as their first record is written, and closed by Close. Any compression set in options is applied to each partition separately.
+func NewPartitionedWriter(create func(key string) (io.WriteCloser, error), partition PartitionOptions, options WriterOptions) (w *PartitionedWriter) {
+	w = &PartitionedWriter{
+		create:     create,
+		partition:  partition,
+		options:    options,
+		partitions: make(map[string]*partFile),
+	}
+
+	w.err = options.Validate()
+	if w.err == nil && len(options.SortBy) > 0 {
+		w.err = OptionError{
+			Option: "SortBy",
+			Err:    ErrorSortNotSupported,
+		}
+	}
+	if w.err == nil && partition.Field == "" {
+		w.err = OptionError{
+			Option: "Field",
+			Err:    ErrorMissingPartitionField,
+		}
+	}
+	if w.err == nil && partition.MaxOpen < 0 {
+		w.err = OptionError{
+			Option: "MaxOpen",
+			Err:    ErrorInvalidMaxOpen,
+		}
+	}
+
+	w.writer = NewWriter(&w.pending, renderOptions(options))
+
+	return w
+}
+
+// WriteHeader sets the header written at the start of every partition to the header names described by the csv decorator tags defined on structPointer,
+// as written by Writer.WriteHeader. It should be called before any records are written.
+func (w *PartitionedWriter) WriteHeader(structPointer interface{}) (err error) {
+	err = w.check()
+	if err != nil {
+		return err
+	}
+
+	err = w.writer.WriteHeader(structPointer)
+	if err != nil {
+		return err
+	}
+
+	return w.setHeader()
+}
+
+// WriteHeaderMap sets the header written at the start of every partition to the Columns listed in the writer options, as written by Writer.WriteHeaderMap.
+func (w *PartitionedWriter) WriteHeaderMap() (err error) {
+	err = w.check()
+	if err != nil {
+		return err
+	}
+
+	err = w.writer.WriteHeaderMap()
+	if err != nil {
+		return err
+	}
+
+	return w.setHeader()
+}
+
+// WriteRecord writes the fields of structPointer to the partition for the value of its partition field, as described by Writer.WriteRecord.
+func (w *PartitionedWriter) WriteRecord(structPointer interface{}) (err error) {
+	err = w.check()
+	if err != nil {
+		return err
+	}
+
+	err = w.writer.WriteRecord(structPointer)
+	if err != nil {
+		w.pending.Reset()
+		return err
+	}
+
+	key, err := w.structKey(structPointer)
+	if err != nil {
+		w.pending.Reset()
+		return err
+	}
+
+	return w.commit(key)
+}
+
+// WriteRecordMap writes the values of record to the partition for the value of its partition key, as described by Writer.WriteRecordMap.
+func (w *PartitionedWriter) WriteRecordMap(record map[string]string) (err error) {
+	err = w.check()
+	if err != nil {
+		return err
+	}
+
+	err = w.writer.WriteRecordMap(record)
+	if err != nil {
+		w.pending.Reset()
+		return err
+	}
+
+	return w.commit(record[w.partition.Field])
+}
+
+// Partitions returns the keys of the partitions created so far, in the order their first records were written.
+func (w *PartitionedWriter) Partitions() (keys []string) {
+	return append(keys, w.keys...)
+}
+
+// Flush writes any buffered data to the open partitions, and reports the first error that occurred.
+func (w *PartitionedWriter) Flush() (err error) {
+	if w.err != nil {
+		return w.err
+	}
+
+	for _, key := range w.open {
+		err = w.partitions[key].flush()
+		if err != nil {
+			w.err = err
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close finishes and closes every open partition, and reports the first error that occurred. Later writes return ErrorWriterClosed.
+func (w *PartitionedWriter) Close() (err error) {
+	if w.err != nil || w.closed {
+		return w.err
+	}
+	w.closed = true
+
+	for _, key := range w.open {
+		closeErr := w.partitions[key].close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+	w.open = nil
+
+	return err
+}
+
+// check returns the error every write should return, if the writer has failed or is closed.
+func (w *PartitionedWriter) check() (err error) {
+	if w.err != nil {
+		return w.err
+	}
+
+	if w.closed {
+		return ErrorWriterClosed
+	}
+
+	return nil
+}
+
+// setHeader keeps the header rendered by the last write as the header of every partition.
+func (w *PartitionedWriter) setHeader() (err error) {
+	err = w.writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	w.header = append([]byte(nil), w.pending.Bytes()...)
+	w.pending.Reset()
+
+	return nil
+}
+
+// structKey returns the value of the partition field of structPointer, formatted as it is written. The struct must already be bound.
+func (w *PartitionedWriter) structKey(structPointer interface{}) (key string, err error) {
+	if w.fieldName == "" {
+		for _, fieldName := range w.writer.fieldOrder {
+			attrs := w.writer.csvAttrs[fieldName]
+			if (attrs.hasHeader && attrs.headerName == w.partition.Field) || fieldName == w.partition.Field {
+				w.fieldName = fieldName
+				break
+			}
+		}
+
+		if w.fieldName == "" {
+			return "", FieldNotFoundError{
+				FieldName:  w.partition.Field,
+				HeaderName: w.partition.Field,
+				Err:        ErrorColumnNotFound,
+			}
+		}
+	}
+
+	key, err = w.writer.getFieldValue(structPointer, w.fieldName)
+	if err != nil {
+		return "", GetValueError{
+			Line:      w.writer.line,
+			FieldName: w.fieldName,
+			Err:       err,
+		}
+	}
+
+	return key, nil
+}
+
+// commit copies the record rendered by the last write to the partition for key, opening the partition first if it isn't open.
+func (w *PartitionedWriter) commit(key string) (err error) {
+	err = w.writer.Flush()
+	if err != nil {
+		return err
+	}
+	defer w.pending.Reset()
+
+	part, err := w.openPartition(key)
+	if err != nil {
+		w.err = err
+		return err
+	}
+
+	err = part.writeRecord(w.pending.Bytes())
+	if err != nil {
+		w.err = err
+	}
+
+	return err
+}
+
+// openPartition returns the open partition for key, creating or reopening it as needed, and marks it as the most recently written.
+// The least recently written partition is closed first if MaxOpen partitions are already open.
+func (w *PartitionedWriter) openPartition(key string) (part *partFile, err error) {
+	part, seen := w.partitions[key]
+	if part != nil {
+		for idx, openKey := range w.open {
+			if openKey == key {
+				w.open = append(append(w.open[:idx:idx], w.open[idx+1:]...), key)
+				break
+			}
+		}
+		return part, nil
+	}
+
+	if w.partition.MaxOpen > 0 && len(w.open) >= w.partition.MaxOpen {
+		oldest := w.open[0]
+		w.open = w.open[1:]
+
+		err = w.partitions[oldest].close()
+		w.partitions[oldest] = nil
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := w.create(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := w.header
+	if seen {
+		header = nil
+	} else {
+		w.keys = append(w.keys, key)
+	}
+
+	part, err = openPart(file, header, w.options)
+	if err != nil {
+		return nil, err
+	}
+
+	w.partitions[key] = part
+	w.open = append(w.open, key)
+
+	return part, nil
+}
@@ -0,0 +1,158 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type partitionTest struct {
+	Country string `csv:"header:country"`
+	Amount  int    `csv:"header:amount"`
+}
+
+// memoryPartitions collects the partitions written by a PartitionedWriter, appending to a partition that is reopened.
+type memoryPartitions struct {
+	files   map[string]*bytes.Buffer
+	creates int
+}
+
+type memoryPartition struct {
+	*bytes.Buffer
+}
+
+func (p memoryPartition) Close() error { return nil }
+
+func (m *memoryPartitions) create(key string) (io.WriteCloser, error) {
+	if m.files == nil {
+		m.files = make(map[string]*bytes.Buffer)
+	}
+	if m.files[key] == nil {
+		m.files[key] = &bytes.Buffer{}
+	}
+	m.creates++
+
+	return memoryPartition{m.files[key]}, nil
+}
+
+func writePartitions(t *testing.T, w *PartitionedWriter, records []partitionTest) {
+	t.Helper()
+
+	err := w.WriteHeader(&partitionTest{})
+	if err != nil {
+		t.Fatalf("encountered error writing csv header: %v", err)
+	}
+
+	for _, record := range records {
+		err = w.WriteRecord(&record)
+		if err != nil {
+			t.Fatalf("encountered error writing csv record: %v", err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing partitioned writer: %v", err)
+	}
+}
+
+var partitionRecords = []partitionTest{
+	{Country: "IE", Amount: 1},
+	{Country: "FR", Amount: 2},
+	{Country: "DE", Amount: 3},
+	{Country: "IE", Amount: 4},
+}
+
+func TestPartitionedWriter(t *testing.T) {
+	var partitions memoryPartitions
+	w := NewPartitionedWriter(partitions.create, PartitionOptions{Field: "country"}, WriterOptions{})
+	writePartitions(t, w, partitionRecords)
+
+	expected := map[string]string{
+		"IE": "country,amount\nIE,1\nIE,4\n",
+		"FR": "country,amount\nFR,2\n",
+		"DE": "country,amount\nDE,3\n",
+	}
+	for key, want := range expected {
+		if got := partitions.files[key].String(); got != want {
+			t.Errorf("improperly written partition %s. Got '%s' but expected '%s'", key, got, want)
+		}
+	}
+
+	if keys := w.Partitions(); !reflect.DeepEqual(keys, []string{"IE", "FR", "DE"}) {
+		t.Errorf("expected partitions in the order they were first written, but got %v", keys)
+	}
+}
+
+func TestPartitionedWriterMaxOpen(t *testing.T) {
+	var partitions memoryPartitions
+	w := NewPartitionedWriter(partitions.create, PartitionOptions{Field: "Country", MaxOpen: 2}, WriterOptions{})
+	writePartitions(t, w, partitionRecords)
+
+	want := "country,amount\nIE,1\nIE,4\n"
+	if got := partitions.files["IE"].String(); got != want {
+		t.Errorf("expected a reopened partition to be appended to without a second header. Got '%s' but expected '%s'", got, want)
+	}
+
+	if partitions.creates != 4 {
+		t.Errorf("expected the IE partition to be reopened once, but create was called %d times", partitions.creates)
+	}
+}
+
+func TestPartitionedWriterMaps(t *testing.T) {
+	var partitions memoryPartitions
+	w := NewPartitionedWriter(partitions.create, PartitionOptions{Field: "country"}, WriterOptions{Columns: []string{"country", "amount"}})
+
+	err := w.WriteHeaderMap()
+	if err != nil {
+		t.Fatalf("encountered error writing csv header: %v", err)
+	}
+
+	err = w.WriteRecordMap(map[string]string{"country": "IE", "amount": "1"})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing partitioned writer: %v", err)
+	}
+
+	if got := partitions.files["IE"].String(); got != "country,amount\nIE,1\n" {
+		t.Errorf("improperly written map partition: '%s'", got)
+	}
+}
+
+func TestPartitionedWriterFieldNotFound(t *testing.T) {
+	var partitions memoryPartitions
+	w := NewPartitionedWriter(partitions.create, PartitionOptions{Field: "region"}, WriterOptions{})
+
+	err := w.WriteRecord(&partitionTest{Country: "IE"})
+	if !errors.Is(err, ErrorColumnNotFound) {
+		t.Errorf("expected %v, but got %v", ErrorColumnNotFound, err)
+	}
+}
+
+func TestPartitionedWriterOptionErrors(t *testing.T) {
+	var partitions memoryPartitions
+	tests := []struct {
+		partition PartitionOptions
+		option    string
+		err       error
+	}{
+		{PartitionOptions{}, "Field", ErrorMissingPartitionField},
+		{PartitionOptions{Field: "country", MaxOpen: -1}, "MaxOpen", ErrorInvalidMaxOpen},
+	}
+
+	for _, test := range tests {
+		w := NewPartitionedWriter(partitions.create, test.partition, WriterOptions{})
+
+		var optionErr OptionError
+		err := w.WriteRecord(&partitionTest{})
+		if !errors.As(err, &optionErr) || optionErr.Option != test.option || !errors.Is(err, test.err) {
+			t.Errorf("expected %v for option %s, but got %v", test.err, test.option, err)
+		}
+	}
+}
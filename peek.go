@@ -0,0 +1,71 @@
+package csv
+
+// Peek reads the next line of the parser's csv file and sets it on structPointer just as ReadRecord would, but leaves the line to be read again by the next call to ReadRecord.
+// Use it to look ahead, such as to find where one section of a report ends and the next begins. Calling Peek again without reading returns the same line.
+// Lines skipped by the Filter and DedupeBy options are skipped by Peek too. Any error reading the line is returned by Peek, and again by the next call to ReadRecord.
+func (p *Parser) Peek(structPointer interface{}) (err error) {
+	err = p.bind(structPointer)
+	if err != nil {
+		return err
+	}
+
+	if p.needsHeader && p.header == nil {
+		return ErrorHeaderNotParsed
+	}
+
+	peek := p.peekKeptRecord()
+	if peek.err != nil {
+		return peek.err
+	}
+
+	// Errors should report the line the peeked record will be read as.
+	line := p.line
+	p.line = peek.line
+	defer func() { p.line = line }()
+
+	return p.setRecord(structPointer, peek.record)
+}
+
+// recordPeek is the record kept by Peek, once the Filter and DedupeBy options have been applied, along with the line it will be read as and the offset it starts at.
+type recordPeek struct {
+	record []string
+	err    error
+	line   int
+	offset int64
+}
+
+// peekKeptRecord returns the next record ReadRecord will return, and keeps it to be returned by the next call to ReadRecord.
+func (p *Parser) peekKeptRecord() (peek *recordPeek) {
+	if p.recordPeek == nil {
+		// Records skipped by the options are read again if the parser is resumed from here, and skipped again.
+		offset, _ := p.readerOffset()
+		if p.hasPeeked {
+			offset = p.peekOffset
+		}
+
+		line := p.line
+		record, err := p.readKeptRecord()
+
+		// Keep a copy, since the reader may reuse the record on the next read.
+		p.recordPeek = &recordPeek{record: append([]string(nil), record...), err: err, line: p.line, offset: offset}
+		p.line = line
+	}
+
+	return p.recordPeek
+}
+
+// peekRecord returns the next record of the parser's csv file, and keeps it to be returned again by the next call to readRecord.
+func (p *Parser) peekRecord() (record []string, err error) {
+	if !p.hasPeeked {
+		p.peekOffset, _ = p.readerOffset()
+
+		record, err := p.nextRecord()
+
+		// Keep a copy, since the reader may reuse the record on the next read.
+		p.peeked = append(p.peeked[:0], record...)
+		p.peekErr = err
+		p.hasPeeked = true
+	}
+
+	return p.peeked, p.peekErr
+}
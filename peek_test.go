@@ -0,0 +1,138 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type peekTest struct {
+	Section string `csv:"index:0"`
+	Count   int    `csv:"index:1"`
+}
+
+func TestPeek(t *testing.T) {
+	p := NewParser(strings.NewReader("a,1\nb,x"), ParserOptions{ReuseRecord: true})
+
+	var peeked, read peekTest
+	err := p.Peek(&peeked)
+	if err != nil {
+		t.Errorf("encountered error peeking csv record: %v", err)
+	}
+
+	err = p.ReadRecord(&read)
+	if err != nil {
+		t.Errorf("encountered error reading peeked csv record: %v", err)
+	}
+	if peeked != read || read.Section != "a" {
+		t.Errorf("peeked record differs from read record. Peeked '%+v' but read '%+v'", peeked, read)
+	}
+
+	err = p.Peek(&peeked)
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 2 {
+		t.Errorf("expected a SetValueError on line 2 when peeking, but got %v", err)
+	}
+
+	err = p.ReadRecord(&read)
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 2 {
+		t.Errorf("expected a SetValueError on line 2 when reading, but got %v", err)
+	}
+
+	err = p.Peek(&peeked)
+	if err != io.EOF {
+		t.Errorf("expected to reach the end of the file when peeking, but got %v", err)
+	}
+
+	err = p.ReadRecord(&read)
+	if err != io.EOF {
+		t.Errorf("expected to reach the end of the file when reading, but got %v", err)
+	}
+}
+
+func TestPeekFilter(t *testing.T) {
+	keep := func(record []string, line int) bool { return record[0] != "skip" }
+	p := NewParser(strings.NewReader("skip,1\nkeep,2\nskip,3\nkeep,4"), ParserOptions{Filter: keep})
+
+	var peeked, read peekTest
+	err := p.Peek(&peeked)
+	if err != nil {
+		t.Errorf("encountered error peeking csv record: %v", err)
+	}
+	if peeked.Section != "keep" || peeked.Count != 2 {
+		t.Errorf("expected Peek to skip the records the filter rejects, but peeked '%+v'", peeked)
+	}
+
+	err = p.ReadRecord(&read)
+	if err != nil {
+		t.Errorf("encountered error reading peeked csv record: %v", err)
+	}
+	if peeked != read || p.Line() != 2 {
+		t.Errorf("peeked record differs from read record. Peeked '%+v' but read '%+v' on line %d", peeked, read, p.Line())
+	}
+
+	err = p.ReadRecord(&read)
+	if err != nil || read.Count != 4 || p.Line() != 4 {
+		t.Errorf("expected the next kept record on line 4, but read '%+v' on line %d and error %v", read, p.Line(), err)
+	}
+}
+
+func TestPeekDedupe(t *testing.T) {
+	p := NewParser(strings.NewReader("a,1\na,2\nb,3"), ParserOptions{DedupeBy: []string{"Section"}})
+
+	var peeked, read peekTest
+	err := p.ReadRecord(&read)
+	if err != nil {
+		t.Errorf("encountered error reading csv record: %v", err)
+	}
+
+	err = p.Peek(&peeked)
+	if err != nil || peeked.Section != "b" {
+		t.Errorf("expected Peek to skip the duplicate record, but peeked '%+v' and error %v", peeked, err)
+	}
+
+	err = p.ReadRecord(&read)
+	if err != nil || read != peeked {
+		t.Errorf("peeked record differs from read record. Peeked '%+v' but read '%+v' and error %v", peeked, read, err)
+	}
+
+	err = p.ReadRecord(&read)
+	if err != io.EOF {
+		t.Errorf("expected to reach the end of the file when reading, but got %v", err)
+	}
+}
+
+func TestPeekSkip(t *testing.T) {
+	p := NewParser(strings.NewReader("a,1\nb,2\nc,3\nd,4"), ParserOptions{})
+
+	var peeked, read peekTest
+	err := p.Peek(&peeked)
+	if err != nil || peeked.Section != "a" {
+		t.Errorf("expected to peek the first record, but peeked '%+v' and error %v", peeked, err)
+	}
+
+	// The peeked record is the next one, so it is the one skipped.
+	err = p.Skip(1)
+	if err != nil {
+		t.Errorf("encountered error skipping csv record: %v", err)
+	}
+
+	err = p.ReadRecord(&read)
+	if err != nil || read.Section != "b" || p.Line() != 2 {
+		t.Errorf("expected the record after the skipped one on line 2, but read '%+v' on line %d and error %v", read, p.Line(), err)
+	}
+
+	err = p.Peek(&peeked)
+	if err != nil {
+		t.Errorf("encountered error peeking csv record: %v", err)
+	}
+
+	// Without a parsed header, the peeked record is read as the header of the map.
+	record, err := p.ReadRecordMap()
+	expected := map[string]string{"c": "d", "3": "4"}
+	if err != nil || !reflect.DeepEqual(record, expected) {
+		t.Errorf("expected the record map %v, but got %v and error %v", expected, record, err)
+	}
+}
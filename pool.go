@@ -0,0 +1,97 @@
+package csv
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// ParserPool hands out parsers that share the options it was created with, and reuses their read buffers and struct bindings between files.
+// It suits services that parse many small files, such as uploads, where reading the csv tags and allocating buffers would otherwise be repeated for every file.
+// A ParserPool is safe for concurrent use, but each parser it hands out is not.
+type ParserPool struct {
+	options    ParserOptions
+	optionsErr error
+	parsers    sync.Pool
+	bindings   sync.Map
+}
+
+// NewParserPool creates a pool of parsers that use options.
+// If the options are invalid, every read of every parser returns the OptionError reported by ParserOptions.Validate.
+func NewParserPool(options ParserOptions) (pool *ParserPool) {
+	return &ParserPool{
+		options:    options,
+		optionsErr: options.Validate(),
+	}
+}
+
+// AcquireParser returns a parser for file, as NewParser would create it. Pass the parser to ReleaseParser once it is no longer needed so that it can be reused.
+func (pool *ParserPool) AcquireParser(file io.Reader) (p *Parser) {
+	p, _ = pool.parsers.Get().(*Parser)
+	if p == nil {
+		p = &Parser{
+			csvAttrs: make(map[string]csvAttributes),
+			buffer:   bufio.NewReader(nil),
+		}
+	}
+
+	csvAttrs := p.csvAttrs
+	for fieldName := range csvAttrs {
+		delete(csvAttrs, fieldName)
+	}
+
+	*p = Parser{
+		file:          file,
+		csvAttrs:      csvAttrs,
+		decodedRecord: p.decodedRecord,
+		pool:          pool,
+		buffer:        p.buffer,
+		options:       pool.options,
+	}
+
+	// csv.NewReader uses the buffer as it is rather than allocating its own, since it is already a bufio.Reader
+	p.buffer.Reset(file)
+	p.reader = newParserReader(p.buffer, pool.options)
+
+	if pool.optionsErr != nil {
+		p.reader = errorReader{err: pool.optionsErr}
+	}
+
+	return p
+}
+
+// ReleaseParser returns p to the pool. The parser must not be used after it has been released.
+func (pool *ParserPool) ReleaseParser(p *Parser) {
+	if p == nil || p.pool != pool {
+		return
+	}
+
+	p.file = nil
+	p.reader = nil
+	p.header = nil
+	p.peeked = nil
+	p.recordPeek = nil
+	p.peekErr = nil
+	p.rejects = nil
+	p.buffer.Reset(nil)
+
+	pool.parsers.Put(p)
+}
+
+// restoreBinding copies the binding kept for the type of structPointer to p, and reports whether there was one.
+func (pool *ParserPool) restoreBinding(p *Parser, structPointer interface{}) bool {
+	stored, ok := pool.bindings.Load(reflect.TypeOf(structPointer))
+	if !ok {
+		return false
+	}
+
+	p.restoreBinding(stored.(*Binding))
+
+	return true
+}
+
+// storeBinding keeps binding for every parser of the pool that binds its type.
+func (pool *ParserPool) storeBinding(binding *Binding) {
+	pool.bindings.Store(binding.structType, binding)
+}
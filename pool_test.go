@@ -0,0 +1,75 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParserPool(t *testing.T) {
+	pool := NewParserPool(ParserOptions{})
+
+	// the header columns are in a different order for each file, so a binding resolved against one file must not leak into the next
+	files := []string{
+		"field1,fieldTwo,Field3\nString,12,123456",
+		"Field3,field1,fieldTwo\n123456,String,12",
+	}
+
+	for _, file := range files {
+		p := pool.AcquireParser(strings.NewReader(file))
+
+		var record headerTest
+		err := p.ParseHeader(&record)
+		if err != nil {
+			t.Errorf("encountered error parsing csv header: %v", err)
+		}
+
+		err = p.ReadRecord(&record)
+		if err != nil {
+			t.Errorf("encountered error parsing csv with pooled parser: %v", err)
+		}
+
+		expected := headerTest{Field1: "String", Field2: 12, Field3: 123456}
+		if record != expected {
+			t.Errorf("expected pooled parser to read %+v, but got %+v", expected, record)
+		}
+
+		err = p.ReadRecord(&record)
+		if err != io.EOF {
+			t.Errorf("expected to encounter io.EOF, but got %v", err)
+		}
+
+		pool.ReleaseParser(p)
+	}
+}
+
+func TestParserPoolDifferentStructs(t *testing.T) {
+	pool := NewParserPool(ParserOptions{})
+
+	p := pool.AcquireParser(strings.NewReader("a,1"))
+	var peeked peekTest
+	err := p.ReadRecord(&peeked)
+	if err != nil || peeked.Section != "a" || peeked.Count != 1 {
+		t.Errorf("expected pooled parser to read {a 1}, but got %+v and error %v", peeked, err)
+	}
+	pool.ReleaseParser(p)
+
+	p = pool.AcquireParser(strings.NewReader("x,firstData,y,46"))
+	var indexed indexTest
+	err = p.ReadRecord(&indexed)
+	if err != nil || indexed != indexTestResults[0] {
+		t.Errorf("expected pooled parser to read %+v, but got %+v and error %v", indexTestResults[0], indexed, err)
+	}
+	pool.ReleaseParser(p)
+}
+
+func TestParserPoolInvalidOptions(t *testing.T) {
+	pool := NewParserPool(ParserOptions{Delimiter: '\n'})
+
+	p := pool.AcquireParser(strings.NewReader("a,1"))
+	err := p.ReadRecord(&peekTest{})
+	if !errors.Is(err, ErrorInvalidDelimiter) {
+		t.Errorf("expected to encounter ErrorInvalidDelimiter error, but got %v", err)
+	}
+}
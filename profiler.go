@@ -0,0 +1,192 @@
+package csv
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// distinctPrecision is the number of hash bits used to pick a register of a distinct count estimate. It gives 4096 registers, and an error of around 1.6%.
+const distinctPrecision = 12
+
+// ColumnProfile holds the statistics a Profiler gathered for one column.
+// Min and Max are the smallest and largest values, compared as numbers or times when every value of the column is one, and as text otherwise.
+// DistinctEstimate is an estimate of the number of distinct values that aren't empty, which is exact for small counts and within a few percent for large ones.
+type ColumnProfile struct {
+	Name             string
+	Index            int
+	Count            int
+	NullCount        int
+	Min              string
+	Max              string
+	DistinctEstimate int
+	Type             ColumnType
+	Format           string
+}
+
+// Profiler gathers statistics for every column of the records a parser reads, in the same pass as the records are parsed. Set it as the Profiler of ParserOptions,
+// and call Columns once the file has been read. Empty values are counted as nulls and otherwise ignored. A Profiler should only be used by one parser.
+type Profiler struct {
+	header  []string
+	columns []columnProfiler
+}
+
+type columnProfiler struct {
+	count     int
+	nullCount int
+	inference columnInference
+
+	minText, maxText       string
+	minNumber, maxNumber   float64
+	minNumText, maxNumText string
+	minTime, maxTime       time.Time
+	minTimeText            string
+	maxTimeText            string
+
+	registers [1 << distinctPrecision]uint8
+}
+
+// NewProfiler creates a new profiler with no statistics.
+func NewProfiler() (profiler *Profiler) {
+	return &Profiler{}
+}
+
+// observe adds the values of record to the statistics of their columns. The header is kept the first time one is available, to name the columns.
+func (profiler *Profiler) observe(header []string, record []string) {
+	if profiler.header == nil && header != nil {
+		profiler.header = append([]string(nil), header...)
+	}
+
+	for len(profiler.columns) < len(record) {
+		profiler.columns = append(profiler.columns, columnProfiler{inference: newColumnInference()})
+	}
+
+	for i, value := range record {
+		profiler.columns[i].observe(value)
+	}
+}
+
+func (cp *columnProfiler) observe(value string) {
+	cp.count++
+	if value == "" {
+		cp.nullCount++
+		return
+	}
+
+	first := !cp.inference.seen
+	cp.inference.observe(value)
+
+	// Values are cloned as they are kept, so that a single value doesn't hold on to the memory of its whole record.
+	if first || value < cp.minText {
+		cp.minText = cloneString(value)
+	}
+	if first || value > cp.maxText {
+		cp.maxText = cloneString(value)
+	}
+
+	if cp.inference.couldFloat {
+		number, _ := strconv.ParseFloat(value, 64)
+		if first || number < cp.minNumber {
+			cp.minNumber, cp.minNumText = number, cloneString(value)
+		}
+		if first || number > cp.maxNumber {
+			cp.maxNumber, cp.maxNumText = number, cloneString(value)
+		}
+	}
+
+	if cp.inference.couldTime {
+		t, _ := time.Parse(cp.inference.timeLayout, value)
+		if first || t.Before(cp.minTime) {
+			cp.minTime, cp.minTimeText = t, cloneString(value)
+		}
+		if first || t.After(cp.maxTime) {
+			cp.maxTime, cp.maxTimeText = t, cloneString(value)
+		}
+	}
+
+	h := mixHash(hashString(value))
+
+	register := h >> (64 - distinctPrecision)
+	rank := uint8(bits.LeadingZeros64(h<<distinctPrecision|1<<(distinctPrecision-1)) + 1)
+	if rank > cp.registers[register] {
+		cp.registers[register] = rank
+	}
+}
+
+// hashString returns the 64 bit FNV-1a hash of value, without the allocations of hash/fnv.
+func hashString(value string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(value); i++ {
+		h ^= uint64(value[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// mixHash spreads the bits of an FNV hash, whose high bits are poorly distributed for short values, using the finalizer of splitmix64.
+func mixHash(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// distinctEstimate estimates the number of distinct values observed with HyperLogLog, using linear counting while few registers are set.
+func (cp *columnProfiler) distinctEstimate() int {
+	m := float64(len(cp.registers))
+
+	sum := 0.0
+	zeros := 0
+	for _, rank := range cp.registers {
+		sum += math.Ldexp(1, -int(rank))
+		if rank == 0 {
+			zeros++
+		}
+	}
+
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return int(math.Round(estimate))
+}
+
+// Columns returns the statistics gathered for each column so far, in column order. Columns are named by the parsed header, if there is one.
+func (profiler *Profiler) Columns() (columns []ColumnProfile) {
+	columns = make([]ColumnProfile, len(profiler.columns))
+
+	for i := range profiler.columns {
+		cp := &profiler.columns[i]
+		column := cp.inference.column("")
+
+		profile := ColumnProfile{
+			Index:            i,
+			Count:            cp.count,
+			NullCount:        cp.nullCount,
+			Min:              cp.minText,
+			Max:              cp.maxText,
+			DistinctEstimate: cp.distinctEstimate(),
+			Type:             column.Type,
+			Format:           column.Format,
+		}
+
+		if i < len(profiler.header) {
+			profile.Name = profiler.header[i]
+		}
+
+		switch column.Type {
+		case ColumnTypeInt, ColumnTypeFloat:
+			profile.Min, profile.Max = cp.minNumText, cp.maxNumText
+		case ColumnTypeTime:
+			profile.Min, profile.Max = cp.minTimeText, cp.maxTimeText
+		}
+
+		columns[i] = profile
+	}
+
+	return columns
+}
@@ -0,0 +1,82 @@
+package csv
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+	"testing"
+)
+
+type profilerTest struct {
+	Name  string  `csv:"header:name"`
+	Score float64 `csv:"header:score"`
+}
+
+func TestProfiler(t *testing.T) {
+	data := "name,score,joined,notes\n" +
+		"bob,9.5,2021-03-04,\n" +
+		"alice,10,2020-01-02,x\n" +
+		"carol,-2,2022-12-31,\n" +
+		"bob,100,2021-03-04,y"
+
+	profiler := NewProfiler()
+	p := NewParser(strings.NewReader(data), ParserOptions{Profiler: profiler})
+
+	err := p.ParseHeader(&profilerTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	for {
+		err := p.ReadRecord(&profilerTest{})
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Errorf("encountered error parsing csv with profiler: %v", err)
+			break
+		}
+	}
+
+	expected := []ColumnProfile{
+		{Name: "name", Index: 0, Count: 4, Min: "alice", Max: "carol", DistinctEstimate: 3, Type: ColumnTypeString},
+		{Name: "score", Index: 1, Count: 4, Min: "-2", Max: "100", DistinctEstimate: 4, Type: ColumnTypeFloat},
+		{Name: "joined", Index: 2, Count: 4, Min: "2020-01-02", Max: "2022-12-31", DistinctEstimate: 3, Type: ColumnTypeTime, Format: "2006-01-02"},
+		{Name: "notes", Index: 3, Count: 4, NullCount: 2, Min: "x", Max: "y", DistinctEstimate: 2, Type: ColumnTypeString},
+	}
+
+	columns := profiler.Columns()
+	if len(columns) != len(expected) {
+		t.Errorf("expected %d column profiles, but got %d", len(expected), len(columns))
+		return
+	}
+
+	for i := range expected {
+		if columns[i] != expected[i] {
+			t.Errorf("expected column profile %+v, but got %+v", expected[i], columns[i])
+		}
+	}
+}
+
+func TestProfilerDistinctEstimate(t *testing.T) {
+	profiler := NewProfiler()
+	for i := 0; i < 100000; i++ {
+		profiler.observe(nil, []string{fmt.Sprintf("value%d", i%50000)})
+	}
+
+	estimate := profiler.Columns()[0].DistinctEstimate
+	if estimate < 47500 || estimate > 52500 {
+		t.Errorf("expected distinct estimate within 5%% of 50000, but got %d", estimate)
+	}
+}
+
+func TestHashString(t *testing.T) {
+	for _, value := range []string{"", "a", "some longer value"} {
+		hash := fnv.New64a()
+		hash.Write([]byte(value))
+		if hashString(value) != hash.Sum64() {
+			t.Errorf("expected hashString to match hash/fnv for %q", value)
+		}
+	}
+}
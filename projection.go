@@ -0,0 +1,50 @@
+package csv
+
+// selectFields returns the fields of csvAttrs that are listed in include, or every field when include is empty, leaving out those listed in exclude.
+// Fields are listed by header name or field name, and a listed name that doesn't match any field is reported as a FieldNotFoundError.
+func selectFields(csvAttrs map[string]csvAttributes, include []string, exclude []string) (selected map[string]csvAttributes, err error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return csvAttrs, nil
+	}
+
+	fieldNames := getFieldOrder(csvAttrs)
+	find := func(name string) (fieldName string, err error) {
+		for _, fieldName := range fieldNames {
+			attrs := csvAttrs[fieldName]
+			if (attrs.hasHeader && attrs.headerName == name) || fieldName == name {
+				return fieldName, nil
+			}
+		}
+
+		return "", FieldNotFoundError{
+			FieldName:  name,
+			HeaderName: name,
+			Err:        ErrorColumnNotFound,
+		}
+	}
+
+	selected = make(map[string]csvAttributes, len(csvAttrs))
+	if len(include) == 0 {
+		for fieldName, attrs := range csvAttrs {
+			selected[fieldName] = attrs
+		}
+	}
+
+	for _, name := range include {
+		fieldName, err := find(name)
+		if err != nil {
+			return nil, err
+		}
+		selected[fieldName] = csvAttrs[fieldName]
+	}
+
+	for _, name := range exclude {
+		fieldName, err := find(name)
+		if err != nil {
+			return nil, err
+		}
+		delete(selected, fieldName)
+	}
+
+	return selected, nil
+}
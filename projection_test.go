@@ -0,0 +1,65 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type projectionTest struct {
+	ID    int    `csv:"header:id"`
+	Name  string `csv:"header:name"`
+	Email string `csv:"header:email"`
+	SSN   string `csv:"header:ssn"`
+}
+
+func writeProjection(t *testing.T, options WriterOptions) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, options)
+
+	err := w.WriteHeader(&projectionTest{})
+	if err != nil {
+		t.Fatalf("encountered error writing csv header: %v", err)
+	}
+
+	err = w.WriteRecord(&projectionTest{ID: 1, Name: "Ann", Email: "ann@example.com", SSN: "123-45-6789"})
+	if err != nil {
+		t.Fatalf("encountered error writing csv record: %v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing csv writer: %v", err)
+	}
+
+	return buf.String()
+}
+
+func TestExcludeFields(t *testing.T) {
+	got := writeProjection(t, WriterOptions{ExcludeFields: []string{"ssn", "Email"}})
+
+	expected := "id,name\n1,Ann\n"
+	if got != expected {
+		t.Errorf("improperly excluded fields. Got '%s' but expected '%s'", got, expected)
+	}
+}
+
+func TestIncludeFields(t *testing.T) {
+	got := writeProjection(t, WriterOptions{IncludeFields: []string{"email", "id", "name"}, ExcludeFields: []string{"name"}})
+
+	expected := "id,email\n1,ann@example.com\n"
+	if got != expected {
+		t.Errorf("expected included fields to keep their usual layout. Got '%s' but expected '%s'", got, expected)
+	}
+}
+
+func TestSelectFieldsNotFound(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, WriterOptions{ExcludeFields: []string{"phone"}})
+
+	err := w.WriteRecord(&projectionTest{})
+	if !errors.Is(err, ErrorColumnNotFound) {
+		t.Errorf("expected %v, but got %v", ErrorColumnNotFound, err)
+	}
+}
@@ -0,0 +1,292 @@
+package csv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrorInvalidQuoteChar  = fmt.Errorf("quote character may not be a line break, an invalid rune, the delimiter or the comment character")
+	ErrorInvalidEscapeChar = fmt.Errorf("escape character may not be a line break, an invalid rune, the delimiter, the comment character or the quote character")
+)
+
+// customQuoting reports whether options quote or escape values differently from the standard csv reader, so that records are read by a quoteReader.
+func customQuoting(options ParserOptions) bool {
+	return (options.QuoteChar != 0 && options.QuoteChar != '"') || options.EscapeChar != 0 || options.Dialect.unquoted()
+}
+
+// newRecordReader creates the reader of the records of file, which is the standard csv reader unless options quote or escape values differently.
+func newRecordReader(file io.Reader, options ParserOptions) (reader filteredRecordReader) {
+	options = options.withDialect()
+	if !customQuoting(options) {
+		return newCsvReader(file, options)
+	}
+
+	if _, filtered := file.(*lineFilter); !filtered && (options.CommentPrefix != "" || options.InlineComments) {
+		file = newLineFilter(file, options)
+	}
+
+	return newQuoteReader(file, options)
+}
+
+// filteredRecordReader is a record reader that can report where its records start, so that a line filter can be applied to it. It is implemented by *csv.Reader and quoteReader.
+type filteredRecordReader interface {
+	RecordReader
+	inputOffsetReader
+	FieldPos(field int) (line, column int)
+}
+
+// allowRaggedRecords lets reader read records with differing numbers of columns, if it is one of the readers created from ParserOptions.
+func allowRaggedRecords(reader RecordReader) {
+	switch reader := reader.(type) {
+	case *csv.Reader:
+		reader.FieldsPerRecord = -1
+	case *quoteReader:
+		reader.fieldsPerRecord = -1
+	case *mappedReader:
+		reader.fieldsPerRecord = -1
+	case *lineFilterReader:
+		allowRaggedRecords(reader.reader)
+	}
+}
+
+// invalidByte is added to a byte of the input that isn't valid UTF-8 to return it from quoteReader.next as a rune outside the range of Unicode, so that it can't be mistaken for a
+// special character, and is written back to the value as the same byte.
+const invalidByte = utf8.MaxRune + 1
+
+// quoteReader reads csv records whose values are quoted with a quote character other than a double quote, or whose special characters are escaped with an escape character,
+// such as the files written by MySQL's SELECT ... INTO OUTFILE. It otherwise reads records as the standard csv reader does: lines starting with the comment character
+// and blank lines are skipped, a doubled quote character inside a quoted value stands for one quote character, and every record must have as many columns as the first.
+type quoteReader struct {
+	source          *bufio.Reader
+	delimiter       rune
+	quote           rune
+	escape          rune
+	comment         rune
+	fieldsPerRecord int
+	offset          int64
+	line            int
+	recordLine      int
+}
+
+func newQuoteReader(file io.Reader, options ParserOptions) (reader *quoteReader) {
+	reader = &quoteReader{
+		source:    bufio.NewReader(file),
+		delimiter: ',',
+		quote:     '"',
+		escape:    options.EscapeChar,
+		comment:   options.CommentChar,
+	}
+
+	if legalDelimiter(options.Delimiter) {
+		reader.delimiter = options.Delimiter
+	}
+
+	if options.QuoteChar != 0 {
+		reader.quote = options.QuoteChar
+	} else if options.Dialect.unquoted() {
+		reader.quote = 0
+	}
+
+	return reader
+}
+
+func (r *quoteReader) Read() (record []string, err error) {
+	for record == nil {
+		record, err = r.readLine()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.fieldsPerRecord == 0 {
+		r.fieldsPerRecord = len(record)
+	} else if r.fieldsPerRecord > 0 && len(record) != r.fieldsPerRecord {
+		return record, &csv.ParseError{StartLine: r.recordLine, Line: r.recordLine, Column: 1, Err: csv.ErrFieldCount}
+	}
+
+	return record, nil
+}
+
+// InputOffset returns the number of bytes of input read so far, which is the offset of the start of the next record.
+func (r *quoteReader) InputOffset() int64 {
+	return r.offset
+}
+
+// FieldPos returns the line the most recently read record starts on. Columns aren't tracked, so the column is always 1.
+func (r *quoteReader) FieldPos(field int) (line, column int) {
+	return r.recordLine, 1
+}
+
+// next reads the next rune of the input. A byte that isn't valid UTF-8 is returned as invalidByte plus the byte, so that values keep the bytes they were read from.
+func (r *quoteReader) next() (c rune, err error) {
+	c, size, err := r.source.ReadRune()
+	r.offset += int64(size)
+
+	if c == utf8.RuneError && size == 1 {
+		_ = r.source.UnreadRune()
+		b, _ := r.source.ReadByte()
+		c = invalidByte + rune(b)
+	}
+
+	return c, err
+}
+
+// writeRune writes c, as returned by next, to field.
+func writeRune(field *strings.Builder, c rune) {
+	if c >= invalidByte {
+		field.WriteByte(byte(c - invalidByte))
+		return
+	}
+
+	field.WriteRune(c)
+}
+
+// peek reports whether the next rune of the input is want, and reads it if it is.
+func (r *quoteReader) peek(want rune) (ok bool) {
+	c, size, err := r.source.ReadRune()
+	if err != nil {
+		return false
+	}
+
+	if c != want {
+		_ = r.source.UnreadRune()
+		return false
+	}
+
+	r.offset += int64(size)
+
+	return true
+}
+
+// unescape returns the character an escape sequence stands for, following the escape sequences of MySQL and PostgreSQL.
+func unescape(c rune) rune {
+	switch c {
+	case '0':
+		return 0
+	case 'b':
+		return '\b'
+	case 'f':
+		return '\f'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'v':
+		return '\v'
+	case 'Z':
+		return 0x1a
+	}
+
+	return c
+}
+
+// readLine reads the record on the next line, along with any further lines that quoted or escaped line breaks continue it onto.
+// It returns a nil record for a blank line or a comment.
+func (r *quoteReader) readLine() (record []string, err error) {
+	r.line++
+	r.recordLine = r.line
+
+	var field strings.Builder
+	fieldStart := true
+	quoted := false
+	closed := false
+	null := false
+	empty := true
+
+	endField := func() {
+		value := field.String()
+		if null && value == "N" {
+			// An escaped N on its own is MySQL's NULL, which is read as an empty value.
+			value = ""
+		}
+
+		record = append(record, value)
+		field.Reset()
+		fieldStart, closed, null = true, false, false
+	}
+
+	for {
+		c, err := r.next()
+		if err == io.EOF {
+			if quoted {
+				return nil, &csv.ParseError{StartLine: r.recordLine, Line: r.line, Column: 1, Err: csv.ErrQuote}
+			}
+			if empty {
+				return nil, io.EOF
+			}
+
+			endField()
+			return record, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if empty && !quoted {
+			if c == '\n' || (c == '\r' && r.peek('\n')) {
+				return nil, nil
+			}
+
+			if r.comment != 0 && c == r.comment {
+				for c != '\n' {
+					c, err = r.next()
+					if err != nil {
+						break
+					}
+				}
+				return nil, nil
+			}
+		}
+		empty = false
+
+		switch {
+		case r.escape != 0 && c == r.escape:
+			escaped, err := r.next()
+			if err != nil {
+				writeRune(&field, c)
+				continue
+			}
+
+			if escaped == '\n' {
+				r.line++
+			}
+			null = fieldStart && !quoted && escaped == 'N'
+			writeRune(&field, unescape(escaped))
+			fieldStart = false
+		case quoted && c == r.quote:
+			if r.peek(r.quote) {
+				writeRune(&field, c)
+				continue
+			}
+			quoted, closed = false, true
+		case quoted:
+			if c == '\r' && r.peek('\n') {
+				c = '\n'
+			}
+			if c == '\n' {
+				r.line++
+			}
+			writeRune(&field, c)
+		case c == r.delimiter:
+			endField()
+		case c == '\n' || (c == '\r' && r.peek('\n')):
+			endField()
+			return record, nil
+		case closed:
+			return nil, &csv.ParseError{StartLine: r.recordLine, Line: r.line, Column: 1, Err: csv.ErrQuote}
+		case fieldStart && r.quote != 0 && c == r.quote:
+			quoted = true
+			fieldStart = false
+		default:
+			writeRune(&field, c)
+			fieldStart = false
+		}
+	}
+}
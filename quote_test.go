@@ -0,0 +1,139 @@
+package csv
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readAllRecords(reader RecordReader) (records [][]string, err error) {
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+func TestQuoteChar(t *testing.T) {
+	reader := newParserReader(strings.NewReader("name,note\n'Widget, large','it''s \"big\"'\n\n'two\nlines',x\n"), ParserOptions{QuoteChar: '\''})
+
+	records, err := readAllRecords(reader)
+	if err != nil {
+		t.Fatalf("encountered error reading single quoted values: %v", err)
+	}
+
+	expected := [][]string{{"name", "note"}, {"Widget, large", `it's "big"`}, {"two\nlines", "x"}}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("improperly read single quoted values. Expected '%q', but got '%q'", expected, records)
+	}
+}
+
+func TestEscapeChar(t *testing.T) {
+	// The default format of MySQL's SELECT ... INTO OUTFILE, which separates values with tabs and escapes special characters with a backslash.
+	file := "1\tsays \\\"hi\\\"\t\\N\n2\ttab\\there\\\nnext line\tN\n3\ta\\\\b\t\n"
+	reader := newParserReader(strings.NewReader(file), ParserOptions{Delimiter: '\t', EscapeChar: '\\'})
+
+	records, err := readAllRecords(reader)
+	if err != nil {
+		t.Fatalf("encountered error reading escaped values: %v", err)
+	}
+
+	expected := [][]string{{"1", `says "hi"`, ""}, {"2", "tab\there\nnext line", "N"}, {"3", `a\b`, ""}}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("improperly read escaped values. Expected '%q', but got '%q'", expected, records)
+	}
+}
+
+func TestEscapeCharQuoted(t *testing.T) {
+	type escaped struct {
+		ID   int    `csv:"header:id"`
+		Note string `csv:"header:note"`
+	}
+
+	p := NewParser(strings.NewReader("id,note\n1,\"a \\\"quoted\\\", value\"\n"), ParserOptions{EscapeChar: '\\'})
+
+	var record escaped
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Fatalf("encountered error parsing the header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != nil || record.Note != `a "quoted", value` {
+		t.Errorf("improperly read an escaped quote in a quoted value. Got '%+v' and error %v", record, err)
+	}
+}
+
+func TestQuoteCharErrors(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected error
+	}{
+		{"a,'b\n", csv.ErrQuote},
+		{"a,'b'c\n", csv.ErrQuote},
+		{"a,b\nc\n", csv.ErrFieldCount},
+	}
+
+	for _, test := range tests {
+		_, err := readAllRecords(newParserReader(strings.NewReader(test.file), ParserOptions{QuoteChar: '\''}))
+
+		var parseError *csv.ParseError
+		if !errors.As(err, &parseError) || !errors.Is(err, test.expected) {
+			t.Errorf("expected reading '%s' to give %v error, but got %v", test.file, test.expected, err)
+		}
+	}
+}
+
+func TestQuoteCharComments(t *testing.T) {
+	file := "# a comment\n'a # not a comment',b # a comment\n\n'c',\\'d\n"
+	reader := newParserReader(strings.NewReader(file), ParserOptions{
+		QuoteChar:      '\'',
+		EscapeChar:     '\\',
+		CommentChar:    '#',
+		InlineComments: true,
+		BlankLines:     BlankLineError,
+	})
+
+	record, err := reader.Read()
+	if err != nil || !reflect.DeepEqual(record, []string{"a # not a comment", "b"}) {
+		t.Errorf("improperly read a quoted comment character. Got '%q' and error %v", record, err)
+	}
+
+	_, err = reader.Read()
+	if !errors.Is(err, ErrorBlankLine) {
+		t.Errorf("expected to encounter ErrorBlankLine error, but got %v", err)
+	}
+
+	record, err = reader.Read()
+	if err != nil || !reflect.DeepEqual(record, []string{"c", "'d"}) {
+		t.Errorf("improperly read an escaped quote character. Got '%q' and error %v", record, err)
+	}
+}
+
+func TestQuoteCharValidate(t *testing.T) {
+	tests := []struct {
+		options  ParserOptions
+		expected error
+	}{
+		{ParserOptions{QuoteChar: ','}, ErrorInvalidQuoteChar},
+		{ParserOptions{QuoteChar: '\n'}, ErrorInvalidQuoteChar},
+		{ParserOptions{EscapeChar: '"'}, ErrorInvalidEscapeChar},
+		{ParserOptions{QuoteChar: '\'', EscapeChar: '\''}, ErrorInvalidEscapeChar},
+		{ParserOptions{QuoteChar: '\'', EscapeChar: '\\'}, nil},
+	}
+
+	for _, test := range tests {
+		err := test.options.Validate()
+		if !errors.Is(err, test.expected) {
+			t.Errorf("expected options '%+v' to give %v error, but got %v", test.options, test.expected, err)
+		}
+	}
+}
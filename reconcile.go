@@ -0,0 +1,233 @@
+package csv
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrorRecordCountMismatch      = fmt.Errorf("record count doesn't match the trailer")
+	ErrorColumnSumMismatch        = fmt.Errorf("column sum doesn't match the trailer")
+	ErrorHashMismatch             = fmt.Errorf("record hash doesn't match the trailer")
+	ErrorMissingTrailer           = fmt.Errorf("file ended without a trailer to reconcile against")
+	ErrorInvalidReconcileValue    = fmt.Errorf("value is not a number")
+	ErrorReconcileWithoutFooter   = fmt.Errorf("reconciliation needs FooterRows or IsFooter to find the trailer")
+	ErrorInvalidReconcileColumn   = fmt.Errorf("reconciliation columns must not be negative")
+	ErrorReconcileWithoutAnyCheck = fmt.Errorf("reconciliation needs at least one of CheckCount, CheckSum or CheckHash")
+)
+
+// Reconciliation compares the records of a file with the totals declared in its trailer row, as is standard practice in financial file exchange,
+// so that truncated or altered files are caught. Columns are zero based, whatever the IndexBase option is.
+type Reconciliation struct {
+	// CheckCount compares the number of records read with the number in CountColumn of the trailer.
+	CheckCount bool
+	// CountColumn is the column of the trailer that holds the number of records.
+	CountColumn int
+	// CheckSum compares the sum of SumColumn of the records with the number in SumTrailerColumn of the trailer. Sums are exact, so decimal amounts such as 0.10 add up as expected.
+	CheckSum bool
+	// SumColumn is the column of the records whose values are summed. Empty values count as zero.
+	SumColumn int
+	// SumTrailerColumn is the column of the trailer that holds the sum.
+	SumTrailerColumn int
+	// CheckHash compares the SHA-256 hash of the records with the hex encoded hash in HashColumn of the trailer. The hash is taken over the records written as comma separated lines,
+	// quoted as encoding/csv quotes them, each ending with a line feed.
+	CheckHash bool
+	// HashColumn is the column of the trailer that holds the hash.
+	HashColumn int
+	// IsTrailer picks the trailer out of the footer rows, when there are several. When it is nil, every footer row is reconciled against.
+	IsTrailer func(record []string) bool
+}
+
+// ReconciliationError is returned when the records of a file don't match the totals declared in its trailer, or when the file has no trailer.
+// Declared is the value of the trailer, and Actual the value of the records that were read.
+type ReconciliationError struct {
+	Line     int
+	Declared string
+	Actual   string
+	Err      error
+}
+
+func (e ReconciliationError) Error() string {
+	if e.Declared == "" && e.Actual == "" {
+		return fmt.Sprintf("problem reconciling the trailer on line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("trailer on line %d declares %s, but the records have %s: %v", e.Line, e.Declared, e.Actual, e.Err)
+}
+
+func (e ReconciliationError) Unwrap() error { return e.Err }
+
+// reconcileState holds the totals of the records read so far.
+type reconcileState struct {
+	count   int
+	sum     *big.Rat
+	hash    hash.Hash
+	encoder *csv.Writer
+	checked bool
+}
+
+// validate reports whether the reconciliation can be used with options.
+func (r *Reconciliation) validate(options ParserOptions) (err error) {
+	if !r.CheckCount && !r.CheckSum && !r.CheckHash {
+		return ErrorReconcileWithoutAnyCheck
+	}
+
+	if options.FooterRows == 0 && options.IsFooter == nil {
+		return ErrorReconcileWithoutFooter
+	}
+
+	if r.CountColumn < 0 || r.SumColumn < 0 || r.SumTrailerColumn < 0 || r.HashColumn < 0 {
+		return ErrorInvalidReconcileColumn
+	}
+
+	return nil
+}
+
+// observeRecord adds record to the totals that are reconciled against the trailer.
+func (p *Parser) observeRecord(record []string) (err error) {
+	reconcile := p.options.Reconcile
+	if reconcile == nil {
+		return nil
+	}
+
+	totals := p.reconcileTotals()
+	totals.count++
+
+	if reconcile.CheckSum && reconcile.SumColumn < len(record) {
+		value := strings.TrimSpace(record[reconcile.SumColumn])
+		if value != "" {
+			number, ok := new(big.Rat).SetString(value)
+			if !ok {
+				return RecordError{
+					Line: p.line + 1,
+					Err:  ErrorInvalidReconcileValue,
+				}
+			}
+			totals.sum.Add(totals.sum, number)
+		}
+	}
+
+	if reconcile.CheckHash {
+		err = totals.encoder.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileTrailer compares the totals of the records read so far with those declared in trailer, which is on line.
+func (p *Parser) reconcileTrailer(trailer []string, line int) (err error) {
+	reconcile := p.options.Reconcile
+	if reconcile == nil || (reconcile.IsTrailer != nil && !reconcile.IsTrailer(trailer)) {
+		return nil
+	}
+
+	totals := p.reconcileTotals()
+	totals.checked = true
+
+	declared := func(column int) (value string, err error) {
+		if column >= len(trailer) {
+			return "", ReconciliationError{
+				Line: line,
+				Err:  ErrorColumnOutOfRange,
+			}
+		}
+		return strings.TrimSpace(trailer[column]), nil
+	}
+
+	if reconcile.CheckCount {
+		value, err := declared(reconcile.CountColumn)
+		if err != nil {
+			return err
+		}
+
+		actual := strconv.Itoa(totals.count)
+		if value != actual {
+			return ReconciliationError{
+				Line:     line,
+				Declared: value,
+				Actual:   actual,
+				Err:      ErrorRecordCountMismatch,
+			}
+		}
+	}
+
+	if reconcile.CheckSum {
+		value, err := declared(reconcile.SumTrailerColumn)
+		if err != nil {
+			return err
+		}
+
+		number, ok := new(big.Rat).SetString(value)
+		if !ok {
+			return ReconciliationError{
+				Line: line,
+				Err:  ErrorInvalidReconcileValue,
+			}
+		}
+
+		if number.Cmp(totals.sum) != 0 {
+			decimals := 0
+			if point := strings.IndexByte(value, '.'); point >= 0 {
+				decimals = len(value) - point - 1
+			}
+
+			return ReconciliationError{
+				Line:     line,
+				Declared: value,
+				Actual:   totals.sum.FloatString(decimals),
+				Err:      ErrorColumnSumMismatch,
+			}
+		}
+	}
+
+	if reconcile.CheckHash {
+		value, err := declared(reconcile.HashColumn)
+		if err != nil {
+			return err
+		}
+
+		totals.encoder.Flush()
+		actual := hex.EncodeToString(totals.hash.Sum(nil))
+		if !strings.EqualFold(value, actual) {
+			return ReconciliationError{
+				Line:     line,
+				Declared: value,
+				Actual:   actual,
+				Err:      ErrorHashMismatch,
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconcileTotals returns the totals of the records read so far, creating them on first use.
+func (p *Parser) reconcileTotals() *reconcileState {
+	if p.reconciled == nil {
+		p.reconciled = &reconcileState{sum: new(big.Rat), hash: sha256.New()}
+		p.reconciled.encoder = csv.NewWriter(p.reconciled.hash)
+	}
+
+	return p.reconciled
+}
+
+// checkTrailerSeen returns a ReconciliationError at the end of the file if no trailer was reconciled against, and err otherwise.
+func (p *Parser) checkTrailerSeen(err error) error {
+	if err != io.EOF || p.options.Reconcile == nil || (p.reconciled != nil && p.reconciled.checked) {
+		return err
+	}
+
+	return ReconciliationError{
+		Line: p.line,
+		Err:  ErrorMissingTrailer,
+	}
+}
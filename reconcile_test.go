@@ -0,0 +1,129 @@
+package csv
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type reconcileAmount struct {
+	ID     int    `csv:"header:id"`
+	Amount string `csv:"header:amount"`
+}
+
+func readReconciled(file string, options ParserOptions) (count int, err error) {
+	p := NewParser(strings.NewReader(file), options)
+
+	var amount reconcileAmount
+	err = p.ParseHeader(&amount)
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		err = p.ReadRecord(&amount)
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+var reconcileTrailer = &Reconciliation{
+	CheckCount:       true,
+	CountColumn:      1,
+	CheckSum:         true,
+	SumColumn:        1,
+	SumTrailerColumn: 2,
+}
+
+func TestReconcile(t *testing.T) {
+	count, err := readReconciled("id,amount\n1,0.10\n2,0.20\n3,\nT,3,0.30\n", ParserOptions{
+		FooterRows: 1,
+		Reconcile:  reconcileTrailer,
+	})
+	if err != nil || count != 3 {
+		t.Errorf("expected to read 3 records matching the trailer, but read %d and got error %v", count, err)
+	}
+}
+
+func TestReconcileMismatch(t *testing.T) {
+	_, err := readReconciled("id,amount\n1,0.10\n2,0.20\nT,3,0.30\n", ParserOptions{
+		FooterRows: 1,
+		Reconcile:  reconcileTrailer,
+	})
+
+	var reconciliationError ReconciliationError
+	if !errors.As(err, &reconciliationError) || !errors.Is(err, ErrorRecordCountMismatch) {
+		t.Fatalf("expected to encounter ErrorRecordCountMismatch error, but got %v", err)
+	}
+	if reconciliationError.Line != 3 || reconciliationError.Declared != "3" || reconciliationError.Actual != "2" {
+		t.Errorf("improperly reported the mismatch. Got '%+v'", reconciliationError)
+	}
+
+	_, err = readReconciled("id,amount\n1,0.10\n2,0.25\nT,2,0.30\n", ParserOptions{
+		FooterRows: 1,
+		Reconcile:  reconcileTrailer,
+	})
+	if !errors.As(err, &reconciliationError) || !errors.Is(err, ErrorColumnSumMismatch) || reconciliationError.Actual != "0.35" {
+		t.Errorf("expected to encounter ErrorColumnSumMismatch error with a sum of 0.35, but got %v", err)
+	}
+}
+
+func TestReconcileHash(t *testing.T) {
+	sum := sha256.Sum256([]byte("1,\"a,b\"\n2,c\n"))
+	file := "id,amount\n1,\"a,b\"\n2,c\nTRAILER," + hex.EncodeToString(sum[:]) + "\n"
+
+	options := ParserOptions{
+		IsFooter: func(record []string) bool {
+			return record[0] == "TRAILER"
+		},
+		Reconcile: &Reconciliation{CheckHash: true, HashColumn: 1},
+	}
+
+	count, err := readReconciled(file, options)
+	if err != nil || count != 2 {
+		t.Errorf("expected to read 2 records matching the trailer hash, but read %d and got error %v", count, err)
+	}
+
+	_, err = readReconciled(strings.Replace(file, "2,c", "2,d", 1), options)
+	if !errors.Is(err, ErrorHashMismatch) {
+		t.Errorf("expected to encounter ErrorHashMismatch error, but got %v", err)
+	}
+}
+
+func TestReconcileMissingTrailer(t *testing.T) {
+	_, err := readReconciled("id,amount\n1,0.10\n", ParserOptions{
+		IsFooter: func(record []string) bool {
+			return record[0] == "T"
+		},
+		Reconcile: reconcileTrailer,
+	})
+	if !errors.Is(err, ErrorMissingTrailer) {
+		t.Errorf("expected to encounter ErrorMissingTrailer error, but got %v", err)
+	}
+}
+
+func TestReconcileValidate(t *testing.T) {
+	tests := []struct {
+		options  ParserOptions
+		expected error
+	}{
+		{ParserOptions{FooterRows: 1, Reconcile: &Reconciliation{}}, ErrorReconcileWithoutAnyCheck},
+		{ParserOptions{Reconcile: &Reconciliation{CheckCount: true}}, ErrorReconcileWithoutFooter},
+		{ParserOptions{FooterRows: 1, Reconcile: &Reconciliation{CheckCount: true, CountColumn: -1}}, ErrorInvalidReconcileColumn},
+	}
+
+	for _, test := range tests {
+		err := test.options.Validate()
+		if !errors.Is(err, test.expected) {
+			t.Errorf("expected to encounter %v error, but got %v", test.expected, err)
+		}
+	}
+}
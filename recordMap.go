@@ -0,0 +1,153 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+var (
+	ErrorNoMapColumns = fmt.Errorf("columns must be listed in the writer options to write maps")
+)
+
+// ReadRecordMap reads the next line of the parser's csv file and returns its values keyed by header label.
+// If the header has not been parsed yet, the first line of the file is read as the header before the record is read.
+// When a header label appears more than once, the value of the first column with that label is used.
+func (p *Parser) ReadRecordMap() (record map[string]string, err error) {
+	if p.header == nil {
+		_, err = p.readHeader()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	readRecord, err := p.readNextRecord()
+	if err != nil {
+		return nil, err
+	}
+
+	record = make(map[string]string, len(p.header))
+	for idx, headerLabel := range p.header {
+		if _, ok := record[headerLabel]; ok || idx >= len(readRecord) {
+			continue
+		}
+		record[headerLabel] = readRecord[idx]
+	}
+
+	return record, nil
+}
+
+// ReadRecordMapTyped reads the next line of the parser's csv file like ReadRecordMap, converting the values of the columns named in types
+// to the given types with the same rules used to set struct fields, including any converters. Columns not named in types are returned as strings.
+func (p *Parser) ReadRecordMapTyped(types map[string]reflect.Type) (record map[string]interface{}, err error) {
+	stringRecord, err := p.ReadRecordMap()
+	if err != nil {
+		return nil, err
+	}
+
+	record = make(map[string]interface{}, len(stringRecord))
+	for headerLabel, value := range stringRecord {
+		t, ok := types[headerLabel]
+		if !ok {
+			record[headerLabel] = value
+			continue
+		}
+
+		record[headerLabel], err = p.convertValue(t, value)
+		if err != nil {
+			return nil, SetValueError{
+				Line:      p.line,
+				Value:     value,
+				FieldName: headerLabel,
+				Err:       err,
+			}
+		}
+	}
+
+	return record, nil
+}
+
+// convertValue converts value to a new value of type t.
+func (p *Parser) convertValue(t reflect.Type, value string) (converted interface{}, err error) {
+	var attrs csvAttributes
+	if converter, ok := lookupConverter(t, p.options.Converters); ok {
+		attrs.converter = &converter
+	}
+
+	field := reflect.New(t).Elem()
+	err = p.setValue(field, attrs, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return field.Interface(), nil
+}
+
+// MapColumns returns the union of the keys of records, sorted, for use as the Columns of a writer when the columns of dynamic data aren't known in advance.
+func MapColumns(records []map[string]string) (columns []string) {
+	seen := make(map[string]bool)
+	for _, record := range records {
+		for key := range record {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+
+	sort.Strings(columns)
+
+	return columns
+}
+
+// WriteHeaderMap writes a header line to the writer's csv file using the Columns listed in the writer options, for writing records with WriteRecordMap.
+func (w *Writer) WriteHeaderMap() (err error) {
+	if len(w.options.Columns) == 0 && w.optionsErr == nil {
+		return ErrorNoMapColumns
+	}
+
+	if w.options.HeaderCase == HeaderCaseUnchanged {
+		return w.writeRaw(w.options.Columns)
+	}
+
+	header := make([]string, len(w.options.Columns))
+	for idx, column := range w.options.Columns {
+		header[idx] = w.options.HeaderCase.apply(column)
+	}
+
+	return w.writeRaw(header)
+}
+
+// WriteRecordMap writes the values of record to the next line of the writer's csv file, in the order of the Columns listed in the writer options.
+// Columns missing from record are written as empty values, and a key that isn't listed is reported as a GetValueError rather than silently dropped.
+func (w *Writer) WriteRecordMap(record map[string]string) (err error) {
+	if len(w.options.Columns) == 0 && w.optionsErr == nil {
+		return ErrorNoMapColumns
+	}
+
+	w.line++
+
+	if w.mapColumns == nil {
+		w.mapColumns = make(map[string]int, len(w.options.Columns))
+		for idx, column := range w.options.Columns {
+			w.mapColumns[column] = idx
+		}
+	}
+
+	for key := range record {
+		if _, ok := w.mapColumns[key]; !ok {
+			return GetValueError{
+				Line:      w.line,
+				FieldName: key,
+				Err:       ErrorColumnNotFound,
+			}
+		}
+	}
+
+	values := make([]string, len(w.options.Columns))
+	for idx, column := range w.options.Columns {
+		values[idx] = record[column]
+	}
+
+	return w.writeRecordValues(values, nil)
+}
@@ -0,0 +1,110 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadRecordMap(t *testing.T) {
+	p := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+
+	expected := []map[string]string{
+		{"field1": "String", "fieldTwo": "12", "uselessGarbage": "asdf65434", "Field3": "123456"},
+		{"field1": "OtherString", "fieldTwo": "14", "uselessGarbage": " f8jf8j", "Field3": "48484848"},
+	}
+
+	for i := 0; true; i++ {
+		record, err := p.ReadRecordMap()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Errorf("encountered error reading csv record map: %v", err)
+			break
+		}
+
+		if !reflect.DeepEqual(record, expected[i]) {
+			t.Errorf("improperly read record map. Got '%v' but expected '%v'", record, expected[i])
+		}
+	}
+}
+
+func TestReadRecordMapTyped(t *testing.T) {
+	p := NewParser(strings.NewReader(headerTestData), ParserOptions{})
+	types := map[string]reflect.Type{
+		"fieldTwo": reflect.TypeOf(int(0)),
+		"Field3":   reflect.TypeOf(float64(0)),
+	}
+
+	record, err := p.ReadRecordMapTyped(types)
+	if err != nil {
+		t.Errorf("encountered error reading typed csv record map: %v", err)
+	}
+
+	expected := map[string]interface{}{"field1": "String", "fieldTwo": 12, "uselessGarbage": "asdf65434", "Field3": float64(123456)}
+	if !reflect.DeepEqual(record, expected) {
+		t.Errorf("improperly read typed record map. Got '%v' but expected '%v'", record, expected)
+	}
+
+	types["field1"] = reflect.TypeOf(int(0))
+	_, err = p.ReadRecordMapTyped(types)
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.FieldName != "field1" || setValueErr.Line != 2 {
+		t.Errorf("expected a SetValueError for field1 on line 2, but got %v", err)
+	}
+}
+
+func TestWriteRecordMap(t *testing.T) {
+	records := []map[string]string{
+		{"name": "Widget", "price": "9.99"},
+		{"name": "Gadget", "color": "red"},
+	}
+
+	var output strings.Builder
+	w := NewWriter(&output, WriterOptions{Columns: MapColumns(records)})
+
+	err := w.WriteHeaderMap()
+	if err != nil {
+		t.Errorf("encountered error writing map header: %v", err)
+	}
+
+	for _, record := range records {
+		err = w.WriteRecordMap(record)
+		if err != nil {
+			t.Errorf("encountered error writing record map: %v", err)
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		t.Errorf("encountered error flushing writer: %v", err)
+	}
+
+	expected := "color,name,price\n,Widget,9.99\nred,Gadget,\n"
+	if output.String() != expected {
+		t.Errorf("improperly wrote record maps. Got '%v' but expected '%v'", output.String(), expected)
+	}
+
+	err = w.WriteRecordMap(map[string]string{"size": "L"})
+	if !errors.Is(err, ErrorColumnNotFound) {
+		t.Errorf("expected to encounter ErrorColumnNotFound error, but got %v", err)
+	}
+
+	var getValueErr GetValueError
+	if !errors.As(err, &getValueErr) || getValueErr.FieldName != "size" || getValueErr.Line != 3 {
+		t.Errorf("expected GetValueError for key size on line 3, but got %v", err)
+	}
+}
+
+func TestWriteRecordMapNoColumns(t *testing.T) {
+	w := NewWriter(io.Discard, WriterOptions{})
+
+	err := w.WriteRecordMap(map[string]string{"name": "Widget"})
+	if !errors.Is(err, ErrorNoMapColumns) {
+		t.Errorf("expected to encounter ErrorNoMapColumns error, but got %v", err)
+	}
+}
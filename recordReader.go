@@ -0,0 +1,18 @@
+package csv
+
+// RecordReader supplies the records a Parser binds to structs. It is satisfied by *csv.Reader from the standard library,
+// and can be implemented to parse records with a custom tokenizer, such as one for multi-character delimiters, or to hand over records that are already split, such as messages from a queue.
+// Read should return io.EOF when there are no more records. A returned record may be reused by the next call to Read.
+type RecordReader interface {
+	Read() (record []string, err error)
+}
+
+// NewRecordParser creates a new parser that reads its records from reader and supports the csv struct decorator tag.
+// The Delimiter, CommentChar, QuoteChar, EscapeChar and ReuseRecord options only configure the reader created by NewParser, so they have no effect here.
+func NewRecordParser(reader RecordReader, options ParserOptions) (p Parser) {
+	p.reader = reader
+	p.csvAttrs = make(map[string]csvAttributes)
+	p.options = options.withDialect()
+
+	return p
+}
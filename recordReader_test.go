@@ -0,0 +1,63 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// multiCharReader splits lines on a multi-character delimiter, which encoding/csv doesn't support.
+type multiCharReader struct {
+	lines []string
+	delim string
+}
+
+func (r *multiCharReader) Read() (record []string, err error) {
+	if len(r.lines) == 0 {
+		return nil, io.EOF
+	}
+
+	line := r.lines[0]
+	r.lines = r.lines[1:]
+
+	return strings.Split(line, r.delim), nil
+}
+
+type recordReaderTest struct {
+	Name  string `csv:"header:name"`
+	Count int    `csv:"header:count"`
+}
+
+func TestRecordParser(t *testing.T) {
+	reader := &multiCharReader{
+		lines: []string{"count||name", "3||widget", "x||gizmo"},
+		delim: "||",
+	}
+	p := NewRecordParser(reader, ParserOptions{})
+
+	err := p.ParseHeader(&recordReaderTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing header from record reader: %v", err)
+	}
+
+	var record recordReaderTest
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading record from record reader: %v", err)
+	}
+	if record.Name != "widget" || record.Count != 3 {
+		t.Errorf("improperly read record from record reader. Got '%+v'", record)
+	}
+
+	err = p.ReadRecord(&record)
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 2 {
+		t.Errorf("expected a SetValueError on line 2, but got %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != io.EOF {
+		t.Errorf("expected to reach the end of the records, but got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package csv
+
+import (
+	"encoding/csv"
+)
+
+const rejectReasonColumn = "reason"
+
+// reject writes readRecord to the parser's RejectWriter with the reason it was rejected, writing the header first if this is the first rejected record.
+// The reason comes first, so that it stays in its column whatever the number of values in the record.
+func (p *Parser) reject(readRecord []string, reason error) (err error) {
+	if p.rejects == nil {
+		p.rejects = csv.NewWriter(p.options.RejectWriter)
+		if legalDelimiter(p.options.Delimiter) {
+			p.rejects.Comma = p.options.Delimiter
+		}
+
+		if p.header != nil {
+			err = p.rejects.Write(append([]string{rejectReasonColumn}, p.header...))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = p.rejects.Write(append([]string{reason.Error()}, readRecord...))
+	if err != nil {
+		return err
+	}
+
+	// Rejects are flushed as they are written, so the file is complete however the import ends.
+	p.rejects.Flush()
+	return p.rejects.Error()
+}
@@ -0,0 +1,88 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRejectWriter(t *testing.T) {
+	var rejects bytes.Buffer
+	p := NewParser(strings.NewReader("field1,fieldTwo,Field3\na,1,2\n\"b, c\",x,4\nd,5,6"), ParserOptions{RejectWriter: &rejects})
+
+	err := p.ParseHeader(&headerTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	var setValueErr SetValueError
+	read := 0
+	for {
+		err := p.ReadRecord(&headerTest{})
+		if err == io.EOF {
+			break
+		}
+		if err != nil && !errors.As(err, &setValueErr) {
+			t.Errorf("expected only SetValueErrors, but got %v", err)
+		}
+		if err == nil {
+			read++
+		}
+	}
+	if read != 2 {
+		t.Errorf("read %d records but expected 2", read)
+	}
+
+	expected := "reason,field1,fieldTwo,Field3\n\"" + strings.ReplaceAll(setValueErr.Error(), `"`, `""`) + "\",\"b, c\",x,4\n"
+	if rejects.String() != expected {
+		t.Errorf("improperly written rejects. Got '%s' but expected '%s'", rejects.String(), expected)
+	}
+}
+
+func TestRejectWriterColumnCounts(t *testing.T) {
+	var rejects bytes.Buffer
+	reader := &multiCharReader{
+		lines: []string{"count|name", "x", "y|gizmo|extra"},
+		delim: "|",
+	}
+	p := NewRecordParser(reader, ParserOptions{RejectWriter: &rejects})
+
+	err := p.ParseHeader(&recordReaderTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	var reasons []string
+	for {
+		err := p.ReadRecord(&recordReaderTest{})
+		if err == io.EOF {
+			break
+		}
+		if err == nil {
+			t.Errorf("expected every record to be rejected")
+			continue
+		}
+		reasons = append(reasons, err.Error())
+	}
+
+	rejectReader := csv.NewReader(&rejects)
+	rejectReader.FieldsPerRecord = -1
+	records, err := rejectReader.ReadAll()
+	if err != nil {
+		t.Fatalf("encountered error reading rejects: %v", err)
+	}
+	if len(records) != 3 || len(reasons) != 2 {
+		t.Fatalf("expected a header and 2 rejects but got %q", records)
+	}
+	if strings.Join(records[0], ",") != "reason,count,name" {
+		t.Errorf("improperly written rejects header. Got %q", records[0])
+	}
+	for idx, reason := range reasons {
+		if records[idx+1][0] != reason {
+			t.Errorf("expected the reason in the first column of reject %d, but got %q", idx+1, records[idx+1])
+		}
+	}
+}
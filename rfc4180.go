@@ -0,0 +1,155 @@
+package csv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorInconsistentFieldCount = fmt.Errorf("record has a different number of fields than the first record")
+	ErrorUnquotedQuote          = fmt.Errorf("quote appears in a value that isn't quoted")
+	ErrorUnterminatedQuote      = fmt.Errorf("quoted value is never closed")
+	ErrorTextAfterQuote         = fmt.Errorf("text follows the closing quote of a value")
+	ErrorLineFeedEnding         = fmt.Errorf("line ends with a line feed rather than CRLF")
+	ErrorBareCarriageReturn     = fmt.Errorf("carriage return appears outside of a quoted value without a line feed")
+	ErrorEmptyLine              = fmt.Errorf("line is empty")
+)
+
+// ValidationIssue is a place where a file doesn't conform to RFC 4180, as reported by Validate. Line and Column count from 1, and Column counts characters.
+type ValidationIssue struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e ValidationIssue) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e ValidationIssue) Unwrap() error { return e.Err }
+
+// Validate checks that file conforms to RFC 4180, without binding it to a struct, and returns every issue it finds in the order they appear. It suits a pre-flight check of files
+// from a new source, before they are parsed. Records must have as many fields as the first, quotes may only appear in quoted values, where they are doubled, and lines must end with CRLF.
+// Lines ending with a line feed alone are common, so only the first is reported. The Delimiter, QuoteChar and Dialect of options are used in place of the comma and double quote.
+// An error reading file is reported as an issue holding the error, and ends the check.
+func Validate(file io.Reader, options ParserOptions) (issues []ValidationIssue) {
+	options = options.withDialect()
+
+	delimiter := parserDelimiter(options)
+	quote := '"'
+	if options.QuoteChar != 0 {
+		quote = options.QuoteChar
+	}
+
+	reader := bufio.NewReader(file)
+	line, column := 1, 0
+	fields, expected := 0, -1
+	empty, fieldStart, quoted, closed := true, true, false, false
+	quoteLine, quoteColumn := 0, 0
+	reportedLineFeed := false
+
+	issue := func(line int, column int, err error) {
+		issues = append(issues, ValidationIssue{Line: line, Column: column, Err: err})
+	}
+
+	endRecord := func() {
+		if empty {
+			issue(line, 1, ErrorEmptyLine)
+			return
+		}
+
+		fields++
+		if expected < 0 {
+			expected = fields
+		} else if fields != expected {
+			issue(line, 1, ErrorInconsistentFieldCount)
+		}
+	}
+
+	startLine := func() {
+		line++
+		column = 0
+		fields = 0
+		empty, fieldStart, closed = true, true, false
+	}
+
+	peek := func(want rune) bool {
+		c, _, err := reader.ReadRune()
+		if err != nil {
+			return false
+		}
+		if c != want {
+			_ = reader.UnreadRune()
+			return false
+		}
+		return true
+	}
+
+	for {
+		c, _, err := reader.ReadRune()
+		if err == io.EOF {
+			if quoted {
+				issue(quoteLine, quoteColumn, ErrorUnterminatedQuote)
+			} else if !empty {
+				// The last record doesn't need a line break.
+				endRecord()
+			}
+			return issues
+		}
+		if err != nil {
+			issue(line, column, err)
+			return issues
+		}
+		column++
+
+		if quoted {
+			switch {
+			case c == quote && peek(quote):
+				column++
+			case c == quote:
+				quoted, closed = false, true
+			case c == '\n':
+				line++
+				column = 0
+			}
+			continue
+		}
+
+		switch {
+		case c == '\r' && peek('\n'):
+			endRecord()
+			startLine()
+			continue
+		case c == '\n':
+			endRecord()
+			if !reportedLineFeed {
+				issue(line, column, ErrorLineFeedEnding)
+				reportedLineFeed = true
+			}
+			startLine()
+			continue
+		}
+
+		empty = false
+
+		switch {
+		case c == delimiter:
+			fields++
+			fieldStart, closed = true, false
+		case closed:
+			issue(line, column, ErrorTextAfterQuote)
+			closed = false
+		case c == '\r':
+			issue(line, column, ErrorBareCarriageReturn)
+		case c == quote && fieldStart:
+			quoted = true
+			quoteLine, quoteColumn = line, column
+			fieldStart = false
+		case c == quote:
+			issue(line, column, ErrorUnquotedQuote)
+		default:
+			fieldStart = false
+		}
+	}
+}
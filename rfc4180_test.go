@@ -0,0 +1,44 @@
+package csv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateConforming(t *testing.T) {
+	file := "id,name\r\n1,\"Widget, \"\"large\"\"\"\r\n2,\"two\r\nlines\"\r\n3,"
+	issues := Validate(strings.NewReader(file), ParserOptions{})
+	if len(issues) != 0 {
+		t.Errorf("expected no issues with a conforming file, but got %v", issues)
+	}
+}
+
+func TestValidateIssues(t *testing.T) {
+	file := "id,name\n1,Wid\"get\r\n2,\"Gizmo\"s\r\n\r\n3,a,b\r\n4,\"open\r\n"
+	issues := Validate(strings.NewReader(file), ParserOptions{})
+
+	expected := []ValidationIssue{
+		{Line: 1, Column: 8, Err: ErrorLineFeedEnding},
+		{Line: 2, Column: 6, Err: ErrorUnquotedQuote},
+		{Line: 3, Column: 10, Err: ErrorTextAfterQuote},
+		{Line: 4, Column: 1, Err: ErrorEmptyLine},
+		{Line: 5, Column: 1, Err: ErrorInconsistentFieldCount},
+		{Line: 6, Column: 3, Err: ErrorUnterminatedQuote},
+	}
+	if !reflect.DeepEqual(issues, expected) {
+		t.Errorf("improperly reported issues. Expected %v, but got %v", expected, issues)
+	}
+
+	if !errors.Is(issues[0], ErrorLineFeedEnding) {
+		t.Errorf("expected the issue to match its error")
+	}
+}
+
+func TestValidateDelimiter(t *testing.T) {
+	issues := Validate(strings.NewReader("a;'b;c'\r\n1;'x''y'\r\n"), ParserOptions{Delimiter: ';', QuoteChar: '\''})
+	if len(issues) != 0 {
+		t.Errorf("expected no issues with a file using the delimiter and quote character of the options, but got %v", issues)
+	}
+}
@@ -0,0 +1,117 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+var (
+	ErrorRoundTripMismatch = fmt.Errorf("value read back differs from the value written")
+)
+
+// RoundTripCheck writes structPointer as a csv record and reads it back into a new value of the same type, and reports the first field whose value changed as a RoundTripError.
+// Use it in tests to check that a type's csv tags, custom getters and setters, and converters are lossless. Formatting attributes such as precision are lossy by design,
+// so they are only reported when a value has more decimal places than the precision. A header is written and parsed unless every field is bound by index.
+// The writer and parser options should describe the same csv format, such as the same Delimiter. The writer's NullToken is read back as null unless the parser options set their own.
+func RoundTripCheck(structPointer interface{}, writerOptions WriterOptions, parserOptions ParserOptions) (err error) {
+	var buf bytes.Buffer
+
+	writerOptions.Columns = nil
+	if parserOptions.NullToken == "" {
+		parserOptions.NullToken = writerOptions.NullToken
+	}
+	w := NewWriter(&buf, writerOptions)
+
+	err = w.bind(structPointer)
+	if err != nil {
+		return err
+	}
+
+	useHeader := false
+	for _, attrs := range w.csvAttrs {
+		if attrs.hasHeader {
+			useHeader = true
+		}
+	}
+
+	if useHeader {
+		err = w.WriteHeader(structPointer)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = w.WriteRecord(structPointer)
+	if err != nil {
+		return err
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	readPointer := reflect.New(reflect.TypeOf(structPointer).Elem())
+
+	p := NewParser(&buf, parserOptions)
+	if useHeader {
+		err = p.ParseHeader(readPointer.Interface())
+		if err != nil {
+			return err
+		}
+	}
+
+	err = p.ReadRecord(readPointer.Interface())
+	if err != nil {
+		return err
+	}
+
+	written := reflect.ValueOf(structPointer).Elem()
+	read := readPointer.Elem()
+	for _, fieldName := range w.fieldOrder {
+		if !roundTripEqual(written.FieldByName(fieldName), read.FieldByName(fieldName)) {
+			value, _ := w.getFieldValue(structPointer, fieldName)
+			return RoundTripError{
+				FieldName: fieldName,
+				Value:     value,
+				Err:       ErrorRoundTripMismatch,
+			}
+		}
+	}
+
+	return nil
+}
+
+// roundTripEqual reports whether two field values are the same, treating NaN as equal to NaN since it can be written and read back.
+func roundTripEqual(written reflect.Value, read reflect.Value) bool {
+	switch written.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return floatsEqual(written.Float(), read.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return floatsEqual(real(written.Complex()), real(read.Complex())) && floatsEqual(imag(written.Complex()), imag(read.Complex()))
+	}
+
+	return reflect.DeepEqual(written.Interface(), read.Interface())
+}
+
+func floatsEqual(written float64, read float64) bool {
+	if math.IsNaN(written) {
+		return math.IsNaN(read)
+	}
+
+	return written == read
+}
+
+type RoundTripError struct {
+	FieldName string
+	Value     string
+	Err       error
+}
+
+func (e RoundTripError) Error() string {
+	return fmt.Sprintf("field %s written as %s: %v", e.FieldName, e.Value, e.Err)
+}
+
+func (e RoundTripError) Unwrap() error { return e.Err }
@@ -0,0 +1,138 @@
+package csv
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+type roundTripTest struct {
+	String     string     `csv:"header:string"`
+	Boolean    bool       `csv:"header:boolean"`
+	Int        int        `csv:"header:int"`
+	Int8       int8       `csv:"header:int8"`
+	Int16      int16      `csv:"header:int16"`
+	Int32      int32      `csv:"header:int32"`
+	Int64      int64      `csv:"header:int64"`
+	UInt       uint       `csv:"header:uint"`
+	UInt8      uint8      `csv:"header:uint8"`
+	UInt16     uint16     `csv:"header:uint16"`
+	UInt32     uint32     `csv:"header:uint32"`
+	UInt64     uint64     `csv:"header:uint64"`
+	Float32    float32    `csv:"header:float32"`
+	Float64    float64    `csv:"header:float64"`
+	Complex64  complex64  `csv:"header:complex64"`
+	Complex128 complex128 `csv:"header:complex128"`
+	Percent    float64    `csv:"header:percent;percent"`
+	Price      float64    `csv:"header:price;currency;precision:2"`
+	Optional   int        `csv:"header:optional;omitempty"`
+	ID         customerID `csv:"header:id"`
+	Tags       []string   `csv:"header:tags;useCustomSetter"`
+}
+
+func (rtt *roundTripTest) CustomSetter(fieldName string, value string) (err error) {
+	rtt.Tags = strings.Split(value, "|")
+	return nil
+}
+
+func (rtt *roundTripTest) CustomGetter(fieldName string) (value string, err error) {
+	return strings.Join(rtt.Tags, "|"), nil
+}
+
+func TestRoundTripCheck(t *testing.T) {
+	records := []roundTripTest{
+		{
+			String:     "quoted \"value\", with a comma\nand a newline",
+			Boolean:    true,
+			Int:        math.MinInt64,
+			Int8:       math.MinInt8,
+			Int16:      math.MaxInt16,
+			Int32:      math.MinInt32,
+			Int64:      math.MaxInt64,
+			UInt:       math.MaxUint64,
+			UInt8:      math.MaxUint8,
+			UInt16:     math.MaxUint16,
+			UInt32:     math.MaxUint32,
+			UInt64:     math.MaxUint64,
+			Float32:    math.MaxFloat32,
+			Float64:    math.SmallestNonzeroFloat64,
+			Complex64:  complex(1.5, -0.1),
+			Complex128: complex(math.Inf(1), math.NaN()),
+			Percent:    0.07,
+			Price:      1234.5,
+			ID:         customerID{Region: "EU", Number: 42},
+			Tags:       []string{"a", "b"},
+		},
+		{
+			String:  "  padded  ",
+			Float64: math.NaN(),
+			Percent: -1.255,
+			Tags:    []string{""},
+		},
+	}
+
+	for _, record := range records {
+		err := RoundTripCheck(&record, WriterOptions{}, ParserOptions{})
+		if err != nil {
+			t.Errorf("expected record to survive a round trip, but got %v", err)
+		}
+	}
+
+	for _, delimiter := range []rune{';', '\t', '|'} {
+		err := RoundTripCheck(&records[0], WriterOptions{Delimiter: delimiter, UseCRLF: true}, ParserOptions{Delimiter: delimiter})
+		if err != nil {
+			t.Errorf("expected record to survive a round trip with delimiter %q, but got %v", delimiter, err)
+		}
+	}
+}
+
+type roundTripLossy struct {
+	Name  string  `csv:"index:0"`
+	Price float64 `csv:"index:1;precision:2"`
+}
+
+func TestRoundTripMismatchError(t *testing.T) {
+	err := RoundTripCheck(&roundTripLossy{Name: "widget", Price: 1.005}, WriterOptions{}, ParserOptions{})
+	if !errors.Is(err, ErrorRoundTripMismatch) {
+		t.Errorf("expected to encounter Round Trip Mismatch error, but got %v", err)
+	}
+
+	var roundTripErr RoundTripError
+	if !errors.As(err, &roundTripErr) || roundTripErr.FieldName != "Price" || roundTripErr.Value != "1.00" {
+		t.Errorf("expected the mismatch to be reported on Price written as 1.00, but got %v", err)
+	}
+}
+
+type roundTripNull struct {
+	Name  string `csv:"header:name;omitempty"`
+	Count int    `csv:"header:count;omitempty"`
+}
+
+func TestRoundTripNullToken(t *testing.T) {
+	for _, record := range []roundTripNull{{}, {Name: "widget", Count: 3}} {
+		err := RoundTripCheck(&record, WriterOptions{NullToken: "NULL"}, ParserOptions{})
+		if err != nil {
+			t.Errorf("expected record to survive a round trip with a null token, but got %v", err)
+		}
+	}
+
+	p := NewParser(strings.NewReader("name,count\nNULL,NULL\n\\N,7\n"), ParserOptions{NullToken: "\\N"})
+	err := p.ParseHeader(&roundTripNull{})
+	if err != nil {
+		t.Errorf("encountered error parsing header: %v", err)
+	}
+
+	var record roundTripNull
+	err = p.ReadRecord(&record)
+	var setErr SetValueError
+	if !errors.As(err, &setErr) || setErr.Value != "NULL" {
+		t.Errorf("expected NULL to be read as a value when it isn't the parser's null token, but got %v", err)
+	}
+
+	record = roundTripNull{Name: "stale"}
+	err = p.ReadRecord(&record)
+	if err != nil || record != (roundTripNull{Count: 7}) {
+		t.Errorf("expected the null token to be read as the zero value, but got %+v and %v", record, err)
+	}
+}
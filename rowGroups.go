@@ -0,0 +1,65 @@
+package csv
+
+import (
+	"io"
+)
+
+// RowGroupWriter is implemented by writers of columnar files that take rows of a struct type, such as the generic writer of a Parquet library.
+// Write adds rows to the current row group, and Flush ends the row group.
+type RowGroupWriter[T any] interface {
+	Write(rows []T) (n int, err error)
+	Flush() (err error)
+}
+
+// ConvertInRowGroups streams the records of file into w in row groups of rowGroupSize records, where *T has csv decorator tags applied,
+// so that a csv file can be archived to a columnar format such as Parquet without holding it all in memory. Only one row group of records is held at a time.
+// The header is parsed first as described by Parse. It returns the number of records written. If a record can't be read, the records before it are written and flushed,
+// and the error is returned. w is not closed, so the caller should close it to finish the file.
+func ConvertInRowGroups[T any](file io.Reader, w RowGroupWriter[T], rowGroupSize int, options ParserOptions) (converted int, err error) {
+	if rowGroupSize <= 0 {
+		return 0, ErrorInvalidBatchSize
+	}
+
+	p := NewParser(file, options)
+
+	var record T
+	err = p.bind(&record)
+	if err != nil {
+		return 0, err
+	}
+
+	if p.needsHeader && !options.AutoDetectHeader {
+		err = p.ParseHeader(&record)
+		if err == io.EOF {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	rows := make([]T, 0, rowGroupSize)
+	for {
+		var read int
+		read, err = p.ReadBatch(&rows, rowGroupSize)
+		if read > 0 {
+			written, writeErr := w.Write(rows)
+			converted += written
+			if writeErr != nil {
+				return converted, writeErr
+			}
+
+			writeErr = w.Flush()
+			if writeErr != nil {
+				return converted, writeErr
+			}
+		}
+
+		if err == io.EOF {
+			return converted, nil
+		}
+		if err != nil {
+			return converted, err
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package csv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// rowGroupRecorder records the row groups written to it, copying rows since the caller reuses them.
+type rowGroupRecorder[T any] struct {
+	current []T
+	groups  [][]T
+}
+
+func (r *rowGroupRecorder[T]) Write(rows []T) (n int, err error) {
+	r.current = append(r.current, rows...)
+	return len(rows), nil
+}
+
+func (r *rowGroupRecorder[T]) Flush() (err error) {
+	r.groups = append(r.groups, r.current)
+	r.current = nil
+	return nil
+}
+
+func TestConvertInRowGroups(t *testing.T) {
+	w := &rowGroupRecorder[headerTest]{}
+
+	converted, err := ConvertInRowGroups[headerTest](strings.NewReader("field1,fieldTwo,Field3\na,1,2\nb,3,4\nc,5,6\n"), w, 2, ParserOptions{})
+	if err != nil {
+		t.Errorf("encountered error converting in row groups: %v", err)
+	}
+
+	expected := [][]headerTest{
+		{{Field1: "a", Field2: 1, Field3: 2}, {Field1: "b", Field2: 3, Field3: 4}},
+		{{Field1: "c", Field2: 5, Field3: 6}},
+	}
+	if converted != 3 || !reflect.DeepEqual(w.groups, expected) {
+		t.Errorf("improperly converted row groups. Got %d records in '%+v' but expected '%+v'", converted, w.groups, expected)
+	}
+}
+
+func TestConvertInRowGroupsError(t *testing.T) {
+	w := &rowGroupRecorder[headerTest]{}
+
+	converted, err := ConvertInRowGroups[headerTest](strings.NewReader("field1,fieldTwo,Field3\na,1,2\nb,x,4\nc,5,6\n"), w, 10, ParserOptions{})
+	var setValueErr SetValueError
+	if !errors.As(err, &setValueErr) || setValueErr.Line != 2 {
+		t.Errorf("expected a SetValueError on line 2, but got %v", err)
+	}
+
+	if converted != 1 || len(w.groups) != 1 {
+		t.Errorf("expected the record before the error to be written and flushed, but got %d records in %d row groups", converted, len(w.groups))
+	}
+
+	_, err = ConvertInRowGroups[headerTest](strings.NewReader(""), w, 0, ParserOptions{})
+	if !errors.Is(err, ErrorInvalidBatchSize) {
+		t.Errorf("expected to encounter ErrorInvalidBatchSize error, but got %v", err)
+	}
+}
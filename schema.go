@@ -0,0 +1,239 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+var (
+	ErrorUnknownColumnType = fmt.Errorf("unknown schema column type")
+	ErrorRequiredValue     = fmt.Errorf("a value is required")
+)
+
+// ColumnType names the type a schema column's values are converted to.
+type ColumnType string
+
+const (
+	ColumnTypeString ColumnType = "string"
+	ColumnTypeInt    ColumnType = "int"
+	ColumnTypeFloat  ColumnType = "float"
+	ColumnTypeBool   ColumnType = "bool"
+	ColumnTypeTime   ColumnType = "time"
+)
+
+// columnTypes maps each ColumnType to the go type its values are converted to.
+var columnTypes = map[ColumnType]reflect.Type{
+	ColumnTypeString: reflect.TypeOf(""),
+	ColumnTypeInt:    reflect.TypeOf(int64(0)),
+	ColumnTypeFloat:  reflect.TypeOf(float64(0)),
+	ColumnTypeBool:   reflect.TypeOf(false),
+	ColumnTypeTime:   reflect.TypeOf(time.Time{}),
+}
+
+// SchemaColumn describes one column of a Schema. A column with an Index is bound to that zero-indexed column,
+// and any other column is bound to the column with the matching header label.
+// Format is the time layout for time columns, and defaults to time.RFC3339.
+// Default is used in place of an empty value, and an empty value is an error for Required columns.
+type SchemaColumn struct {
+	Name     string     `json:"name" yaml:"name"`
+	Index    *int       `json:"index,omitempty" yaml:"index,omitempty"`
+	Type     ColumnType `json:"type" yaml:"type"`
+	Format   string     `json:"format,omitempty" yaml:"format,omitempty"`
+	Required bool       `json:"required,omitempty" yaml:"required,omitempty"`
+	Default  string     `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// Schema describes the layout of a csv file at runtime, in place of a struct with csv tags.
+// It can be built in code, or unmarshaled from JSON or YAML configuration.
+type Schema struct {
+	Columns []SchemaColumn `json:"columns" yaml:"columns"`
+}
+
+// SchemaParser reads csv records as described by a Schema.
+type SchemaParser struct {
+	parser       Parser
+	schema       Schema
+	columns      []int
+	checked      bool
+	headerParsed bool
+	needsHeader  bool
+}
+
+// NewSchemaParser creates a new csv parser for the provided file that converts values as described by schema.
+// Use ParserOptions to specify any desired changed from the default behavior as defined in the standard csv parser library.
+func NewSchemaParser(file io.Reader, schema Schema, options ParserOptions) (sp SchemaParser) {
+	sp.parser = NewParser(file, options)
+	sp.schema = schema
+	sp.columns = make([]int, len(schema.Columns))
+
+	for i, column := range schema.Columns {
+		if column.Index == nil {
+			sp.needsHeader = true
+			continue
+		}
+		sp.columns[i] = *column.Index
+	}
+
+	return sp
+}
+
+func (sp *SchemaParser) checkSchema() (err error) {
+	if sp.checked {
+		return nil
+	}
+
+	for _, column := range sp.schema.Columns {
+		if _, ok := columnTypes[column.Type]; !ok {
+			return SchemaError{
+				ColumnName: column.Name,
+				Err:        ErrorUnknownColumnType,
+			}
+		}
+
+		if column.Index != nil && *column.Index < 0 {
+			return SchemaError{
+				ColumnName: column.Name,
+				Err:        ErrorInvalidIndex,
+			}
+		}
+	}
+
+	sp.checked = true
+
+	return nil
+}
+
+// ParseHeader reads the first line of the parser's csv file and resolves the columns of the schema that are bound by name.
+// If the schema has columns bound by name and ParseHeader has not been called, the first call to ReadRecord will call it.
+func (sp *SchemaParser) ParseHeader() (err error) {
+	err = sp.checkSchema()
+	if err != nil {
+		return err
+	}
+
+	header, err := sp.parser.readHeader()
+	if err != nil {
+		return err
+	}
+
+	sp.headerParsed = true
+
+	for i, column := range sp.schema.Columns {
+		if column.Index != nil {
+			continue
+		}
+
+		idx, foundIdx := findHeaderIndex(header, column.Name)
+		if !foundIdx {
+			return FieldNotFoundError{
+				FieldName:  column.Name,
+				HeaderName: column.Name,
+				Err:        ErrorFieldNotFound,
+			}
+		}
+
+		sp.columns[i] = idx
+	}
+
+	return nil
+}
+
+// ReadRecordValues reads the next line of the parser's csv file and returns the converted value of each schema column, in schema order.
+func (sp *SchemaParser) ReadRecordValues() (values []interface{}, err error) {
+	values, _, err = sp.readRecordValues()
+	return values, err
+}
+
+// readRecordValues reads the next line of the parser's csv file like ReadRecordValues, and also reports for each schema column whether it had a value or default.
+func (sp *SchemaParser) readRecordValues() (values []interface{}, present []bool, err error) {
+	err = sp.checkSchema()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if sp.needsHeader && !sp.headerParsed {
+		err = sp.ParseHeader()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	readRecord, err := sp.parser.readNextRecord()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values = make([]interface{}, len(sp.schema.Columns))
+	present = make([]bool, len(sp.schema.Columns))
+	for i, column := range sp.schema.Columns {
+		var value string
+		if sp.columns[i] < len(readRecord) {
+			value = readRecord[sp.columns[i]]
+		}
+		present[i] = value != "" || column.Default != ""
+
+		values[i], err = convertSchemaValue(&sp.parser, column, value)
+		if err != nil {
+			return nil, nil, SetValueError{
+				Line:      sp.parser.line,
+				Value:     value,
+				FieldName: column.Name,
+				Err:       err,
+			}
+		}
+	}
+
+	return values, present, nil
+}
+
+// ReadRecord reads the next line of the parser's csv file and returns the converted values keyed by schema column name.
+func (sp *SchemaParser) ReadRecord() (record map[string]interface{}, err error) {
+	values, err := sp.ReadRecordValues()
+	if err != nil {
+		return nil, err
+	}
+
+	record = make(map[string]interface{}, len(values))
+	for i, column := range sp.schema.Columns {
+		record[column.Name] = values[i]
+	}
+
+	return record, nil
+}
+
+// convertSchemaValue converts value as described by column, using the parser's options for any conversion it shares with struct fields.
+func convertSchemaValue(p *Parser, column SchemaColumn, value string) (converted interface{}, err error) {
+	if value == "" {
+		value = column.Default
+	}
+
+	if value == "" {
+		if column.Required {
+			return nil, ErrorRequiredValue
+		}
+		return reflect.Zero(columnTypes[column.Type]).Interface(), nil
+	}
+
+	if column.Type == ColumnTypeTime {
+		layout := column.Format
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return time.Parse(layout, value)
+	}
+
+	return p.convertValue(columnTypes[column.Type], value)
+}
+
+type SchemaError struct {
+	ColumnName string
+	Err        error
+}
+
+func (e SchemaError) Error() string {
+	return fmt.Sprintf("problem with schema definition of column %s: %v", e.ColumnName, e.Err)
+}
+
+func (e SchemaError) Unwrap() error { return e.Err }
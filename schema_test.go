@@ -0,0 +1,73 @@
+package csv
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	schemaTestJSON = `{
+	"columns": [
+		{"name": "id", "index": 0, "type": "int"},
+		{"name": "name", "type": "string", "required": true},
+		{"name": "price", "type": "float", "default": "9.99"},
+		{"name": "sold", "type": "time", "format": "2006-01-02"}
+	]
+}`
+
+	schemaTestData = `id,name,price,sold
+1,widget,12.5,2022-03-04
+2,gadget,,2022-03-05
+3,,1,2022-03-06`
+)
+
+func TestSchemaParser(t *testing.T) {
+	schema := Schema{}
+	err := json.Unmarshal([]byte(schemaTestJSON), &schema)
+	if err != nil {
+		t.Errorf("encountered error unmarshaling schema: %v", err)
+	}
+
+	sp := NewSchemaParser(strings.NewReader(schemaTestData), schema, ParserOptions{})
+
+	record, err := sp.ReadRecord()
+	if err != nil {
+		t.Errorf("encountered error parsing csv with schema: %v", err)
+	}
+	expected := map[string]interface{}{
+		"id":    int64(1),
+		"name":  "widget",
+		"price": 12.5,
+		"sold":  time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(record, expected) {
+		t.Errorf("improperly parsed record with schema. Got '%v' but expected '%v'", record, expected)
+	}
+
+	values, err := sp.ReadRecordValues()
+	if err != nil {
+		t.Errorf("encountered error parsing csv with schema: %v", err)
+	}
+	if len(values) != 4 || values[2] != 9.99 {
+		t.Errorf("schema default was not applied. Got '%v' but expected price '9.99'", values)
+	}
+
+	_, err = sp.ReadRecord()
+	if !errors.Is(err, ErrorRequiredValue) {
+		t.Errorf("expected to encounter Required Value error, but got %v", err)
+	}
+}
+
+func TestUnknownColumnTypeError(t *testing.T) {
+	schema := Schema{Columns: []SchemaColumn{{Name: "id", Type: "uuid"}}}
+	sp := NewSchemaParser(strings.NewReader(schemaTestData), schema, ParserOptions{})
+
+	_, err := sp.ReadRecord()
+	if !errors.Is(err, ErrorUnknownColumnType) {
+		t.Errorf("expected to encounter Unknown Column Type error, but got %v", err)
+	}
+}
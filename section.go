@@ -0,0 +1,50 @@
+package csv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrorSectionsNotEnabled = fmt.Errorf("sections need the BlankLineEndSection blank line policy")
+)
+
+// NextSection moves the parser to the next section of a report whose sections are separated by blank lines, each with its own header.
+// Any records left in the current section are skipped. The first line of the next section is then parsed as its header, and the parser is bound to structPointer,
+// which may be a different struct type than the previous section was read into. It returns io.EOF when there are no more sections.
+// The parser must use the BlankLineEndSection policy, or ErrorSectionsNotEnabled is returned. Parse the header of the first section with ParseHeader, and call NextSection for each section after it.
+func (p *Parser) NextSection(structPointer interface{}) (err error) {
+	err = checkStructPointer(structPointer)
+	if err != nil {
+		return err
+	}
+
+	if p.options.BlankLines != BlankLineEndSection {
+		return ErrorSectionsNotEnabled
+	}
+
+	// A record kept by Peek belongs to the section being skipped.
+	p.recordPeek = nil
+	for !p.sectionEnded {
+		_, err = p.readRecord()
+
+		// Records that are skipped don't need to be well formed, but other errors, such as io.EOF, stop the search.
+		var parseErr *csv.ParseError
+		if err != nil && err != ErrorEndOfSection && !errors.As(err, &parseErr) {
+			return err
+		}
+	}
+	p.sectionEnded = false
+
+	p.csvAttrs = make(map[string]csvAttributes)
+	p.boundType = nil
+	p.fieldOrder = nil
+	p.header = nil
+	p.needsHeader = false
+	p.useDecoder = false
+	p.headerChecked = false
+	p.dedupe = nil
+
+	return p.ParseHeader(structPointer)
+}
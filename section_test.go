@@ -0,0 +1,77 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type sectionOrder struct {
+	ID    int     `csv:"header:id"`
+	Total float64 `csv:"header:total"`
+}
+
+type sectionSummary struct {
+	Region string `csv:"header:region"`
+	Count  int    `csv:"header:orders"`
+}
+
+var sectionReport = "id,total\n1,9.5\n2,3\n\norders,region\n2,north\n\n\nid,total\n3,1.25\n"
+
+func TestNextSection(t *testing.T) {
+	p := NewParser(strings.NewReader(sectionReport), ParserOptions{BlankLines: BlankLineEndSection})
+
+	var order sectionOrder
+	err := p.ParseHeader(&order)
+	if err != nil {
+		t.Errorf("encountered error parsing the first header: %v", err)
+	}
+
+	err = p.ReadRecord(&order)
+	if err != nil || order.ID != 1 {
+		t.Errorf("improperly read the first section. Got '%+v' and error %v", order, err)
+	}
+
+	// The rest of the first section is skipped.
+	var summary sectionSummary
+	err = p.NextSection(&summary)
+	if err != nil {
+		t.Errorf("encountered error moving to the second section: %v", err)
+	}
+
+	err = p.ReadRecord(&summary)
+	if err != nil || summary != (sectionSummary{Region: "north", Count: 2}) {
+		t.Errorf("improperly read the second section. Got '%+v' and error %v", summary, err)
+	}
+
+	err = p.ReadRecord(&summary)
+	if err != ErrorEndOfSection {
+		t.Errorf("expected to encounter ErrorEndOfSection error, but got %v", err)
+	}
+
+	// The end of the section has already been read, so the third section isn't skipped.
+	err = p.NextSection(&order)
+	if err != nil {
+		t.Errorf("encountered error moving to the third section: %v", err)
+	}
+
+	err = p.ReadRecord(&order)
+	if err != nil || order != (sectionOrder{ID: 3, Total: 1.25}) {
+		t.Errorf("improperly read the third section. Got '%+v' and error %v", order, err)
+	}
+
+	err = p.NextSection(&order)
+	if err != io.EOF {
+		t.Errorf("expected io.EOF after the last section, but got %v", err)
+	}
+}
+
+func TestNextSectionNotEnabled(t *testing.T) {
+	p := NewParser(strings.NewReader(sectionReport), ParserOptions{})
+
+	err := p.NextSection(&sectionOrder{})
+	if !errors.Is(err, ErrorSectionsNotEnabled) {
+		t.Errorf("expected to encounter ErrorSectionsNotEnabled error, but got %v", err)
+	}
+}
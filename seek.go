@@ -0,0 +1,46 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorNotSeekable = fmt.Errorf("parser input does not support seeking")
+)
+
+// Skip reads and discards the next n records without setting them on a struct. Skipped records are still counted in line numbers.
+// It returns io.EOF if the file ends before n records have been skipped.
+func (p *Parser) Skip(n int) (err error) {
+	for i := 0; i < n; i++ {
+		_, err = p.readNextRecord()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SeekToOffset moves the parser to the given byte offset of its file, so that an import can continue where a previous run stopped.
+// The offset must be at the start of a record. The parser's file must implement io.Seeker, and the parser must have been created with NewParser, or ErrorNotSeekable is returned.
+// The parsed header and struct bindings are kept, and line numbers continue to count from where they were.
+func (p *Parser) SeekToOffset(offset int64) (err error) {
+	seeker, ok := p.file.(io.Seeker)
+	if !ok {
+		return ErrorNotSeekable
+	}
+
+	_, err = seeker.Seek(offset, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	// The csv reader buffers its input, so a new one is needed to start reading from the offset.
+	p.reader = newParserReader(p.file, p.options)
+	p.offset = offset
+	p.hasPeeked = false
+	p.recordPeek = nil
+
+	return nil
+}
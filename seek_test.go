@@ -0,0 +1,69 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSkip(t *testing.T) {
+	p := NewParser(strings.NewReader("field1,fieldTwo,Field3\na,1,2\nb,3,4\nc,5,6"), ParserOptions{})
+
+	err := p.ParseHeader(&headerTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.Skip(2)
+	if err != nil {
+		t.Errorf("encountered error skipping records: %v", err)
+	}
+
+	var data headerTest
+	err = p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error reading record after skipping: %v", err)
+	}
+	if data.Field1 != "c" || p.line != 3 {
+		t.Errorf("improperly skipped records. Read '%+v' on line %d", data, p.line)
+	}
+
+	err = p.Skip(1)
+	if err != io.EOF {
+		t.Errorf("expected to reach the end of the file, but got %v", err)
+	}
+}
+
+func TestSeekToOffset(t *testing.T) {
+	data := "field1,fieldTwo,Field3\na,1,2\nb,3,4\nc,5,6"
+	p := NewParser(strings.NewReader(data), ParserOptions{})
+
+	err := p.ParseHeader(&headerTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	err = p.SeekToOffset(int64(strings.Index(data, "c,")))
+	if err != nil {
+		t.Errorf("encountered error seeking to offset: %v", err)
+	}
+
+	var record headerTest
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading record after seeking: %v", err)
+	}
+	if record.Field1 != "c" || record.Field2 != 5 || record.Field3 != 6 {
+		t.Errorf("improperly read record after seeking. Got '%+v'", record)
+	}
+}
+
+func TestNotSeekableError(t *testing.T) {
+	p := NewRecordParser(&multiCharReader{}, ParserOptions{})
+
+	err := p.SeekToOffset(0)
+	if !errors.Is(err, ErrorNotSeekable) {
+		t.Errorf("expected to encounter Not Seekable error, but got %v", err)
+	}
+}
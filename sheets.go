@@ -0,0 +1,92 @@
+package csv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorInvalidMajorDimension = fmt.Errorf("values response major dimension must be ROWS or COLUMNS")
+)
+
+// SheetsReader reads the values of a Google Sheets API values response as records. It implements RecordReader.
+type SheetsReader struct {
+	records [][]string
+	next    int
+}
+
+// NewSheetsReader creates a reader of the records in a Google Sheets API values response, such as the body of a spreadsheets.values.get request, so that they can be bound to structs with NewRecordParser.
+// Responses with a major dimension of COLUMNS are transposed into rows. Numbers and booleans, returned when values are requested unformatted, are read as they appear in the response.
+// The API leaves out empty values at the end of a row, so every row is padded to the width of the widest.
+func NewSheetsReader(response io.Reader) (r *SheetsReader, err error) {
+	var values struct {
+		MajorDimension string          `json:"majorDimension"`
+		Values         [][]interface{} `json:"values"`
+	}
+
+	decoder := json.NewDecoder(response)
+	decoder.UseNumber()
+	err = decoder.Decode(&values)
+	if err != nil {
+		return nil, err
+	}
+
+	r = &SheetsReader{}
+	for _, row := range values.Values {
+		record := make([]string, len(row))
+		for idx, value := range row {
+			if value != nil {
+				record[idx] = fmt.Sprint(value)
+			}
+		}
+		r.records = append(r.records, record)
+	}
+
+	switch values.MajorDimension {
+	case "", "ROWS":
+	case "COLUMNS":
+		r.records = transposeRecords(r.records)
+	default:
+		return nil, ErrorInvalidMajorDimension
+	}
+
+	width := 0
+	for _, record := range r.records {
+		if len(record) > width {
+			width = len(record)
+		}
+	}
+	for idx := range r.records {
+		for len(r.records[idx]) < width {
+			r.records[idx] = append(r.records[idx], "")
+		}
+	}
+
+	return r, nil
+}
+
+// Read returns the next row of the response, or io.EOF after the last row.
+func (r *SheetsReader) Read() (record []string, err error) {
+	if r.next == len(r.records) {
+		return nil, io.EOF
+	}
+
+	r.next++
+
+	return r.records[r.next-1], nil
+}
+
+// transposeRecords turns columns of values into rows, reading values missing from the end of a column as empty values.
+func transposeRecords(columns [][]string) (rows [][]string) {
+	for column, values := range columns {
+		for row, value := range values {
+			for len(rows) <= row {
+				rows = append(rows, make([]string, len(columns)))
+			}
+			rows[row][column] = value
+		}
+	}
+
+	return rows
+}
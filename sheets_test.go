@@ -0,0 +1,67 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type sheetsTest struct {
+	Name   string  `csv:"header:name"`
+	Price  float64 `csv:"header:price"`
+	Active bool    `csv:"header:active;omitempty"`
+}
+
+func TestSheetsReader(t *testing.T) {
+	testCases := []string{
+		`{"range": "Prices!A1:C3", "majorDimension": "ROWS", "values": [["name", "price", "active"], ["Widget", 9.5, true], ["Gadget", "12"]]}`,
+		`{"range": "Prices!A1:C3", "majorDimension": "COLUMNS", "values": [["name", "Widget", "Gadget"], ["price", 9.5, "12"], ["active", true]]}`,
+	}
+
+	expected := []sheetsTest{
+		{Name: "Widget", Price: 9.5, Active: true},
+		{Name: "Gadget", Price: 12},
+	}
+
+	for _, testCase := range testCases {
+		r, err := NewSheetsReader(strings.NewReader(testCase))
+		if err != nil {
+			t.Errorf("encountered error reading values response: %v", err)
+			continue
+		}
+
+		p := NewRecordParser(r, ParserOptions{})
+
+		var record sheetsTest
+		err = p.ParseHeader(&record)
+		if err != nil {
+			t.Errorf("encountered error parsing values response header: %v", err)
+		}
+
+		var records []sheetsTest
+		for {
+			err = p.ReadRecord(&record)
+			if err != nil {
+				break
+			}
+			records = append(records, record)
+		}
+
+		if err != io.EOF {
+			t.Errorf("encountered error reading values response record: %v", err)
+		}
+
+		if !reflect.DeepEqual(records, expected) {
+			t.Errorf("improperly read values response. Got '%+v' but expected '%+v'", records, expected)
+		}
+	}
+}
+
+func TestSheetsReaderInvalidMajorDimension(t *testing.T) {
+	_, err := NewSheetsReader(strings.NewReader(`{"majorDimension": "DIMENSION_UNSPECIFIED", "values": []}`))
+	if !errors.Is(err, ErrorInvalidMajorDimension) {
+		t.Errorf("expected to encounter ErrorInvalidMajorDimension error, but got %v", err)
+	}
+}
@@ -0,0 +1,459 @@
+package csv
+
+import (
+	"container/heap"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrorInvalidSortKey         = fmt.Errorf("sort keys must name a field")
+	ErrorInvalidSortMemoryLimit = fmt.Errorf("sort memory limit must not be negative")
+	ErrorSortNotSupported       = fmt.Errorf("sorting is not supported by writers that spread records across several files")
+)
+
+// defaultSortMemoryLimit is the number of records a sorting writer holds in memory when SortMemoryLimit isn't set.
+const defaultSortMemoryLimit = 100000
+
+// SortKey is a field that a Writer sorts its records by, named by header name or field name. For maps written with WriteRecordMap, Field is one of the Columns.
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// sortState holds the records a sorting writer has yet to write.
+type sortState struct {
+	columns []int
+	// kinds holds the kind each sort key is compared as: reflect.Int64, reflect.Uint64 or reflect.Float64 for numeric fields, and reflect.String for the text of any other column.
+	kinds        []reflect.Kind
+	fieldIndexes []int
+	records      []sortRecord
+	runs         []*os.File
+	resolved     bool
+}
+
+// sortRecord is a record held by a sorting writer, with the values of its sort keys. Numeric keys are taken from the field values, as int64, uint64 or float64,
+// so that they are compared as numbers however the field is formatted, and other keys are the text written to their column.
+type sortRecord struct {
+	values []string
+	keys   []interface{}
+}
+
+// writeRecordValues writes record as the next line of the writer's csv file, or holds it to be written in order when the writer sorts its records.
+// The structPointer the record was rendered from, if any, gives the values of numeric sort keys.
+func (w *Writer) writeRecordValues(record []string, structPointer interface{}) (err error) {
+	if w.optionsErr != nil {
+		return w.optionsErr
+	}
+
+	if len(w.options.SortBy) == 0 {
+		return w.write(record)
+	}
+
+	if w.closed {
+		return ErrorWriterClosed
+	}
+
+	if w.sort == nil {
+		w.sort = &sortState{}
+	}
+
+	if !w.sort.resolved {
+		err = w.resolveSortKeys()
+		if err != nil {
+			return err
+		}
+	}
+
+	sorted, err := w.sortRecord(record, structPointer)
+	if err != nil {
+		return err
+	}
+	w.sort.records = append(w.sort.records, sorted)
+
+	limit := w.options.SortMemoryLimit
+	if limit == 0 {
+		limit = defaultSortMemoryLimit
+	}
+	if len(w.sort.records) >= limit {
+		return w.spillSorted()
+	}
+
+	return nil
+}
+
+// resolveSortKeys finds the column of each sort key, and the kind it is compared as.
+func (w *Writer) resolveSortKeys() (err error) {
+	for _, key := range w.options.SortBy {
+		column, kind, fieldIndex := -1, reflect.String, -1
+
+		if w.columns != nil {
+			for _, fieldName := range w.fieldOrder {
+				attrs := w.csvAttrs[fieldName]
+				if (attrs.hasHeader && attrs.headerName == key.Field) || fieldName == key.Field {
+					if idx, ok := w.fieldColumns[fieldName]; ok {
+						column = idx
+						kind = sortKind(attrs.kind)
+						fieldIndex = attrs.fieldIndex
+					}
+					break
+				}
+			}
+		} else {
+			for idx, name := range w.options.Columns {
+				if name == key.Field {
+					column = idx
+					break
+				}
+			}
+		}
+
+		if column < 0 {
+			return FieldNotFoundError{
+				FieldName:  key.Field,
+				HeaderName: key.Field,
+				Err:        ErrorColumnNotFound,
+			}
+		}
+
+		w.sort.columns = append(w.sort.columns, column)
+		w.sort.kinds = append(w.sort.kinds, kind)
+		w.sort.fieldIndexes = append(w.sort.fieldIndexes, fieldIndex)
+	}
+
+	w.sort.resolved = true
+
+	return nil
+}
+
+// sortKind returns the kind that keys of fields of kind are compared as.
+func sortKind(kind reflect.Kind) reflect.Kind {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.Uint64
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+
+	return reflect.String
+}
+
+// sortRecord takes the sort keys of record, reading numeric keys from the fields of structPointer. Without a struct, as for a map written to the columns of a struct,
+// numeric keys are parsed from their text.
+func (w *Writer) sortRecord(record []string, structPointer interface{}) (sorted sortRecord, err error) {
+	keys := make([]interface{}, len(w.sort.columns))
+	for idx, column := range w.sort.columns {
+		kind := w.sort.kinds[idx]
+		if kind == reflect.String || structPointer == nil {
+			var text string
+			if column < len(record) {
+				text = record[column]
+			}
+			keys[idx], err = parseSortKey(kind, text)
+			if err != nil {
+				return sorted, GetValueError{
+					Line:      w.line,
+					FieldName: w.options.SortBy[idx].Field,
+					Err:       err,
+				}
+			}
+			continue
+		}
+
+		field := reflect.ValueOf(structPointer).Elem().Field(w.sort.fieldIndexes[idx])
+		switch kind {
+		case reflect.Int64:
+			keys[idx] = field.Int()
+		case reflect.Uint64:
+			keys[idx] = field.Uint()
+		case reflect.Float64:
+			keys[idx] = field.Float()
+		}
+	}
+
+	return sortRecord{values: record, keys: keys}, nil
+}
+
+// compareSorted compares two records by the writer's sort keys, returning a negative number if a is written first, and a positive number if b is.
+func (w *Writer) compareSorted(a sortRecord, b sortRecord) int {
+	for idx, key := range w.options.SortBy {
+		result := compareSortKeys(a.keys[idx], b.keys[idx])
+		if key.Descending {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+// compareSortKeys compares two keys of the same kind. NaN sorts before every other float, so that the order stays consistent.
+func compareSortKeys(a interface{}, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		return compareOrdered(a, b.(int64))
+	case uint64:
+		return compareOrdered(a, b.(uint64))
+	case float64:
+		b := b.(float64)
+		if math.IsNaN(a) || math.IsNaN(b) {
+			return compareOrdered(boolRank(!math.IsNaN(a)), boolRank(!math.IsNaN(b)))
+		}
+		return compareOrdered(a, b)
+	}
+
+	return strings.Compare(a.(string), b.(string))
+}
+
+// compareOrdered returns -1 if a is less than b, 1 if it is greater, and 0 otherwise.
+func compareOrdered[T int | int64 | uint64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// boolRank ranks false before true.
+func boolRank(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
+// sortHeld sorts the records held in memory. Records with equal keys keep the order they were written in.
+func (w *Writer) sortHeld() {
+	records := w.sort.records
+	sort.SliceStable(records, func(i, j int) bool {
+		return w.compareSorted(records[i], records[j]) < 0
+	})
+}
+
+// spillSorted sorts the records held in memory and writes them to a temporary file, as one of the sorted runs merged by finishSorted.
+func (w *Writer) spillSorted() (err error) {
+	w.sortHeld()
+
+	run, err := os.CreateTemp(w.options.SortTempDir, "csv-sort-*")
+	if err != nil {
+		return err
+	}
+	w.sort.runs = append(w.sort.runs, run)
+
+	encoder := csv.NewWriter(run)
+	for _, record := range w.sort.records {
+		err = encoder.Write(encodeSortRecord(record))
+		if err != nil {
+			return err
+		}
+	}
+	encoder.Flush()
+	err = encoder.Error()
+	if err != nil {
+		return err
+	}
+
+	w.sort.records = w.sort.records[:0]
+
+	return nil
+}
+
+// finishSorted writes every held record in order, merging any sorted runs spilled to temporary files, and removes the temporary files.
+func (w *Writer) finishSorted() (err error) {
+	if w.sort == nil {
+		return nil
+	}
+	state := w.sort
+	defer w.removeSortRuns()
+
+	if len(state.runs) == 0 {
+		w.sortHeld()
+		for _, record := range state.records {
+			err = w.write(record.values)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(state.records) > 0 {
+		err = w.spillSorted()
+		if err != nil {
+			return err
+		}
+	}
+
+	merge := &sortMerge{writer: w}
+	for _, run := range state.runs {
+		_, err = run.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		reader := csv.NewReader(run)
+		reader.FieldsPerRecord = -1
+		err = merge.add(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	for merge.Len() > 0 {
+		head := merge.runs[0]
+		err = w.write(head.record.values)
+		if err != nil {
+			return err
+		}
+
+		err = merge.advance()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeSortRuns closes and removes the temporary files of the sorted runs, and drops the held records.
+func (w *Writer) removeSortRuns() {
+	for _, run := range w.sort.runs {
+		_ = run.Close()
+		_ = os.Remove(run.Name())
+	}
+
+	w.sort = nil
+}
+
+// sortRun is a sorted run being merged, with the next of its records.
+type sortRun struct {
+	reader *csv.Reader
+	record sortRecord
+	order  int
+}
+
+// sortMerge is a heap of sorted runs, ordered by their next records, for merging the runs into one sorted sequence.
+type sortMerge struct {
+	writer *Writer
+	runs   []*sortRun
+}
+
+func (m *sortMerge) Len() int { return len(m.runs) }
+
+func (m *sortMerge) Less(i, j int) bool {
+	result := m.writer.compareSorted(m.runs[i].record, m.runs[j].record)
+	if result == 0 {
+		return m.runs[i].order < m.runs[j].order
+	}
+	return result < 0
+}
+
+func (m *sortMerge) Swap(i, j int) { m.runs[i], m.runs[j] = m.runs[j], m.runs[i] }
+
+func (m *sortMerge) Push(x interface{}) { m.runs = append(m.runs, x.(*sortRun)) }
+
+func (m *sortMerge) Pop() interface{} {
+	last := m.runs[len(m.runs)-1]
+	m.runs = m.runs[:len(m.runs)-1]
+	return last
+}
+
+// add adds the run read by reader to the merge, unless it is empty.
+func (m *sortMerge) add(reader *csv.Reader) (err error) {
+	record, err := m.writer.readSortRecord(reader)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	heap.Push(m, &sortRun{reader: reader, record: record, order: m.Len()})
+
+	return nil
+}
+
+// advance moves the run with the least record on to its next record, removing it from the merge once it is exhausted.
+func (m *sortMerge) advance() (err error) {
+	head := m.runs[0]
+
+	head.record, err = m.writer.readSortRecord(head.reader)
+	if err == io.EOF {
+		heap.Pop(m)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	heap.Fix(m, 0)
+
+	return nil
+}
+
+// encodeSortRecord lays out record as a line of a sorted run, with its keys written ahead of its values.
+func encodeSortRecord(record sortRecord) (line []string) {
+	line = make([]string, 0, len(record.keys)+len(record.values))
+	for _, key := range record.keys {
+		switch key := key.(type) {
+		case int64:
+			line = append(line, strconv.FormatInt(key, 10))
+		case uint64:
+			line = append(line, strconv.FormatUint(key, 10))
+		case float64:
+			line = append(line, strconv.FormatFloat(key, 'g', -1, 64))
+		case string:
+			line = append(line, key)
+		}
+	}
+
+	return append(line, record.values...)
+}
+
+// readSortRecord reads the next record of a sorted run, as laid out by encodeSortRecord.
+func (w *Writer) readSortRecord(reader *csv.Reader) (record sortRecord, err error) {
+	line, err := reader.Read()
+	if err != nil {
+		return record, err
+	}
+
+	record.keys = make([]interface{}, len(w.sort.kinds))
+	for idx, kind := range w.sort.kinds {
+		record.keys[idx], err = parseSortKey(kind, line[idx])
+		if err != nil {
+			return record, err
+		}
+	}
+	record.values = line[len(w.sort.kinds):]
+
+	return record, nil
+}
+
+// parseSortKey parses text as a sort key of the given kind.
+func parseSortKey(kind reflect.Kind, text string) (key interface{}, err error) {
+	switch kind {
+	case reflect.Int64:
+		return strconv.ParseInt(text, 10, 64)
+	case reflect.Uint64:
+		return strconv.ParseUint(text, 10, 64)
+	case reflect.Float64:
+		return strconv.ParseFloat(text, 64)
+	}
+
+	return text, nil
+}
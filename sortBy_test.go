@@ -0,0 +1,194 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"testing"
+)
+
+type sortTest struct {
+	Region string  `csv:"header:region"`
+	Amount int     `csv:"header:amount"`
+	Rate   float64 `csv:"header:rate;precision:1"`
+}
+
+var sortRecords = []sortTest{
+	{Region: "west", Amount: 10, Rate: 0.5},
+	{Region: "east", Amount: 9, Rate: 1.5},
+	{Region: "west", Amount: 100, Rate: 2.5},
+	{Region: "east", Amount: 9, Rate: 3.5},
+	{Region: "north", Amount: 20, Rate: 4.5},
+}
+
+func writeSorted(t *testing.T, options WriterOptions) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, options)
+
+	err := w.WriteHeader(&sortTest{})
+	if err != nil {
+		t.Fatalf("encountered error writing csv header: %v", err)
+	}
+
+	for _, record := range sortRecords {
+		err = w.WriteRecord(&record)
+		if err != nil {
+			t.Fatalf("encountered error writing csv record: %v", err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing csv writer: %v", err)
+	}
+
+	return buf.String()
+}
+
+func TestSortBy(t *testing.T) {
+	got := writeSorted(t, WriterOptions{SortBy: []SortKey{{Field: "region"}, {Field: "Amount", Descending: true}}})
+
+	expected := "region,amount,rate\neast,9,1.5\neast,9,3.5\nnorth,20,4.5\nwest,100,2.5\nwest,10,0.5\n"
+	if got != expected {
+		t.Errorf("improperly sorted csv. Got '%s' but expected '%s'", got, expected)
+	}
+}
+
+func TestSortByNumeric(t *testing.T) {
+	got := writeSorted(t, WriterOptions{SortBy: []SortKey{{Field: "amount"}}})
+
+	expected := "region,amount,rate\neast,9,1.5\neast,9,3.5\nwest,10,0.5\nnorth,20,4.5\nwest,100,2.5\n"
+	if got != expected {
+		t.Errorf("expected amounts to be sorted as numbers. Got '%s' but expected '%s'", got, expected)
+	}
+}
+
+type sortFormattedTest struct {
+	Share float64 `csv:"header:share;percent"`
+	Price float64 `csv:"header:price;currency;precision:2"`
+	Big   int64   `csv:"header:big"`
+}
+
+func TestSortByFormattedNumbers(t *testing.T) {
+	records := []sortFormattedTest{
+		{Share: 0.1, Price: 1000, Big: 1<<62 + 1},
+		{Share: math.NaN(), Price: 5, Big: 1 << 62},
+		{Share: 0.09, Price: 20, Big: -3},
+		{Share: -0.5, Price: 300, Big: 1<<62 + 2},
+	}
+
+	for _, test := range []struct {
+		field    string
+		expected []int
+	}{
+		{field: "share", expected: []int{1, 3, 2, 0}},
+		{field: "price", expected: []int{1, 2, 3, 0}},
+		{field: "big", expected: []int{2, 1, 0, 3}},
+	} {
+		for _, limit := range []int{0, 1} {
+			var buf, expected bytes.Buffer
+			w := NewWriter(&buf, WriterOptions{SortBy: []SortKey{{Field: test.field}}, SortMemoryLimit: limit, SortTempDir: t.TempDir()})
+			unsorted := NewWriter(&expected, WriterOptions{})
+
+			for idx := range records {
+				err := w.WriteRecord(&records[idx])
+				if err != nil {
+					t.Fatalf("encountered error writing csv record: %v", err)
+				}
+
+				err = unsorted.WriteRecord(&records[test.expected[idx]])
+				if err != nil {
+					t.Fatalf("encountered error writing csv record: %v", err)
+				}
+			}
+
+			err := w.Close()
+			if err != nil {
+				t.Fatalf("encountered error closing csv writer: %v", err)
+			}
+			unsorted.Flush()
+
+			if buf.String() != expected.String() {
+				t.Errorf("expected %s to be sorted by value with a memory limit of %d. Got '%s' but expected '%s'", test.field, limit, buf.String(), expected.String())
+			}
+		}
+	}
+}
+
+func TestSortBySpill(t *testing.T) {
+	dir := t.TempDir()
+	options := WriterOptions{SortBy: []SortKey{{Field: "region"}, {Field: "amount", Descending: true}}, SortMemoryLimit: 2, SortTempDir: dir}
+
+	got := writeSorted(t, options)
+	expected := writeSorted(t, WriterOptions{SortBy: options.SortBy})
+	if got != expected {
+		t.Errorf("expected spilled runs to merge into the same order as an in-memory sort. Got '%s' but expected '%s'", got, expected)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected the temporary files to be removed, but found %d", len(entries))
+	}
+}
+
+func TestSortByMaps(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{Columns: []string{"id", "name"}, SortBy: []SortKey{{Field: "name"}}})
+
+	for _, record := range []map[string]string{{"id": "1", "name": "b"}, {"id": "2", "name": "a"}} {
+		err := w.WriteRecordMap(record)
+		if err != nil {
+			t.Fatalf("encountered error writing csv record: %v", err)
+		}
+	}
+
+	err := w.Close()
+	if err != nil {
+		t.Fatalf("encountered error closing csv writer: %v", err)
+	}
+
+	if buf.String() != "2,a\n1,b\n" {
+		t.Errorf("improperly sorted maps: '%s'", buf.String())
+	}
+}
+
+func TestSortByFieldNotFound(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, WriterOptions{SortBy: []SortKey{{Field: "missing"}}})
+
+	err := w.WriteRecord(&sortTest{})
+	if !errors.Is(err, ErrorColumnNotFound) {
+		t.Errorf("expected %v, but got %v", ErrorColumnNotFound, err)
+	}
+}
+
+func TestSortByOptionErrors(t *testing.T) {
+	tests := []struct {
+		options WriterOptions
+		option  string
+		err     error
+	}{
+		{WriterOptions{SortBy: []SortKey{{}}}, "SortBy", ErrorInvalidSortKey},
+		{WriterOptions{SortMemoryLimit: -1}, "SortMemoryLimit", ErrorInvalidSortMemoryLimit},
+	}
+
+	for _, test := range tests {
+		var optionErr OptionError
+		err := test.options.Validate()
+		if !errors.As(err, &optionErr) || optionErr.Option != test.option || !errors.Is(err, test.err) {
+			t.Errorf("expected %v for option %s, but got %v", test.err, test.option, err)
+		}
+	}
+
+	var parts memoryParts
+	chunked := NewChunkedWriter(parts.create, ChunkOptions{}, WriterOptions{SortBy: []SortKey{{Field: "region"}}})
+	err := chunked.WriteRecord(&sortTest{})
+	if !errors.Is(err, ErrorSortNotSupported) {
+		t.Errorf("expected %v from a chunked writer, but got %v", ErrorSortNotSupported, err)
+	}
+}
@@ -0,0 +1,205 @@
+package csv
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrorInvalidBatchSize = fmt.Errorf("batch size must be a positive integer")
+)
+
+// PlaceholderStyle is the bind parameter syntax of a database driver.
+type PlaceholderStyle int
+
+const (
+	// PlaceholderQuestion uses ? for every parameter, as MySQL and SQLite do.
+	PlaceholderQuestion PlaceholderStyle = iota
+	// PlaceholderDollar uses numbered parameters like $1, as PostgreSQL does.
+	PlaceholderDollar
+)
+
+// DBOptions controls how ScanIntoDB builds its insert statements.
+type DBOptions struct {
+	Placeholder PlaceholderStyle
+	// QuoteIdentifier quotes the table and column names. It defaults to ANSI double quotes, which MySQL needs replaced with backticks.
+	QuoteIdentifier func(identifier string) string
+	// NullEmpty inserts empty values as NULL instead of empty strings.
+	NullEmpty bool
+}
+
+func quoteIdentifierANSI(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// ScanIntoDB reads every remaining record from parser and inserts them into table with batched, parameterized insert statements inside a single transaction.
+// The columns are named by the header, which is read first if the parser hasn't parsed it yet. Values are passed to the driver as strings.
+// It returns the number of records inserted, and if an error occurs the transaction is rolled back.
+func ScanIntoDB(ctx context.Context, db *sql.DB, table string, parser *Parser, batchSize int, options DBOptions) (inserted int, err error) {
+	if batchSize <= 0 {
+		return 0, ErrorInvalidBatchSize
+	}
+
+	if options.QuoteIdentifier == nil {
+		options.QuoteIdentifier = quoteIdentifierANSI
+	}
+
+	if parser.header == nil {
+		_, err = parser.readHeader()
+		if err != nil {
+			return 0, err
+		}
+	}
+	columns := parser.header
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	args := make([]interface{}, 0, batchSize*len(columns))
+	rows := 0
+	for {
+		record, err := parser.readNextRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		for i := range columns {
+			var value interface{}
+			if i < len(record) {
+				value = record[i]
+			}
+			if options.NullEmpty && (value == nil || value == "") {
+				value = nil
+			}
+			args = append(args, value)
+		}
+		rows++
+
+		if rows == batchSize {
+			err = insertBatch(ctx, tx, table, columns, rows, args, options)
+			if err != nil {
+				tx.Rollback()
+				return 0, RecordError{
+					Line: parser.line,
+					Err:  err,
+				}
+			}
+			inserted += rows
+			rows = 0
+			args = args[:0]
+		}
+	}
+
+	if rows > 0 {
+		err = insertBatch(ctx, tx, table, columns, rows, args, options)
+		if err != nil {
+			tx.Rollback()
+			return 0, RecordError{
+				Line: parser.line,
+				Err:  err,
+			}
+		}
+		inserted += rows
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return inserted, nil
+}
+
+func insertBatch(ctx context.Context, tx *sql.Tx, table string, columns []string, rows int, args []interface{}, options DBOptions) (err error) {
+	var query strings.Builder
+
+	query.WriteString("INSERT INTO ")
+	query.WriteString(options.QuoteIdentifier(table))
+	query.WriteString(" (")
+	for i, column := range columns {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(options.QuoteIdentifier(column))
+	}
+	query.WriteString(") VALUES ")
+
+	param := 0
+	for row := 0; row < rows; row++ {
+		if row > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(")
+		for i := range columns {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			param++
+			if options.Placeholder == PlaceholderDollar {
+				query.WriteString("$" + strconv.Itoa(param))
+			} else {
+				query.WriteString("?")
+			}
+		}
+		query.WriteString(")")
+	}
+
+	_, err = tx.ExecContext(ctx, query.String(), args...)
+	return err
+}
+
+// WriteFromRows writes the column names of rows as a header to w, followed by every row as a csv record, and returns the number of rows written.
+// NULL values are written as empty values. The rows are not closed.
+func WriteFromRows(rows *sql.Rows, w io.Writer, options WriterOptions) (written int, err error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	writer := NewWriter(w, options)
+	err = writer.writeRaw(columns)
+	if err != nil {
+		return 0, err
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	record := make([]string, len(columns))
+
+	for rows.Next() {
+		err = rows.Scan(dest...)
+		if err != nil {
+			return written, err
+		}
+
+		for i, value := range values {
+			record[i] = value.String
+		}
+
+		err = writer.writeRaw(record)
+		if err != nil {
+			return written, err
+		}
+		written++
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return written, err
+	}
+
+	return written, writer.Close()
+}
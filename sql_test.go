@@ -0,0 +1,158 @@
+package csv
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeDB is a minimal database/sql driver that records statements and serves canned query results.
+type fakeDB struct {
+	queries    []string
+	args       [][]driver.Value
+	columns    []string
+	rows       [][]driver.Value
+	execErr    error
+	committed  bool
+	rolledBack bool
+}
+
+func (db *fakeDB) Connect(ctx context.Context) (driver.Conn, error) { return fakeConn{db}, nil }
+func (db *fakeDB) Driver() driver.Driver                            { return nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.db, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return fakeTx(c), nil }
+
+type fakeTx struct{ db *fakeDB }
+
+func (tx fakeTx) Commit() error   { tx.db.committed = true; return nil }
+func (tx fakeTx) Rollback() error { tx.db.rolledBack = true; return nil }
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if s.db.execErr != nil {
+		return nil, s.db.execErr
+	}
+	s.db.queries = append(s.db.queries, s.query)
+	s.db.args = append(s.db.args, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{columns: s.db.columns, rows: s.db.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestScanIntoDB(t *testing.T) {
+	fake := &fakeDB{}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	p := NewParser(strings.NewReader("id,name\n1,widget\n2,\n3,gizmo"), ParserOptions{})
+
+	inserted, err := ScanIntoDB(context.Background(), db, "products", &p, 2, DBOptions{Placeholder: PlaceholderDollar, NullEmpty: true})
+	if err != nil {
+		t.Errorf("encountered error scanning csv into database: %v", err)
+	}
+	if inserted != 3 {
+		t.Errorf("inserted %d records but expected 3", inserted)
+	}
+	if !fake.committed {
+		t.Errorf("expected the transaction to be committed")
+	}
+
+	expectedQueries := []string{
+		`INSERT INTO "products" ("id", "name") VALUES ($1, $2), ($3, $4)`,
+		`INSERT INTO "products" ("id", "name") VALUES ($1, $2)`,
+	}
+	if !reflect.DeepEqual(fake.queries, expectedQueries) {
+		t.Errorf("improperly built insert statements. Got '%v' but expected '%v'", fake.queries, expectedQueries)
+	}
+
+	expectedArgs := [][]driver.Value{{"1", "widget", "2", nil}, {"3", "gizmo"}}
+	if !reflect.DeepEqual(fake.args, expectedArgs) {
+		t.Errorf("improperly bound insert parameters. Got '%v' but expected '%v'", fake.args, expectedArgs)
+	}
+}
+
+func TestScanIntoDBRollback(t *testing.T) {
+	errorInsertFailed := errors.New("insert failed")
+	fake := &fakeDB{execErr: errorInsertFailed}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	p := NewParser(strings.NewReader("id,name\n1,widget"), ParserOptions{})
+
+	_, err := ScanIntoDB(context.Background(), db, "products", &p, 10, DBOptions{})
+	if !errors.Is(err, errorInsertFailed) {
+		t.Errorf("expected to encounter the insert error, but got %v", err)
+	}
+	if !fake.rolledBack || fake.committed {
+		t.Errorf("expected the transaction to be rolled back")
+	}
+}
+
+func TestWriteFromRows(t *testing.T) {
+	fake := &fakeDB{
+		columns: []string{"id", "name", "sold"},
+		rows: [][]driver.Value{
+			{int64(1), "widget, deluxe", time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)},
+			{int64(2), nil, nil},
+		},
+	}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id, name, sold FROM products")
+	if err != nil {
+		t.Errorf("encountered error querying fake database: %v", err)
+	}
+	defer rows.Close()
+
+	var buf bytes.Buffer
+	written, err := WriteFromRows(rows, &buf, WriterOptions{})
+	if err != nil {
+		t.Errorf("encountered error writing csv from rows: %v", err)
+	}
+	if written != 2 {
+		t.Errorf("wrote %d rows but expected 2", written)
+	}
+
+	expected := "id,name,sold\n1,\"widget, deluxe\",2022-03-04T00:00:00Z\n2,,\n"
+	if buf.String() != expected {
+		t.Errorf("improperly written csv from rows. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
@@ -0,0 +1,61 @@
+package csv
+
+import (
+	"fmt"
+)
+
+var (
+	ErrorExtraColumns           = fmt.Errorf("record has more columns than expected")
+	ErrorInvalidColumnTolerance = fmt.Errorf("extra column tolerance must not be negative")
+)
+
+// ExtraColumnsError is returned by ReadRecord for a record with more columns than expected, when the StrictColumns option is set.
+// Columns is the number of columns of the record, and Expected the most it may have.
+type ExtraColumnsError struct {
+	Line     int
+	Columns  int
+	Expected int
+	Err      error
+}
+
+func (e ExtraColumnsError) Error() string {
+	return fmt.Sprintf("record on line %d has %d columns, but no more than %d were expected: %v", e.Line, e.Columns, e.Expected, e.Err)
+}
+
+func (e ExtraColumnsError) Unwrap() error { return e.Err }
+
+// boundColumns returns one more than the highest column index bound by the parser's fields.
+func (p *Parser) boundColumns() (columns int) {
+	for _, fieldName := range p.fieldOrder {
+		if index := p.csvAttrs[fieldName].columnIndex; index >= columns {
+			columns = index + 1
+		}
+	}
+
+	return columns
+}
+
+// checkExtraColumns returns an ExtraColumnsError if the StrictColumns option is set and readRecord has more columns than expected.
+// That is the number of columns of the header once one is parsed, and otherwise boundColumns, plus the ExtraColumnTolerance.
+func (p *Parser) checkExtraColumns(readRecord []string, boundColumns int) (err error) {
+	if !p.options.StrictColumns {
+		return nil
+	}
+
+	expected := boundColumns
+	if p.header != nil {
+		expected = len(p.header)
+	}
+	expected += p.options.ExtraColumnTolerance
+
+	if len(readRecord) > expected {
+		return ExtraColumnsError{
+			Line:     p.line,
+			Columns:  len(readRecord),
+			Expected: expected,
+			Err:      ErrorExtraColumns,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package csv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type strictIndexed struct {
+	Code  string `csv:"index:0"`
+	Price int    `csv:"index:2"`
+}
+
+func TestStrictColumnsHeader(t *testing.T) {
+	reader := &multiCharReader{
+		lines: []string{"count|name", "3|widget", "4|gizmo, large|extra"},
+		delim: "|",
+	}
+	p := NewRecordParser(reader, ParserOptions{StrictColumns: true})
+
+	var record recordReaderTest
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Fatalf("encountered error parsing the header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading a record with as many columns as the header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+
+	var extraColumnsError ExtraColumnsError
+	if !errors.As(err, &extraColumnsError) || !errors.Is(err, ErrorExtraColumns) {
+		t.Fatalf("expected to encounter ErrorExtraColumns error, but got %v", err)
+	}
+	if extraColumnsError.Line != 2 || extraColumnsError.Columns != 3 || extraColumnsError.Expected != 2 {
+		t.Errorf("improperly reported the extra columns. Got '%+v'", extraColumnsError)
+	}
+}
+
+func TestStrictColumnsIndex(t *testing.T) {
+	tests := []struct {
+		line      string
+		tolerance int
+		expected  error
+	}{
+		{"a|b|3", 0, nil},
+		{"a|b|3|", 0, ErrorExtraColumns},
+		{"a|b|3|", 1, nil},
+		{"a|b|3||", 1, ErrorExtraColumns},
+	}
+
+	for _, test := range tests {
+		reader := &multiCharReader{lines: []string{test.line}, delim: "|"}
+		p := NewRecordParser(reader, ParserOptions{StrictColumns: true, ExtraColumnTolerance: test.tolerance})
+
+		err := p.ReadRecord(&strictIndexed{})
+		if !errors.Is(err, test.expected) {
+			t.Errorf("expected '%s' with a tolerance of %d to give %v error, but got %v", test.line, test.tolerance, test.expected, err)
+		}
+	}
+}
+
+func TestStrictColumnsMapper(t *testing.T) {
+	mapper := NewMapper[strictIndexed]().
+		BindIndex(0, func(record *strictIndexed, value string) error {
+			record.Code = value
+			return nil
+		})
+
+	mp := mapper.NewParser(strings.NewReader("a\n"), ParserOptions{StrictColumns: true})
+
+	var record strictIndexed
+	err := mp.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading a record with only the bound column: %v", err)
+	}
+
+	// The standard csv reader checks that records have as many columns as the first, so extra columns are caught in the first.
+	mp = mapper.NewParser(strings.NewReader("a,b\n"), ParserOptions{StrictColumns: true})
+	err = mp.ReadRecord(&record)
+	if !errors.Is(err, ErrorExtraColumns) {
+		t.Errorf("expected to encounter ErrorExtraColumns error, but got %v", err)
+	}
+}
+
+func TestExtraColumnToleranceValidate(t *testing.T) {
+	err := ParserOptions{ExtraColumnTolerance: -1}.Validate()
+	if !errors.Is(err, ErrorInvalidColumnTolerance) {
+		t.Errorf("expected to encounter ErrorInvalidColumnTolerance error, but got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package csv
+
+import (
+	"io"
+	"sync"
+)
+
+// SyncParser wraps a Parser so that ReadRecord can be called from many goroutines at once, such as a pool of workers sharing one file.
+// Each record is read by exactly one caller, but records are not handed out in any guaranteed order between goroutines, so use the line numbers passed to
+// AfterCsvRecordHook or reported in errors to tell records apart. Parser itself is not safe for concurrent use.
+type SyncParser struct {
+	mu     sync.Mutex
+	parser Parser
+}
+
+// NewSyncParser creates a new csv parser for the provided file that is safe for concurrent use.
+// Use ParserOptions to specify any desired changed from the default behavior as defined in the standard csv parser library.
+func NewSyncParser(file io.Reader, options ParserOptions) (sp *SyncParser) {
+	return &SyncParser{
+		parser: NewParser(file, options),
+	}
+}
+
+// ParseHeader reads the header as Parser.ParseHeader does. Call it before handing the parser to other goroutines.
+func (sp *SyncParser) ParseHeader(structType interface{}) (err error) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	return sp.parser.ParseHeader(structType)
+}
+
+// ReadRecord reads the next record into structPointer as Parser.ReadRecord does. Every goroutine should read into its own struct.
+func (sp *SyncParser) ReadRecord(structPointer interface{}) (err error) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	return sp.parser.ReadRecord(structPointer)
+}
+
+// Line returns the line number of the most recently read record, by any goroutine.
+func (sp *SyncParser) Line() int {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	return sp.parser.Line()
+}
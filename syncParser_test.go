@@ -0,0 +1,53 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSyncParser(t *testing.T) {
+	var data strings.Builder
+	data.WriteString("section,count\n")
+	for i := 1; i <= 1000; i++ {
+		fmt.Fprintf(&data, "s%d,%d\n", i, i)
+	}
+
+	sp := NewSyncParser(strings.NewReader(data.String()), ParserOptions{})
+	err := sp.ParseHeader(&peekTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	sums := make([]int, 4)
+	for worker := range sums {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for {
+				var record peekTest
+				err := sp.ReadRecord(&record)
+				if err == io.EOF {
+					return
+				}
+				if err != nil {
+					t.Errorf("encountered error reading csv record concurrently: %v", err)
+					return
+				}
+				sums[worker] += record.Count
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, sum := range sums {
+		total += sum
+	}
+	if total != 500500 {
+		t.Errorf("expected every record to be read exactly once, but the counts summed to %d", total)
+	}
+}
@@ -0,0 +1,127 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrorUnknownAttribute = fmt.Errorf("attribute is not a csv tag attribute")
+)
+
+// knownAttributes holds the keys of every attribute a csv tag may have.
+var knownAttributes = map[string]bool{
+	headerAttr:          true,
+	indexAttr:           true,
+	useCustomSetterAttr: true,
+	precisionAttr:       true,
+	percentAttr:         true,
+	currencyAttr:        true,
+	minAttr:             true,
+	maxAttr:             true,
+	regexAttr:           true,
+	posAttr:             true,
+	trimAttr:            true,
+	omitemptyAttr:       true,
+	headerRegexAttr:     true,
+	allowSharedAttr:     true,
+	maskAttr:            true,
+	encryptedAttr:       true,
+}
+
+// TagIssue is a problem with the csv tag of a field, as reported by CheckStruct. Err is the error a parser would return for it, such as ErrorInvalidIndex or a DuplicateBindingError.
+type TagIssue struct {
+	FieldName string
+	CsvTag    string
+	Err       error
+}
+
+func (e TagIssue) Error() string {
+	return fmt.Sprintf("problem with csv tag \"%s\" on field %s: %v", e.CsvTag, e.FieldName, e.Err)
+}
+
+func (e TagIssue) Unwrap() error { return e.Err }
+
+// CheckStruct checks every csv tag defined on structPointer, and returns every problem it finds, in the order of the fields, rather than the first as ParseHeader and ReadRecord do.
+// It reports unknown attributes, such as a misspelled trim, bad indexes, fields bound to the same column, and fields of types that can't be set without a CustomSetter.
+// Call it from a test or at startup, so that mistakes in tags are found before data arrives. Tags are checked as a parser with the default options reads them.
+func CheckStruct(structPointer interface{}) (issues []TagIssue) {
+	err := checkStructPointer(structPointer)
+	if err != nil {
+		return []TagIssue{{Err: err}}
+	}
+
+	structType := reflect.TypeOf(structPointer)
+	options := tagOptions{ignoreUnknown: true}
+
+	_, errs := readCsvAttributes(structType.Elem(), options, supportsCustomSetter(structType), ErrorMissingCustomSetter, ErrorUnsupportedDataType, true)
+	fieldErrs := make(map[string][]error)
+	for _, err := range errs {
+		if tagErr, ok := err.(CsvTagDefError); ok {
+			fieldErrs[tagErr.FieldName] = append(fieldErrs[tagErr.FieldName], tagErr.Err)
+		}
+	}
+
+	for i := 0; i < structType.Elem().NumField(); i++ {
+		field := structType.Elem().Field(i)
+		tag := fieldTag(field, options)
+
+		for _, err := range fieldErrs[field.Name] {
+			issues = append(issues, TagIssue{FieldName: field.Name, CsvTag: tag, Err: err})
+		}
+
+		for _, attribute := range unknownAttributes(tag) {
+			issues = append(issues, TagIssue{
+				FieldName: field.Name,
+				CsvTag:    tag,
+				Err: UnknownAttributeError{
+					Attribute: attribute,
+					Err:       ErrorUnknownAttribute,
+				},
+			})
+		}
+	}
+
+	return issues
+}
+
+// UnknownAttributeError holds an attribute of a csv tag that isn't a csv tag attribute, usually because it is misspelled.
+type UnknownAttributeError struct {
+	Attribute string
+	Err       error
+}
+
+func (e UnknownAttributeError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Attribute, e.Err)
+}
+
+func (e UnknownAttributeError) Unwrap() error { return e.Err }
+
+// unknownAttributes returns the attributes of tag whose keys aren't csv tag attributes, including those scoped to a profile.
+func unknownAttributes(tag string) (unknown []string) {
+	if tag == "" || tag == "-" {
+		return nil
+	}
+
+	for _, attribute := range splitTag(tag) {
+		if strings.HasPrefix(attribute, profilePrefix) {
+			_, attribute, _ = strings.Cut(strings.TrimPrefix(attribute, profilePrefix), valueDelim)
+		}
+
+		key, _, _ := strings.Cut(attribute, valueDelim)
+		if key != "" && !knownAttributes[key] {
+			unknown = append(unknown, attribute)
+		}
+	}
+
+	return unknown
+}
+
+// supportsCustomSetter reports whether values of structType, a pointer to a struct, set their own fields by implementing CustomSetter or CustomSetterV2.
+func supportsCustomSetter(structType reflect.Type) bool {
+	customDataSetter := reflect.TypeOf((*CustomSetter)(nil)).Elem()
+	customDataSetterV2 := reflect.TypeOf((*CustomSetterV2)(nil)).Elem()
+
+	return structType.Implements(customDataSetter) || structType.Implements(customDataSetterV2)
+}
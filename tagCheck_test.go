@@ -0,0 +1,59 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+)
+
+type lintedStruct struct {
+	Name    string   `csv:"header:name;trimm"`
+	Code    string   `csv:"index:-1"`
+	Total   int      `csv:"header:total;precision:2"`
+	Copy    string   `csv:"header:name"`
+	Channel chan int `csv:"header:channel"`
+	Scoped  string   `csv:"profile=vendorA:hedaer:x;index:4"`
+	Ignored string   `json:"ignored"`
+}
+
+func TestCheckStruct(t *testing.T) {
+	issues := CheckStruct(&lintedStruct{})
+
+	expected := []struct {
+		fieldName string
+		err       error
+	}{
+		{"Name", ErrorUnknownAttribute},
+		{"Code", ErrorInvalidIndex},
+		{"Total", ErrorPrecisionNotFloat},
+		{"Copy", ErrorDuplicateBinding},
+		{"Channel", ErrorUnsupportedDataType},
+		{"Scoped", ErrorUnknownAttribute},
+	}
+
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, but got %d: %v", len(expected), len(issues), issues)
+	}
+
+	for i, issue := range issues {
+		if issue.FieldName != expected[i].fieldName || !errors.Is(issue, expected[i].err) {
+			t.Errorf("expected issue %d to be %v on field %s, but got %v", i, expected[i].err, expected[i].fieldName, issue)
+		}
+	}
+
+	var unknown UnknownAttributeError
+	if !errors.As(issues[0], &unknown) || unknown.Attribute != "trimm" {
+		t.Errorf("expected the unknown attribute to be trimm, but got %v", issues[0])
+	}
+}
+
+func TestCheckStructClean(t *testing.T) {
+	issues := CheckStruct(&sharedBinding{})
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, but got %v", issues)
+	}
+
+	issues = CheckStruct(lintedStruct{})
+	if len(issues) != 1 || !errors.Is(issues[0], ErrorInvalidArgument) {
+		t.Errorf("expected to encounter ErrorInvalidArgument error, but got %v", issues)
+	}
+}
@@ -0,0 +1,140 @@
+package csv
+
+import (
+	"reflect"
+)
+
+// TagSpec describes the attributes of a csv tag, for tools that inspect the csv layout of structs, such as code generators, schema validators and form builders.
+// Attributes that take a value are only meaningful when their Has field is set.
+type TagSpec struct {
+	// HeaderName is the header the field is bound by. It is empty for a header attribute without a value, whose name is derived from the field name.
+	HeaderName string
+	HasHeader  bool
+	// Index is the column the field is bound by, as written in the tag.
+	Index           int
+	HasIndex        bool
+	UseCustomSetter bool
+	Precision       int
+	HasPrecision    bool
+	Percent         bool
+	Currency        bool
+	Min             float64
+	HasMin          bool
+	Max             float64
+	HasMax          bool
+	// Regex is the regular expression values must match, and HeaderRegex the one headers of the columns bound by the field match. Both are empty when they aren't set.
+	Regex       string
+	HeaderRegex string
+	// PosStart and PosEnd are the first and last character positions of a fixed-width field, counted from 1.
+	PosStart    int
+	PosEnd      int
+	HasPos      bool
+	Trim        bool
+	Omitempty   bool
+	AllowShared bool
+	// Mask names the masker applied to the field's values, and is empty when it isn't set.
+	Mask      string
+	Encrypted bool
+}
+
+// ColumnSpec describes a field of a struct that is bound to csv columns, as returned by StructColumns. The TagSpec holds the header name derived for a header attribute
+// without a value, and Index counts columns from 0.
+type ColumnSpec struct {
+	FieldName string
+	// FieldIndex is the index of the field in the struct, as used by reflect.Value.Field.
+	FieldIndex int
+	Type       reflect.Type
+	TagSpec
+}
+
+// ParseTag reads the attributes of a csv tag, such as header:amount;precision:2, as parsers and writers read them. Attributes scoped to a profile are left out.
+// Problems with the tag are returned as the errors parsers return for them, such as ErrorInvalidIndex or an UnknownAttributeError.
+func ParseTag(tag string) (spec TagSpec, err error) {
+	tag, _ = selectProfile(tag, "")
+
+	attrs, err := getAttributesFromTag(tag)
+	if err != nil {
+		return spec, err
+	}
+
+	return attrs.spec(), nil
+}
+
+// StructColumns returns the fields of t, a struct type or a pointer to one, that are bound to csv columns by their csv tags, in the order they are defined.
+// Tags are read as a parser with the default options reads them, and the first problem with them is returned as a CsvTagDefError.
+func StructColumns(t reflect.Type) (columns []ColumnSpec, err error) {
+	if t == nil || (t.Kind() == reflect.Pointer && t.Elem().Kind() != reflect.Struct) || (t.Kind() != reflect.Pointer && t.Kind() != reflect.Struct) {
+		received := "nil"
+		if t != nil {
+			received = t.String()
+		}
+
+		return nil, ArgumentError{
+			Received: received,
+			Err:      ErrorInvalidArgument,
+		}
+	}
+
+	if t.Kind() == reflect.Struct {
+		t = reflect.PointerTo(t)
+	}
+
+	csvAttrs, errs := readCsvAttributes(t.Elem(), tagOptions{}, supportsCustomSetter(t), ErrorMissingCustomSetter, ErrorUnsupportedDataType, false)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	for _, fieldName := range getFieldOrder(csvAttrs) {
+		attrs := csvAttrs[fieldName]
+		field := t.Elem().Field(attrs.fieldIndex)
+
+		columns = append(columns, ColumnSpec{
+			FieldName:  fieldName,
+			FieldIndex: attrs.fieldIndex,
+			Type:       field.Type,
+			TagSpec:    attrs.spec(),
+		})
+	}
+
+	return columns, nil
+}
+
+// spec returns the attributes as a TagSpec.
+func (attrs csvAttributes) spec() (spec TagSpec) {
+	spec = TagSpec{
+		HeaderName:      attrs.headerName,
+		HasHeader:       attrs.hasHeader,
+		Index:           attrs.columnIndex,
+		HasIndex:        attrs.hasIndex,
+		UseCustomSetter: attrs.useCustomSetter,
+		Precision:       attrs.precision,
+		HasPrecision:    attrs.hasPrecision,
+		Percent:         attrs.percent,
+		Currency:        attrs.currency,
+		Min:             attrs.min,
+		HasMin:          attrs.hasMin,
+		Max:             attrs.max,
+		HasMax:          attrs.hasMax,
+		HasPos:          attrs.hasPos,
+		Trim:            attrs.trim,
+		Omitempty:       attrs.omitempty,
+		AllowShared:     attrs.allowShared,
+		Mask:            attrs.mask,
+		Encrypted:       attrs.encrypted,
+	}
+
+	if attrs.pattern != nil {
+		spec.Regex = attrs.pattern.String()
+	}
+
+	if attrs.headerPattern != nil {
+		spec.HeaderRegex = attrs.headerPattern.String()
+	}
+
+	if attrs.hasPos {
+		spec.PosStart = attrs.posStart + 1
+		spec.PosEnd = attrs.posEnd
+	}
+
+	return spec
+}
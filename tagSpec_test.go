@@ -0,0 +1,74 @@
+package csv
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	spec, err := ParseTag("header:'unit;price';precision:2;min:0;regex:^[0-9.]+$;trim;profile=vendorA:index:4")
+	if err != nil {
+		t.Fatalf("encountered error parsing the tag: %v", err)
+	}
+
+	expected := TagSpec{
+		HeaderName:   "unit;price",
+		HasHeader:    true,
+		Precision:    2,
+		HasPrecision: true,
+		HasMin:       true,
+		Regex:        "^[0-9.]+$",
+		Trim:         true,
+	}
+	if spec != expected {
+		t.Errorf("improperly parsed the tag. Expected '%+v', but got '%+v'", expected, spec)
+	}
+
+	spec, err = ParseTag("pos:3-8")
+	if err != nil || spec.PosStart != 3 || spec.PosEnd != 8 || !spec.HasPos {
+		t.Errorf("expected positions 3 to 8, but got '%+v' and error %v", spec, err)
+	}
+
+	_, err = ParseTag("index:x")
+	if !errors.Is(err, ErrorInvalidIndex) {
+		t.Errorf("expected to encounter ErrorInvalidIndex error, but got %v", err)
+	}
+}
+
+type columnsStruct struct {
+	Name    string  `csv:"header"`
+	Price   float64 `csv:"index:3;precision:2"`
+	Ignored string
+}
+
+func TestStructColumns(t *testing.T) {
+	for _, structType := range []reflect.Type{reflect.TypeOf(columnsStruct{}), reflect.TypeOf(&columnsStruct{})} {
+		columns, err := StructColumns(structType)
+		if err != nil {
+			t.Fatalf("encountered error reading the columns of %v: %v", structType, err)
+		}
+
+		if len(columns) != 2 {
+			t.Fatalf("expected 2 columns, but got '%+v'", columns)
+		}
+
+		if columns[0].FieldName != "Name" || columns[0].HeaderName != "Name" || columns[0].Type != reflect.TypeOf("") {
+			t.Errorf("improperly described the first column. Got '%+v'", columns[0])
+		}
+
+		if columns[1].FieldName != "Price" || columns[1].FieldIndex != 1 || columns[1].Index != 3 || columns[1].Precision != 2 {
+			t.Errorf("improperly described the second column. Got '%+v'", columns[1])
+		}
+	}
+
+	_, err := StructColumns(reflect.TypeOf(&lintedStruct{}))
+	if !errors.Is(err, ErrorUnknownAttribute) {
+		t.Errorf("expected to encounter ErrorUnknownAttribute error, but got %v", err)
+	}
+
+	_, err = StructColumns(reflect.TypeOf(3))
+	if !errors.Is(err, ErrorInvalidArgument) {
+		t.Errorf("expected to encounter ErrorInvalidArgument error, but got %v", err)
+	}
+}
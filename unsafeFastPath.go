@@ -0,0 +1,124 @@
+package csv
+
+import (
+	"reflect"
+	"strconv"
+	"unsafe"
+)
+
+// canUseUnsafeFastPath reports whether a field can be set through setValueUnsafe. Only unnamed default data types without a converter or custom setter can.
+func canUseUnsafeFastPath(fieldType reflect.Type, attrs csvAttributes) bool {
+	if attrs.converter != nil || attrs.useCustomSetter || fieldType.PkgPath() != "" {
+		return false
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+
+	return false
+}
+
+// setValueUnsafe converts value as described by attrs, and writes it directly to the memory of the field at the offset recorded by bind.
+// It converts values exactly as setValue does, but skips the reflect.Value for the field and the checks made by its setters.
+func (p *Parser) setValueUnsafe(structPointer interface{}, attrs csvAttributes, value string) (err error) {
+	ptr := unsafe.Add(reflect.ValueOf(structPointer).UnsafePointer(), attrs.offset)
+
+	if attrs.currency {
+		value = stripCurrency(value)
+	}
+
+	switch attrs.kind {
+	case reflect.String:
+		if p.options.CloneStrings {
+			value = cloneString(value)
+		}
+		*(*string)(ptr) = value
+	case reflect.Bool:
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*(*bool)(ptr) = boolValue
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intValue, err := strconv.ParseInt(value, 10, integerBits(attrs.kind))
+		if err != nil {
+			return err
+		}
+		switch attrs.kind {
+		case reflect.Int:
+			*(*int)(ptr) = int(intValue)
+		case reflect.Int8:
+			*(*int8)(ptr) = int8(intValue)
+		case reflect.Int16:
+			*(*int16)(ptr) = int16(intValue)
+		case reflect.Int32:
+			*(*int32)(ptr) = int32(intValue)
+		case reflect.Int64:
+			*(*int64)(ptr) = intValue
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintValue, err := strconv.ParseUint(value, 10, integerBits(attrs.kind))
+		if err != nil {
+			return err
+		}
+		switch attrs.kind {
+		case reflect.Uint:
+			*(*uint)(ptr) = uint(uintValue)
+		case reflect.Uint8:
+			*(*uint8)(ptr) = uint8(uintValue)
+		case reflect.Uint16:
+			*(*uint16)(ptr) = uint16(uintValue)
+		case reflect.Uint32:
+			*(*uint32)(ptr) = uint32(uintValue)
+		case reflect.Uint64:
+			*(*uint64)(ptr) = uintValue
+		}
+	case reflect.Float32:
+		floatValue, err := p.parseFloat(attrs, value, 32)
+		if err != nil {
+			return err
+		}
+		*(*float32)(ptr) = float32(floatValue)
+	case reflect.Float64:
+		floatValue, err := p.parseFloat(attrs, value, 64)
+		if err != nil {
+			return err
+		}
+		*(*float64)(ptr) = floatValue
+	case reflect.Complex64:
+		cmplxValue, err := p.parseComplex(value, 64)
+		if err != nil {
+			return err
+		}
+		*(*complex64)(ptr) = complex64(cmplxValue)
+	case reflect.Complex128:
+		cmplxValue, err := p.parseComplex(value, 128)
+		if err != nil {
+			return err
+		}
+		*(*complex128)(ptr) = cmplxValue
+	}
+
+	return nil
+}
+
+// integerBits returns the size in bits of integers of the given kind, so that values are parsed at the size of the field they are written to.
+func integerBits(kind reflect.Kind) int {
+	switch kind {
+	case reflect.Int8, reflect.Uint8:
+		return 8
+	case reflect.Int16, reflect.Uint16:
+		return 16
+	case reflect.Int32, reflect.Uint32:
+		return 32
+	case reflect.Int64, reflect.Uint64:
+		return 64
+	}
+
+	return strconv.IntSize
+}
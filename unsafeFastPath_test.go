@@ -0,0 +1,97 @@
+package csv
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestUnsafeFastPathDataTypes(t *testing.T) {
+	p := NewParser(strings.NewReader(typesTestData), ParserOptions{UnsafeFastPath: true})
+
+	err := p.ParseHeader(&dataTypesTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+
+	for i := 0; true; i++ {
+		var data dataTypesTest
+		err := p.ReadRecord(&data)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Errorf("encountered error parsing csv with the unsafe fast path: %v", err)
+			break
+		}
+
+		if data != typesTestResults[i] {
+			t.Errorf("expected the unsafe fast path to parse %+v, but got %+v", typesTestResults[i], data)
+		}
+	}
+}
+
+type unsafeFastPathTest struct {
+	Price   float64 `csv:"index:0;currency;max:100"`
+	Percent float32 `csv:"index:1;percent"`
+	Count   int16   `csv:"index:2;omitempty"`
+	Active  yesNo   `csv:"index:3"`
+}
+
+func TestUnsafeFastPathAttributes(t *testing.T) {
+	p := NewParser(strings.NewReader("$12.50,25%,,Y\n$150,1%,3,N"), ParserOptions{UnsafeFastPath: true})
+
+	record := unsafeFastPathTest{Count: 9}
+	err := p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with the unsafe fast path: %v", err)
+	}
+
+	expected := unsafeFastPathTest{Price: 12.5, Percent: 0.25, Count: 0, Active: true}
+	if record != expected {
+		t.Errorf("expected the unsafe fast path to parse %+v, but got %+v", expected, record)
+	}
+
+	err = p.ReadRecord(&record)
+	if !errors.Is(err, ErrorAboveMaximum) {
+		t.Errorf("expected to encounter ErrorAboveMaximum error, but got %v", err)
+	}
+}
+
+type unsafeBoundTest struct {
+	X int    `csv:"index:0"`
+	S string `csv:"index:1"`
+}
+
+type unsafeOtherTypeTest struct {
+	P *int `csv:"index:0"`
+	Q int  `csv:"index:1"`
+}
+
+func TestUnsafeFastPathOtherStructType(t *testing.T) {
+	p := NewParser(strings.NewReader("1,a\n2,b"), ParserOptions{UnsafeFastPath: true})
+
+	err := p.ReadRecord(&unsafeBoundTest{})
+	if err != nil {
+		t.Errorf("encountered error parsing csv with the unsafe fast path: %v", err)
+	}
+
+	var other unsafeOtherTypeTest
+	err = p.ReadRecord(&other)
+	if !errors.Is(err, ErrorBoundTypeMismatch) {
+		t.Errorf("expected to encounter ErrorBoundTypeMismatch error, but got %v", err)
+	}
+	if other.P != nil || other.Q != 0 {
+		t.Errorf("expected a struct of another type to be left unchanged, but got %+v", other)
+	}
+
+	var record unsafeBoundTest
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv with the unsafe fast path: %v", err)
+	}
+	if record != (unsafeBoundTest{X: 2, S: "b"}) {
+		t.Errorf("expected the record after the rejected struct to be read, but got %+v", record)
+	}
+}
@@ -0,0 +1,271 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// DefaultUploadMaxBytes is the size limit of an uploaded file when UploadOptions doesn't set one.
+const DefaultUploadMaxBytes = 32 << 20
+
+// utf8BOM is the byte order mark that spreadsheet programs often write at the start of UTF-8 csv files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+var (
+	ErrorUploadFieldNotFound = fmt.Errorf("request has no file in the form field")
+	ErrorUploadTooLarge      = fmt.Errorf("uploaded file is larger than the size limit")
+	ErrorUploadContentType   = fmt.Errorf("uploaded file is not csv text")
+	ErrorUnsupportedCharset  = fmt.Errorf("charset of uploaded file is not supported")
+)
+
+// uploadContentTypes are the declared content types accepted for an uploaded csv file. Browsers label csv files inconsistently, so these are only a first check before the content is sniffed.
+var uploadContentTypes = map[string]bool{
+	"":                          true,
+	"text/csv":                  true,
+	"text/plain":                true,
+	"text/tab-separated-values": true,
+	"application/csv":           true,
+	"application/vnd.ms-excel":  true,
+	"application/octet-stream":  true,
+}
+
+type UploadOptions struct {
+	ParserOptions
+	// MaxBytes limits the size of the uploaded file. Reading past it fails with ErrorUploadTooLarge. It defaults to DefaultUploadMaxBytes.
+	MaxBytes int64
+}
+
+// ParseUpload creates a parser for the csv file uploaded in the named field of a multipart form, or for the body of a request sent with a csv content type, in which case fieldName is ignored.
+// The file is streamed rather than buffered to disk, and is limited to MaxBytes. Files whose declared content type isn't one used for csv, or whose content doesn't look like text, are rejected with ErrorUploadContentType.
+// A UTF-8 byte order mark is removed, and files declared or detected as UTF-16, ISO-8859-1 or Windows-1252 are decoded to UTF-8. Other charsets are rejected with ErrorUnsupportedCharset.
+// The size limit applies to the file only, so use http.MaxBytesReader to limit the rest of the request.
+func ParseUpload(r *http.Request, fieldName string, options UploadOptions) (p *Parser, err error) {
+	var source io.Reader
+	var contentType string
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		source, contentType, err = findUploadPart(r, fieldName)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		source, contentType = r.Body, r.Header.Get("Content-Type")
+	}
+
+	maxBytes := options.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultUploadMaxBytes
+	}
+
+	reader, err := decodeUpload(&uploadLimitReader{reader: source, remaining: maxBytes}, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := NewParser(reader, options.ParserOptions)
+
+	return &parser, nil
+}
+
+// findUploadPart returns the part of a multipart request holding the named form field, and its declared content type.
+func findUploadPart(r *http.Request, fieldName string) (part io.Reader, contentType string, err error) {
+	multipartReader, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", err
+	}
+
+	for {
+		nextPart, err := multipartReader.NextPart()
+		if err == io.EOF {
+			return nil, "", ErrorUploadFieldNotFound
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if nextPart.FormName() == fieldName {
+			return nextPart, nextPart.Header.Get("Content-Type"), nil
+		}
+	}
+}
+
+// decodeUpload checks the declared content type and sniffed content of an uploaded file, and returns a reader of its content as UTF-8.
+func decodeUpload(source io.Reader, contentType string) (reader io.Reader, err error) {
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	if !uploadContentTypes[mediaType] {
+		return nil, ErrorUploadContentType
+	}
+
+	// A file larger than the size limit still reports ErrorUploadTooLarge when the parser reaches the limit.
+	buffer := bufio.NewReader(source)
+	head, err := buffer.Peek(512)
+	if err != nil && err != io.EOF && err != ErrorUploadTooLarge {
+		return nil, err
+	}
+
+	sniffed, sniffedParams, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if len(head) > 0 && !strings.HasPrefix(sniffed, "text/") {
+		return nil, ErrorUploadContentType
+	}
+
+	charset := strings.ToLower(params["charset"])
+	if charset == "" && strings.HasPrefix(sniffedParams["charset"], "utf-16") {
+		charset = sniffedParams["charset"]
+	}
+
+	switch charset {
+	case "", "utf-8", "utf8", "us-ascii":
+		if bytes.HasPrefix(head, utf8BOM) {
+			buffer.Discard(3)
+		}
+		return buffer, nil
+	case "iso-8859-1", "latin1":
+		return &transcodeReader{source: buffer, decode: decodeLatin1}, nil
+	case "windows-1252", "cp1252":
+		return &transcodeReader{source: buffer, decode: decodeWindows1252}, nil
+	case "utf-16", "utf-16be", "utf-16le":
+		bigEndian := charset != "utf-16le"
+		if len(head) >= 2 && head[0] == 0xFF && head[1] == 0xFE {
+			bigEndian = false
+			buffer.Discard(2)
+		} else if len(head) >= 2 && head[0] == 0xFE && head[1] == 0xFF {
+			bigEndian = true
+			buffer.Discard(2)
+		}
+		return &transcodeReader{source: buffer, decode: func(source *bufio.Reader) (rune, error) { return decodeUTF16(source, bigEndian) }}, nil
+	}
+
+	return nil, ErrorUnsupportedCharset
+}
+
+// uploadLimitReader reads from reader until remaining bytes have been read, and then fails with ErrorUploadTooLarge if there is more to read.
+type uploadLimitReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (l *uploadLimitReader) Read(b []byte) (n int, err error) {
+	if int64(len(b)) > l.remaining+1 {
+		b = b[:l.remaining+1]
+	}
+
+	n, err = l.reader.Read(b)
+	if int64(n) > l.remaining {
+		n = int(l.remaining)
+		l.remaining = 0
+		return n, ErrorUploadTooLarge
+	}
+	l.remaining -= int64(n)
+
+	return n, err
+}
+
+// transcodeReader reads runes from source with decode, and returns them encoded as UTF-8.
+type transcodeReader struct {
+	source  *bufio.Reader
+	decode  func(source *bufio.Reader) (r rune, err error)
+	pending []byte
+}
+
+func (t *transcodeReader) Read(b []byte) (n int, err error) {
+	for n < len(b) {
+		if len(t.pending) > 0 {
+			copied := copy(b[n:], t.pending)
+			t.pending = t.pending[copied:]
+			n += copied
+			continue
+		}
+
+		r, err := t.decode(t.source)
+		if err == io.EOF && n > 0 {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+
+		if r < utf8.RuneSelf {
+			b[n] = byte(r)
+			n++
+			continue
+		}
+
+		var encoded [utf8.UTFMax]byte
+		t.pending = append(t.pending[:0], encoded[:utf8.EncodeRune(encoded[:], r)]...)
+	}
+
+	return n, nil
+}
+
+func decodeLatin1(source *bufio.Reader) (r rune, err error) {
+	b, err := source.ReadByte()
+	return rune(b), err
+}
+
+// windows1252 maps the bytes 0x80 to 0x9F, where Windows-1252 differs from ISO-8859-1. Bytes it leaves undefined map to the code point of the same value.
+var windows1252 = [32]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021, 0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014, 0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
+
+func decodeWindows1252(source *bufio.Reader) (r rune, err error) {
+	b, err := source.ReadByte()
+	if b >= 0x80 && b < 0xA0 {
+		return windows1252[b-0x80], err
+	}
+	return rune(b), err
+}
+
+// decodeUTF16 decodes the next rune from source, decoding invalid or truncated code units as utf8.RuneError.
+func decodeUTF16(source *bufio.Reader, bigEndian bool) (r rune, err error) {
+	unit, err := readUTF16Unit(source, bigEndian)
+	if err != nil {
+		return 0, err
+	}
+
+	if !utf16.IsSurrogate(unit) {
+		return unit, nil
+	}
+
+	next, err := source.Peek(2)
+	if err != nil {
+		return utf8.RuneError, nil
+	}
+
+	second := rune(next[0])<<8 | rune(next[1])
+	if !bigEndian {
+		second = rune(next[1])<<8 | rune(next[0])
+	}
+
+	r = utf16.DecodeRune(unit, second)
+	if r != utf8.RuneError {
+		source.Discard(2)
+	}
+
+	return r, nil
+}
+
+func readUTF16Unit(source *bufio.Reader, bigEndian bool) (unit rune, err error) {
+	first, err := source.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	second, err := source.ReadByte()
+	if err != nil {
+		return utf8.RuneError, nil
+	}
+
+	if bigEndian {
+		return rune(first)<<8 | rune(second), nil
+	}
+	return rune(second)<<8 | rune(first), nil
+}
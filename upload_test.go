@@ -0,0 +1,147 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+type uploadTest struct {
+	Name  string `csv:"header:name"`
+	Price int    `csv:"header:price"`
+}
+
+// newUploadRequest builds a multipart request with a note field, followed by a file field holding content with the given content type.
+func newUploadRequest(fieldName string, contentType string, content []byte) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("note", "monthly prices")
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="`+fieldName+`"; filename="prices.csv"`)
+	header.Set("Content-Type", contentType)
+	part, _ := form.CreatePart(header)
+	part.Write(content)
+	form.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	r.Header.Set("Content-Type", form.FormDataContentType())
+
+	return r
+}
+
+func readUploadNames(p *Parser) (names []string, err error) {
+	var record uploadTest
+	err = p.ParseHeader(&record)
+	for err == nil {
+		err = p.ReadRecord(&record)
+		if err == nil {
+			names = append(names, record.Name)
+		}
+	}
+
+	if err == io.EOF {
+		return names, nil
+	}
+	return names, err
+}
+
+func TestParseUpload(t *testing.T) {
+	testCases := []struct {
+		contentType string
+		content     []byte
+		expected    string
+	}{
+		{"text/csv", []byte("\xef\xbb\xbfname,price\nCafé,3\n"), "Café"},
+		{"text/csv; charset=iso-8859-1", []byte("name,price\nCaf\xe9,3\n"), "Café"},
+		{"text/csv; charset=windows-1252", []byte("name,price\n\x93Caf\xe9\x94,3\n"), "“Café”"},
+		{"text/plain", []byte("\xff\xfen\x00a\x00m\x00e\x00,\x00p\x00r\x00i\x00c\x00e\x00\n\x00=\xd8\x00\xde,\x003\x00\n\x00"), "😀"},
+	}
+
+	for _, testCase := range testCases {
+		p, err := ParseUpload(newUploadRequest("file", testCase.contentType, testCase.content), "file", UploadOptions{})
+		if err != nil {
+			t.Errorf("encountered error parsing upload with content type %s: %v", testCase.contentType, err)
+			continue
+		}
+
+		names, err := readUploadNames(p)
+		if err != nil {
+			t.Errorf("encountered error reading upload with content type %s: %v", testCase.contentType, err)
+		}
+
+		if len(names) != 1 || names[0] != testCase.expected {
+			t.Errorf("improperly decoded upload with content type %s. Got '%v' but expected '%v'", testCase.contentType, names, testCase.expected)
+		}
+	}
+}
+
+func TestParseUploadBody(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("name,price\nWidget,3\n"))
+	r.Header.Set("Content-Type", "text/csv")
+
+	p, err := ParseUpload(r, "", UploadOptions{})
+	if err != nil {
+		t.Errorf("encountered error parsing upload body: %v", err)
+		return
+	}
+
+	names, err := readUploadNames(p)
+	if err != nil || len(names) != 1 || names[0] != "Widget" {
+		t.Errorf("improperly read upload body. Got '%v' and error %v", names, err)
+	}
+}
+
+func TestParseUploadErrors(t *testing.T) {
+	_, err := ParseUpload(newUploadRequest("file", "text/csv", []byte("name,price\n")), "other", UploadOptions{})
+	if !errors.Is(err, ErrorUploadFieldNotFound) {
+		t.Errorf("expected to encounter ErrorUploadFieldNotFound error, but got %v", err)
+	}
+
+	_, err = ParseUpload(newUploadRequest("file", "image/png", []byte("name,price\n")), "file", UploadOptions{})
+	if !errors.Is(err, ErrorUploadContentType) {
+		t.Errorf("expected to encounter ErrorUploadContentType error for declared type, but got %v", err)
+	}
+
+	_, err = ParseUpload(newUploadRequest("file", "application/octet-stream", []byte("PK\x03\x04\x14\x00\x06\x00")), "file", UploadOptions{})
+	if !errors.Is(err, ErrorUploadContentType) {
+		t.Errorf("expected to encounter ErrorUploadContentType error for sniffed type, but got %v", err)
+	}
+
+	_, err = ParseUpload(newUploadRequest("file", "text/csv; charset=shift_jis", []byte("name,price\n")), "file", UploadOptions{})
+	if !errors.Is(err, ErrorUnsupportedCharset) {
+		t.Errorf("expected to encounter ErrorUnsupportedCharset error, but got %v", err)
+	}
+}
+
+func TestParseUploadTooLarge(t *testing.T) {
+	content := "name,price\n" + strings.Repeat("Widget,3\n", 100)
+
+	p, err := ParseUpload(newUploadRequest("file", "text/csv", []byte(content)), "file", UploadOptions{MaxBytes: int64(len(content))})
+	if err != nil {
+		t.Errorf("encountered error parsing upload: %v", err)
+		return
+	}
+
+	names, err := readUploadNames(p)
+	if err != nil || len(names) != 100 {
+		t.Errorf("expected to read 100 records from upload at the size limit, but got %d and error %v", len(names), err)
+	}
+
+	p, err = ParseUpload(newUploadRequest("file", "text/csv", []byte(content)), "file", UploadOptions{MaxBytes: 100})
+	if err != nil {
+		t.Errorf("encountered error parsing upload: %v", err)
+		return
+	}
+
+	_, err = readUploadNames(p)
+	if !errors.Is(err, ErrorUploadTooLarge) {
+		t.Errorf("expected to encounter ErrorUploadTooLarge error, but got %v", err)
+	}
+}
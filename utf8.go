@@ -0,0 +1,55 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrorInvalidUTF8       = fmt.Errorf("value is not valid UTF-8")
+	ErrorInvalidUTF8Policy = fmt.Errorf("invalid UTF-8 policy must be UTF8PassThrough, UTF8Replace or UTF8Error")
+)
+
+// UTF8Policy decides what ReadRecord does with values that aren't valid UTF-8.
+type UTF8Policy int
+
+const (
+	// UTF8PassThrough sets values as they are, whether or not they are valid UTF-8.
+	UTF8PassThrough UTF8Policy = iota
+	// UTF8Replace replaces each run of invalid bytes with the Unicode replacement character.
+	UTF8Replace
+	// UTF8Error rejects values that aren't valid UTF-8 with an InvalidUTF8Error.
+	UTF8Error
+)
+
+// checkUTF8 applies the InvalidUTF8 policy to the value of the named field, found in the given column.
+func (p *Parser) checkUTF8(fieldName string, column int, value string) (checked string, err error) {
+	if p.options.InvalidUTF8 == UTF8PassThrough || utf8.ValidString(value) {
+		return value, nil
+	}
+
+	if p.options.InvalidUTF8 == UTF8Replace {
+		return strings.ToValidUTF8(value, string(utf8.RuneError)), nil
+	}
+
+	return value, InvalidUTF8Error{
+		Line:      p.line,
+		Column:    column,
+		FieldName: fieldName,
+		Err:       ErrorInvalidUTF8,
+	}
+}
+
+type InvalidUTF8Error struct {
+	Line      int
+	Column    int
+	FieldName string
+	Err       error
+}
+
+func (e InvalidUTF8Error) Error() string {
+	return fmt.Sprintf("record on line %d: value in column %d for field %s: %v", e.Line, e.Column, e.FieldName, e.Err)
+}
+
+func (e InvalidUTF8Error) Unwrap() error { return e.Err }
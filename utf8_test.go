@@ -0,0 +1,46 @@
+package csv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const invalidUTF8TestData = "ok,caf\xe9,1"
+
+type utf8Test struct {
+	Name  string `csv:"index:1"`
+	Count int    `csv:"index:2"`
+}
+
+func TestInvalidUTF8PassThrough(t *testing.T) {
+	p := NewParser(strings.NewReader(invalidUTF8TestData), ParserOptions{})
+
+	var record utf8Test
+	err := p.ReadRecord(&record)
+	if err != nil || record.Name != "caf\xe9" {
+		t.Errorf("expected invalid UTF-8 to be passed through, but got %q and error %v", record.Name, err)
+	}
+}
+
+func TestInvalidUTF8Replace(t *testing.T) {
+	p := NewParser(strings.NewReader(invalidUTF8TestData), ParserOptions{InvalidUTF8: UTF8Replace})
+
+	var record utf8Test
+	err := p.ReadRecord(&record)
+	if err != nil || record.Name != "caf�" {
+		t.Errorf("expected invalid UTF-8 to be replaced, but got %q and error %v", record.Name, err)
+	}
+}
+
+func TestInvalidUTF8Error(t *testing.T) {
+	p := NewParser(strings.NewReader(invalidUTF8TestData), ParserOptions{InvalidUTF8: UTF8Error})
+
+	err := p.ReadRecord(&utf8Test{})
+	var utf8Err InvalidUTF8Error
+	if !errors.As(err, &utf8Err) || !errors.Is(err, ErrorInvalidUTF8) {
+		t.Errorf("expected to encounter ErrorInvalidUTF8 error, but got %v", err)
+	} else if utf8Err.Line != 1 || utf8Err.Column != 1 || utf8Err.FieldName != "Name" {
+		t.Errorf("expected the error to point at line 1, column 1 and field Name, but got %v", utf8Err)
+	}
+}
@@ -0,0 +1,72 @@
+package csv
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+var (
+	ErrorBelowMinimum    = fmt.Errorf("value is below the minimum")
+	ErrorAboveMaximum    = fmt.Errorf("value is above the maximum")
+	ErrorPatternMismatch = fmt.Errorf("value does not match the regex")
+)
+
+// validateFieldValue enforces the min, max and regex attributes of a field. The regex is matched against the raw csv value,
+// while min and max are compared against the value that was set on the field.
+func validateFieldValue(structPointer interface{}, fieldName string, attrs csvAttributes, value string) (rule string, err error) {
+	return validateValue(reflect.ValueOf(structPointer).Elem().Field(attrs.fieldIndex), attrs, value)
+}
+
+// validateValue enforces the min, max and regex attributes of a field on value, which was set on field.
+func validateValue(field reflect.Value, attrs csvAttributes, value string) (rule string, err error) {
+	if attrs.pattern != nil && !attrs.pattern.MatchString(value) {
+		return fmt.Sprintf("%s:%s", regexAttr, attrs.pattern), ErrorPatternMismatch
+	}
+
+	if !attrs.hasMin && !attrs.hasMax {
+		return "", nil
+	}
+
+	var number float64
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number = float64(field.Uint())
+	case reflect.Float32, reflect.Float64:
+		number = field.Float()
+	}
+
+	// NaN compares false with every bound, so it would pass both rules unless it is rejected as outside them.
+	if math.IsNaN(number) {
+		if attrs.hasMin {
+			return fmt.Sprintf("%s:%v", minAttr, attrs.min), ErrorBelowMinimum
+		}
+		return fmt.Sprintf("%s:%v", maxAttr, attrs.max), ErrorAboveMaximum
+	}
+
+	if attrs.hasMin && number < attrs.min {
+		return fmt.Sprintf("%s:%v", minAttr, attrs.min), ErrorBelowMinimum
+	}
+
+	if attrs.hasMax && number > attrs.max {
+		return fmt.Sprintf("%s:%v", maxAttr, attrs.max), ErrorAboveMaximum
+	}
+
+	return "", nil
+}
+
+type ValidationError struct {
+	Line      int
+	Value     string
+	FieldName string
+	Rule      string
+	Err       error
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("record on line %d: value %s on field %s failed validation rule %s: %v", e.Line, e.Value, e.FieldName, e.Rule, e.Err)
+}
+
+func (e ValidationError) Unwrap() error { return e.Err }
@@ -0,0 +1,179 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type validationTest struct {
+	Age   int    `csv:"index:0;min:0;max:150"`
+	Email string `csv:"index:1;regex:^[^@]+@[^@]+$"`
+}
+
+func TestValidationAttributes(t *testing.T) {
+	p := NewParser(strings.NewReader("42,someone@example.com\n-1,someone@example.com\n200,someone@example.com\n42,not an email"), ParserOptions{})
+
+	data := validationTest{}
+	err := p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing valid record: %v", err)
+	}
+
+	expectedErrors := []error{ErrorBelowMinimum, ErrorAboveMaximum, ErrorPatternMismatch}
+	expectedRules := []string{"min:0", "max:150", "regex:^[^@]+@[^@]+$"}
+	for i, expectedErr := range expectedErrors {
+		err := p.ReadRecord(&data)
+		if !errors.Is(err, expectedErr) {
+			t.Errorf("expected to encounter %v, but got %v", expectedErr, err)
+		}
+
+		var validationErr ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected to encounter a ValidationError, but got %v", err)
+			continue
+		}
+		if validationErr.Line != i+2 {
+			t.Errorf("validation error reported line %d but expected %d", validationErr.Line, i+2)
+		}
+		if validationErr.Rule != expectedRules[i] {
+			t.Errorf("validation error reported rule %s but expected %s", validationErr.Rule, expectedRules[i])
+		}
+	}
+}
+
+type minMaxNotNumeric struct {
+	Name string `csv:"index:0;min:1"`
+}
+
+func TestMinMaxNotNumericError(t *testing.T) {
+	p := NewParser(strings.NewReader("name"), ParserOptions{})
+
+	err := p.ReadRecord(&minMaxNotNumeric{})
+	if !errors.Is(err, ErrorMinMaxNotNumeric) {
+		t.Errorf("expected to encounter Min Max Not Numeric error, but got %v", err)
+	}
+}
+
+type invalidRegex struct {
+	Name string `csv:"index:0;regex:[a-z"`
+}
+
+func TestInvalidRegexError(t *testing.T) {
+	p := NewParser(strings.NewReader("name"), ParserOptions{})
+
+	err := p.ReadRecord(&invalidRegex{})
+	if !errors.Is(err, ErrorInvalidRegex) {
+		t.Errorf("expected to encounter Invalid Regex error, but got %v", err)
+	}
+}
+
+var errorAdultsOnly = fmt.Errorf("must be an adult")
+
+func TestValidatorOption(t *testing.T) {
+	validator := func(record interface{}, line int) error {
+		if record.(*validationTest).Age < 18 {
+			return errorAdultsOnly
+		}
+		return nil
+	}
+	p := NewParser(strings.NewReader("42,someone@example.com\n12,child@example.com"), ParserOptions{Validator: validator})
+
+	data := validationTest{}
+	err := p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing valid record: %v", err)
+	}
+
+	err = p.ReadRecord(&data)
+	if !errors.Is(err, errorAdultsOnly) {
+		t.Errorf("expected to encounter error from validator, but got %v", err)
+	}
+	var recordErr RecordError
+	if !errors.As(err, &recordErr) || recordErr.Line != 2 {
+		t.Errorf("expected a RecordError on line 2, but got %v", err)
+	}
+}
+
+type partialRecordTest struct {
+	Quantity int    `csv:"index:0"`
+	Age      int    `csv:"index:1;max:150"`
+	Note     string `csv:"index:2"`
+}
+
+func TestPartialRecordError(t *testing.T) {
+	p := NewParser(strings.NewReader("x,200,ok\n5,12,good"), ParserOptions{PartialRecords: true})
+
+	data := partialRecordTest{}
+	err := p.ReadRecord(&data)
+
+	var partialErr PartialRecordError
+	if !errors.As(err, &partialErr) || !errors.Is(err, ErrorPartialRecord) || partialErr.Line != 1 {
+		t.Errorf("expected a PartialRecordError on line 1, but got %v", err)
+	}
+	if len(partialErr.Errors) != 2 {
+		t.Errorf("expected 2 field errors, but got %v", partialErr.Errors)
+	}
+
+	var setValueErr SetValueError
+	if len(partialErr.Errors) > 0 && (!errors.As(partialErr.Errors[0], &setValueErr) || setValueErr.FieldName != "Quantity") {
+		t.Errorf("expected the first field error to be a SetValueError on Quantity, but got %v", partialErr.Errors[0])
+	}
+	if len(partialErr.Errors) > 1 && !errors.Is(partialErr.Errors[1], ErrorAboveMaximum) {
+		t.Errorf("expected the second field error to be Above Maximum, but got %v", partialErr.Errors[1])
+	}
+	if data.Note != "ok" {
+		t.Errorf("expected the fields that parsed cleanly to be set, but got '%+v'", data)
+	}
+
+	err = p.ReadRecord(&data)
+	if err != nil {
+		t.Errorf("encountered error parsing valid record in partial record mode: %v", err)
+	}
+}
+
+type validationBoundsTest struct {
+	Level int8    `csv:"index:0;max:100"`
+	Ratio float64 `csv:"index:1;min:0"`
+	Count uint8   `csv:"index:2"`
+}
+
+func TestValidationOutOfRange(t *testing.T) {
+	for _, unsafeFastPath := range []bool{false, true} {
+		p := NewParser(strings.NewReader("300,0.5,1\n1,NaN,1\n1,0.5,256\n-129,0.5,1\n"), ParserOptions{UnsafeFastPath: unsafeFastPath})
+
+		var data validationBoundsTest
+		err := p.ReadRecord(&data)
+		var setErr SetValueError
+		if !errors.As(err, &setErr) || !errors.Is(err, strconv.ErrRange) {
+			t.Errorf("expected a value overflowing an int8 field to be out of range, but got %v and %+v", err, data)
+		}
+
+		err = p.ReadRecord(&data)
+		if !errors.Is(err, ErrorBelowMinimum) {
+			t.Errorf("expected NaN to fail the min rule, but got %v", err)
+		}
+
+		for _, field := range []string{"Count", "Level"} {
+			err = p.ReadRecord(&data)
+			if !errors.As(err, &setErr) || setErr.FieldName != field || !errors.Is(err, strconv.ErrRange) {
+				t.Errorf("expected a value overflowing %s to be out of range, but got %v", field, err)
+			}
+		}
+	}
+}
+
+type validationNaNMaxTest struct {
+	Ratio float32 `csv:"index:0;max:1"`
+}
+
+func TestValidationNaNMax(t *testing.T) {
+	p := NewParser(strings.NewReader("NaN\n"), ParserOptions{})
+
+	err := p.ReadRecord(&validationNaNMaxTest{})
+	if !errors.Is(err, ErrorAboveMaximum) {
+		t.Errorf("expected NaN to fail the max rule, but got %v", err)
+	}
+}
@@ -0,0 +1,506 @@
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrorMissingCustomGetter       = fmt.Errorf("cannot use custom data type without implementing CustomGetter interface")
+	ErrorUnsupportedWriterDataType = fmt.Errorf("must implement CustomGetter interface when using unsupported data types")
+	ErrorColumnNotFound            = fmt.Errorf("column does not match the header or name of any csv tagged field")
+	ErrorDuplicateColumn           = fmt.Errorf("column may only be listed once")
+)
+
+type CustomGetter interface {
+	CustomGetter(fieldName string) (value string, err error)
+}
+
+// BeforeCsvRecordHook may be implemented by a struct to be called by WriteRecord before any fields of a record are written.
+// Use it to populate computed or denormalized fields just in time, or to refuse to write a record by returning an error.
+type BeforeCsvRecordHook interface {
+	BeforeCsvRecord() (err error)
+}
+
+// Writer writes structs with csv decorator tags as csv records. A Writer is not safe for concurrent use.
+type Writer struct {
+	writer       *csv.Writer
+	line         int
+	csvAttrs     map[string]csvAttributes
+	boundType    reflect.Type
+	columns      []string
+	fieldOrder   []string
+	fieldColumns map[string]int
+	mapColumns   map[string]int
+	useEncoder   bool
+	optionsErr   error
+	options      WriterOptions
+	flushEvery   int
+	unflushed    int
+	closed       bool
+	compressor   compressor
+	sort         *sortState
+}
+
+type WriterOptions struct {
+	Delimiter rune
+	UseCRLF   bool
+	// Converters are used by this writer in preference to any converters registered with RegisterConverter.
+	Converters map[reflect.Type]Converter
+	// Columns lists the columns to write, in order, by header name or field name. Fields that aren't listed are not written.
+	// When writing maps with WriteRecordMap, Columns lists the keys to write, and is required.
+	// When Columns is empty, fields are placed by their index attribute, and the rest fill the free columns in struct order.
+	Columns []string
+	// NullToken is written in place of the zero value of fields using the omitempty attribute. It defaults to an empty value.
+	NullToken string
+	// TagName replaces csv as the struct tag key that fields are written with, as described by ParserOptions.TagName.
+	TagName string
+	// Profile selects the attributes of csv tags scoped to a profile, as described by ParserOptions.Profile.
+	Profile string
+	// IndexBase is the number of the first column in index attributes, as described by ParserOptions.IndexBase.
+	IndexBase int
+	// HeaderNaming derives the header name of fields from their field names, as described by ParserOptions.HeaderNaming.
+	HeaderNaming NamingStrategy
+	// FieldNamer derives header names from fields in place of HeaderNaming, as described by ParserOptions.FieldNamer.
+	FieldNamer FieldNamer
+	// HeaderCase transforms the case of the header names written by WriteHeader and WriteHeaderMap, such as to Title Case with spaces for human-facing exports. It doesn't change how fields are matched to Columns.
+	HeaderCase HeaderCase
+	// DeriveHeaders writes every exported field without a csv tag, as described by ParserOptions.DeriveHeaders.
+	DeriveHeaders bool
+	// Compression compresses the csv data as it is written, so that an export is produced compressed in a single pass. Close must be called to finish the compressed stream.
+	Compression Compression
+	// CompressionLevel is the gzip compression level, from gzip.HuffmanOnly to gzip.BestCompression. Zero uses gzip.DefaultCompression.
+	CompressionLevel int
+	// SortBy sorts the records written by WriteRecord and WriteRecordMap by the listed fields, so that exports are written in a deterministic order. The sorted records are written by Close.
+	// Numeric fields are compared by their value rather than their formatted text, and other fields as text. Records with equal keys keep the order they were written in.
+	SortBy []SortKey
+	// SortMemoryLimit is the most records held in memory while sorting. Beyond it, sorted runs of records are spilled to temporary files and merged by Close. It defaults to 100000.
+	SortMemoryLimit int
+	// SortTempDir is the directory temporary files are spilled to while sorting. It defaults to os.TempDir.
+	SortTempDir string
+	// IncludeFields lists the only fields to write, by header name or field name, so that one struct can produce several export shapes. The listed fields keep their usual layout,
+	// as if the other fields weren't on the struct. When it is empty, every field is written.
+	IncludeFields []string
+	// ExcludeFields lists fields to leave out, by header name or field name, such as to omit columns holding personal data. It is applied after IncludeFields.
+	ExcludeFields []string
+	// Maskers are used by this writer in preference to any maskers registered with RegisterMasker.
+	Maskers map[string]Masker
+	// FieldEncryptor encrypts the values of fields with the encrypted attribute as they are written. Writing such a field without one returns ErrorMissingFieldEncryptor.
+	FieldEncryptor FieldEncryptor
+}
+
+// NewWriter creates a new csv writer for the provided file that supports the csv struct decorator tag.
+// Use WriterOptions to specify any desired changed from the default behavior as defined in the standard csv writer library.
+// If the options are invalid, every write returns the OptionError reported by WriterOptions.Validate.
+func NewWriter(file io.Writer, options WriterOptions) (w Writer) {
+	w.optionsErr = options.Validate()
+	if w.optionsErr == nil {
+		w.compressor = newCompressor(file, options)
+	}
+	if w.compressor != nil {
+		file = w.compressor
+	}
+
+	w.writer = csv.NewWriter(file)
+	w.csvAttrs = make(map[string]csvAttributes)
+	w.options = options
+
+	// Keep default value if zero-value rune is passed in
+	if legalDelimiter(options.Delimiter) {
+		w.writer.Comma = options.Delimiter
+	}
+
+	w.writer.UseCRLF = options.UseCRLF
+
+	return w
+}
+
+func getCsvWriteAttributes(structPointer interface{}, options tagOptions) (csvAttrs map[string]csvAttributes, err error) {
+	customDataGetter := reflect.TypeOf((*CustomGetter)(nil)).Elem()
+	supportsCustomData := reflect.TypeOf(structPointer).Implements(customDataGetter)
+
+	return getCsvAttributesWith(structPointer, options, supportsCustomData, ErrorMissingCustomGetter, ErrorUnsupportedWriterDataType)
+}
+
+func (w *Writer) tagOptions() tagOptions {
+	return tagOptions{
+		converters:    w.options.Converters,
+		tagName:       w.options.TagName,
+		profile:       w.options.Profile,
+		indexBase:     w.options.IndexBase,
+		headerNaming:  w.options.HeaderNaming,
+		fieldNamer:    w.options.FieldNamer,
+		deriveHeaders: w.options.DeriveHeaders,
+		maskers:       w.options.Maskers,
+	}
+}
+
+// getColumnOrder lays out the fields described by csvAttrs as columns. Fields with an index attribute are placed at that index,
+// and the remaining fields fill the free columns in the order they are defined on the struct. Unused columns are left as empty strings.
+// A field sharing its column with an earlier field is left out, so the column is written once, from the earlier field.
+func getColumnOrder(csvAttrs map[string]csvAttributes) (columns []string, err error) {
+	var fieldNames []string
+	for _, fieldName := range getFieldOrder(csvAttrs) {
+		if !csvAttrs[fieldName].shared {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+
+	for _, fieldName := range fieldNames {
+		attrs := csvAttrs[fieldName]
+		if !attrs.hasIndex {
+			continue
+		}
+		for len(columns) <= attrs.columnIndex {
+			columns = append(columns, "")
+		}
+		if columns[attrs.columnIndex] != "" {
+			return nil, CsvTagDefError{
+				CsvTag:    attrs.tag,
+				FieldName: fieldName,
+				Err:       ErrorDuplicateIndex,
+			}
+		}
+		columns[attrs.columnIndex] = fieldName
+	}
+
+	next := 0
+	for _, fieldName := range fieldNames {
+		if csvAttrs[fieldName].hasIndex {
+			continue
+		}
+		for next < len(columns) && columns[next] != "" {
+			next++
+		}
+		if next == len(columns) {
+			columns = append(columns, "")
+		}
+		columns[next] = fieldName
+	}
+
+	return columns, nil
+}
+
+// getListedColumnOrder lays out the fields described by csvAttrs in the order of listed, which names each column by header name or field name.
+func getListedColumnOrder(csvAttrs map[string]csvAttributes, listed []string) (columns []string, err error) {
+	fieldNames := getFieldOrder(csvAttrs)
+	columns = make([]string, len(listed))
+	used := make(map[string]bool, len(listed))
+
+	for idx, column := range listed {
+		for _, fieldName := range fieldNames {
+			attrs := csvAttrs[fieldName]
+			if (attrs.hasHeader && attrs.headerName == column) || fieldName == column {
+				columns[idx] = fieldName
+				break
+			}
+		}
+
+		if columns[idx] == "" {
+			return nil, FieldNotFoundError{
+				FieldName:  column,
+				HeaderName: column,
+				Err:        ErrorColumnNotFound,
+			}
+		}
+
+		if used[columns[idx]] {
+			return nil, CsvTagDefError{
+				CsvTag:    csvAttrs[columns[idx]].tag,
+				FieldName: columns[idx],
+				Err:       ErrorDuplicateColumn,
+			}
+		}
+		used[columns[idx]] = true
+	}
+
+	return columns, nil
+}
+
+func (w *Writer) bind(structPointer interface{}) (err error) {
+	if w.optionsErr != nil {
+		return w.optionsErr
+	}
+
+	err = checkStructPointer(structPointer)
+	if err != nil {
+		return err
+	}
+
+	if len(w.csvAttrs) != 0 {
+		return w.checkBoundType(reflect.TypeOf(structPointer))
+	}
+
+	w.csvAttrs, err = getCsvWriteAttributes(structPointer, w.tagOptions())
+	if err != nil {
+		return err
+	}
+
+	for fieldName, attrs := range w.csvAttrs {
+		if attrs.headerPattern != nil {
+			w.csvAttrs = make(map[string]csvAttributes)
+			return CsvTagDefError{
+				CsvTag:    attrs.tag,
+				FieldName: fieldName,
+				Err:       ErrorHeaderRegexNotWritable,
+			}
+		}
+	}
+
+	w.csvAttrs, err = selectFields(w.csvAttrs, w.options.IncludeFields, w.options.ExcludeFields)
+	if err != nil {
+		w.csvAttrs = make(map[string]csvAttributes)
+		return err
+	}
+
+	var columns []string
+	if len(w.options.Columns) > 0 {
+		columns, err = getListedColumnOrder(w.csvAttrs, w.options.Columns)
+	} else {
+		columns, err = getColumnOrder(w.csvAttrs)
+	}
+	if err != nil {
+		w.csvAttrs = make(map[string]csvAttributes)
+		return err
+	}
+	w.fieldOrder = getFieldOrder(w.csvAttrs)
+	w.setColumns(columns)
+
+	// Generated codecs are generated from csv tags outside of any profile, so they don't match fields bound by another tag name or profile, or by derived headers,
+	// and they encode every field, so they don't match a selection of fields.
+	_, implementsEncoder := structPointer.(RecordEncoder)
+	selectsFields := len(w.options.IncludeFields) > 0 || len(w.options.ExcludeFields) > 0
+	w.useEncoder = implementsEncoder && w.tagOptions().key() == tagName && w.options.Profile == "" && !w.options.DeriveHeaders && !selectsFields && canUseCodec(w.csvAttrs)
+	w.boundType = reflect.TypeOf(structPointer)
+
+	return nil
+}
+
+// checkBoundType returns an error if structPointerType isn't the type the writer was first used with, whose fields its columns are laid out for.
+func (w *Writer) checkBoundType(structPointerType reflect.Type) (err error) {
+	if w.boundType == nil || structPointerType == w.boundType {
+		return nil
+	}
+
+	return ArgumentError{
+		Received: structPointerType.String(),
+		Err:      ErrorBoundTypeMismatch,
+	}
+}
+
+// setColumns lays out the bound fields as columns, where each column holds a field name, or an empty string for a column no field is written to.
+func (w *Writer) setColumns(columns []string) {
+	w.columns = columns
+	w.fieldColumns = make(map[string]int, len(w.fieldOrder))
+	for idx, fieldName := range w.columns {
+		if fieldName != "" {
+			w.fieldColumns[fieldName] = idx
+		}
+	}
+}
+
+// WriteHeader writes a header line to the writer's csv file using the header names described by the csv decorator tags defined on structPointer.
+// The structPointer should be pointer to a struct with csv decorator tags applied. Columns bound only by index are given an empty header,
+// unless HeaderNaming, FieldNamer or DeriveHeaders is set, in which case their header name is derived from the field. HeaderCase is applied to every header name.
+func (w *Writer) WriteHeader(structPointer interface{}) (err error) {
+	err = w.bind(structPointer)
+	if err != nil {
+		return err
+	}
+
+	options := w.tagOptions()
+	namesIndexFields := w.options.HeaderNaming != NameFieldName || w.options.FieldNamer != nil || w.options.DeriveHeaders
+	structType := reflect.TypeOf(structPointer).Elem()
+
+	header := make([]string, len(w.columns))
+	for idx, fieldName := range w.columns {
+		if fieldName == "" {
+			continue
+		}
+
+		attrs := w.csvAttrs[fieldName]
+		name := attrs.headerName
+		if !attrs.hasHeader && namesIndexFields {
+			name = options.headerFor(structType.Field(attrs.fieldIndex))
+		}
+		header[idx] = w.options.HeaderCase.apply(name)
+	}
+
+	return w.write(header)
+}
+
+// WriteRecord writes the fields of structPointer to the next line of the writer's csv file as described by the csv decorator tags defined on structPointer.
+// The structPointer should be pointer to a struct with csv decorator tags applied, of the same type as the writer was first used with, or ErrorBoundTypeMismatch is returned.
+func (w *Writer) WriteRecord(structPointer interface{}) (err error) {
+	err = w.bind(structPointer)
+	if err != nil {
+		return err
+	}
+
+	w.line++
+
+	if hook, ok := structPointer.(BeforeCsvRecordHook); ok {
+		err = hook.BeforeCsvRecord()
+		if err != nil {
+			return RecordError{
+				Line: w.line,
+				Err:  err,
+			}
+		}
+	}
+
+	record := make([]string, len(w.columns))
+
+	if w.useEncoder {
+		err = w.encodeRecord(structPointer.(RecordEncoder), record)
+		if err != nil {
+			return err
+		}
+
+		return w.writeRecordValues(record, structPointer)
+	}
+
+	for idx, fieldName := range w.columns {
+		if fieldName == "" {
+			continue
+		}
+
+		// The NullToken stands for a missing value, so it is written as it is, rather than masked or encrypted as a value would be.
+		if w.isNull(structPointer, fieldName) {
+			record[idx] = w.options.NullToken
+			continue
+		}
+
+		record[idx], err = w.getFieldValue(structPointer, fieldName)
+		if err != nil {
+			return GetValueError{
+				Line:      w.line,
+				FieldName: fieldName,
+				Err:       err,
+			}
+		}
+		attrs := w.csvAttrs[fieldName]
+		record[idx], err = w.encryptValue(fieldName, attrs, attrs.applyMask(record[idx]))
+		if err != nil {
+			return GetValueError{
+				Line:      w.line,
+				FieldName: fieldName,
+				Err:       err,
+			}
+		}
+	}
+
+	return w.writeRecordValues(record, structPointer)
+}
+
+// writeRaw writes record as the next line of the writer's csv file, without binding a struct.
+func (w *Writer) writeRaw(record []string) (err error) {
+	if w.optionsErr != nil {
+		return w.optionsErr
+	}
+
+	return w.write(record)
+}
+
+// isNull reports whether the named field uses the omitempty attribute and holds its zero value, so that it is written as the NullToken.
+func (w *Writer) isNull(structPointer interface{}, fieldName string) bool {
+	return w.csvAttrs[fieldName].omitempty && reflect.ValueOf(structPointer).Elem().FieldByName(fieldName).IsZero()
+}
+
+func (w *Writer) getFieldValue(structPointer interface{}, fieldName string) (value string, err error) {
+	inStruct := reflect.ValueOf(structPointer)
+	field := inStruct.Elem().FieldByName(fieldName)
+	attrs := w.csvAttrs[fieldName]
+
+	if w.isNull(structPointer, fieldName) {
+		return w.options.NullToken, nil
+	}
+
+	if attrs.useCustomSetter {
+		return structPointer.(CustomGetter).CustomGetter(fieldName)
+	}
+
+	if attrs.converter != nil && attrs.converter.Format != nil {
+		return attrs.converter.Format(field.Interface())
+	}
+
+	switch fieldValue := field.Interface().(type) {
+	case string:
+		return fieldValue, nil
+	case bool:
+		return strconv.FormatBool(fieldValue), nil
+	case int, int8, int16, int32, int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+	case uint, uint8, uint16, uint32, uint64:
+		return strconv.FormatUint(field.Uint(), 10), nil
+	case float32:
+		return formatFloat(float64(fieldValue), attrs, 32), nil
+	case float64:
+		return formatFloat(fieldValue, attrs, 64), nil
+	case complex64:
+		return strconv.FormatComplex(complex128(fieldValue), 'f', -1, 64), nil
+	case complex128:
+		return strconv.FormatComplex(fieldValue, 'f', -1, 128), nil
+	}
+
+	return "", ErrorUnsupportedWriterDataType
+}
+
+func formatFloat(value float64, attrs csvAttributes, bitSize int) string {
+	precision := -1
+	if attrs.hasPrecision {
+		precision = attrs.precision
+	}
+
+	formatted := strconv.FormatFloat(value, 'f', precision, bitSize)
+
+	if attrs.percent && !math.IsNaN(value) && !math.IsInf(value, 0) {
+		return shiftDecimalPoint(formatted, 2) + "%"
+	}
+
+	return formatted
+}
+
+// shiftDecimalPoint multiplies the decimal number in formatted by 10^places without going through float arithmetic,
+// so that a value like 0.07 is formatted as 7 rather than 7.000000000000001.
+func shiftDecimalPoint(formatted string, places int) string {
+	sign := ""
+	if strings.HasPrefix(formatted, "-") {
+		sign = "-"
+		formatted = formatted[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(formatted, ".")
+	for len(fraction) < places {
+		fraction += "0"
+	}
+
+	integer = strings.TrimLeft(integer+fraction[:places], "0")
+	if integer == "" {
+		integer = "0"
+	}
+
+	fraction = fraction[places:]
+	if fraction == "" {
+		return sign + integer
+	}
+
+	return sign + integer + "." + fraction
+}
+
+type GetValueError struct {
+	Line      int
+	FieldName string
+	Err       error
+}
+
+func (e GetValueError) Error() string {
+	return fmt.Sprintf("record on line %d: problem getting value from field %s: %v", e.Line, e.FieldName, e.Err)
+}
+
+func (e GetValueError) Unwrap() error { return e.Err }
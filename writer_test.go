@@ -0,0 +1,310 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type writerHeaderTest struct {
+	IgnoredField int
+	Field1       string  `csv:"header:field1"`
+	Field2       int     `csv:"header:fieldTwo"`
+	Field3       float64 `csv:"header:Field3;precision:2"`
+	Field4       float64 `csv:"header:field4;percent"`
+}
+
+func TestWriteWithHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+
+	err := w.WriteHeader(&writerHeaderTest{})
+	if err != nil {
+		t.Errorf("encountered error writing csv header: %v", err)
+	}
+
+	records := []writerHeaderTest{
+		{IgnoredField: 1, Field1: "String", Field2: 12, Field3: 1.005, Field4: 0.07},
+		{IgnoredField: 2, Field1: "Other, String", Field2: -14, Field3: 48.3, Field4: 0.125},
+	}
+	for _, record := range records {
+		err := w.WriteRecord(&record)
+		if err != nil {
+			t.Errorf("encountered error writing csv record: %v", err)
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		t.Errorf("encountered error flushing csv writer: %v", err)
+	}
+
+	expected := "field1,fieldTwo,Field3,field4\nString,12,1.00,7%\n\"Other, String\",-14,48.30,12.5%\n"
+	if buf.String() != expected {
+		t.Errorf("improperly written csv with header. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+type writerIndexTest struct {
+	Field1 string `csv:"index:1"`
+	Field2 int    `csv:"index:3"`
+}
+
+func TestWriteWithoutHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{Delimiter: '\t'})
+
+	err := w.WriteRecord(&writerIndexTest{Field1: "first", Field2: 46})
+	if err != nil {
+		t.Errorf("encountered error writing csv record: %v", err)
+	}
+	w.Flush()
+
+	expected := "\tfirst\t\t46\n"
+	if buf.String() != expected {
+		t.Errorf("improperly written csv without header. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+type writerCustomGetter struct {
+	CustomField string `csv:"index:0;useCustomSetter"`
+}
+
+func (wcg *writerCustomGetter) CustomGetter(fieldName string) (value string, err error) {
+	if fieldName == "CustomField" {
+		return strings.ToUpper(wcg.CustomField), nil
+	}
+
+	return "", fmt.Errorf("unexpected call to CustomGetter")
+}
+
+func TestWriteCustomGetter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+
+	err := w.WriteRecord(&writerCustomGetter{CustomField: "value"})
+	if err != nil {
+		t.Errorf("encountered error writing csv record: %v", err)
+	}
+	w.Flush()
+
+	if buf.String() != "VALUE\n" {
+		t.Errorf("improperly written custom field. Got '%s' but expected 'VALUE'", buf.String())
+	}
+}
+
+func TestCustomGetterInterfaceError(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, WriterOptions{})
+
+	err := w.WriteHeader(&missingCustomSetter{})
+	if !errors.Is(err, ErrorMissingCustomGetter) {
+		t.Errorf("expected to encounter Missing Custom Getter error, but got %v", err)
+	}
+}
+
+var errorMissingName = fmt.Errorf("name is required")
+
+type beforeRecordTest struct {
+	FirstName string `csv:"header:first"`
+	LastName  string `csv:"header:last"`
+	FullName  string `csv:"header:full"`
+}
+
+func (brt *beforeRecordTest) BeforeCsvRecord() (err error) {
+	if brt.FirstName == "" {
+		return errorMissingName
+	}
+	brt.FullName = brt.FirstName + " " + brt.LastName
+	return nil
+}
+
+func TestBeforeCsvRecordHook(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+
+	err := w.WriteRecord(&beforeRecordTest{FirstName: "Ada", LastName: "Lovelace"})
+	if err != nil {
+		t.Errorf("encountered error writing csv record with before record hook: %v", err)
+	}
+	w.Flush()
+
+	if buf.String() != "Ada,Lovelace,Ada Lovelace\n" {
+		t.Errorf("before record hook did not populate computed field. Got '%s'", buf.String())
+	}
+
+	err = w.WriteRecord(&beforeRecordTest{LastName: "Nobody"})
+	if !errors.Is(err, errorMissingName) {
+		t.Errorf("expected to encounter error from before record hook, but got %v", err)
+	}
+	var recordErr RecordError
+	if !errors.As(err, &recordErr) || recordErr.Line != 2 {
+		t.Errorf("expected a RecordError on line 2, but got %v", err)
+	}
+}
+
+type writerColumnsTest struct {
+	ID    int     `csv:"header:id"`
+	Name  string  `csv:"header:name"`
+	Price float64 `csv:"header:price"`
+	Note  string  `csv:"index:3"`
+}
+
+func TestWriteWithColumns(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{Columns: []string{"price", "Note", "id"}})
+
+	err := w.WriteHeader(&writerColumnsTest{})
+	if err != nil {
+		t.Errorf("encountered error writing csv header: %v", err)
+	}
+
+	err = w.WriteRecord(&writerColumnsTest{ID: 1, Name: "widget", Price: 4.5, Note: "new"})
+	if err != nil {
+		t.Errorf("encountered error writing csv record: %v", err)
+	}
+	w.Flush()
+
+	expected := "price,,id\n4.5,new,1\n"
+	if buf.String() != expected {
+		t.Errorf("improperly written csv with listed columns. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+func TestWriteColumnsError(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, WriterOptions{Columns: []string{"id", "cost"}})
+
+	err := w.WriteHeader(&writerColumnsTest{})
+	if !errors.Is(err, ErrorColumnNotFound) {
+		t.Errorf("expected to encounter Column Not Found error, but got %v", err)
+	}
+
+	w = NewWriter(&bytes.Buffer{}, WriterOptions{Columns: []string{"id", "ID"}})
+
+	err = w.WriteHeader(&writerColumnsTest{})
+	if !errors.Is(err, ErrorDuplicateColumn) {
+		t.Errorf("expected to encounter Duplicate Column error, but got %v", err)
+	}
+}
+
+type writerDuplicateIndex struct {
+	Field1 string `csv:"index:1"`
+	Field2 string `csv:"index:1"`
+}
+
+func TestWriteDuplicateIndexError(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, WriterOptions{})
+
+	err := w.WriteRecord(&writerDuplicateIndex{})
+	if !errors.Is(err, ErrorDuplicateIndex) {
+		t.Errorf("expected to encounter Duplicate Index error, but got %v", err)
+	}
+
+	var tagErr CsvTagDefError
+	if !errors.As(err, &tagErr) || tagErr.FieldName != "Field2" {
+		t.Errorf("expected the duplicate index to be reported on Field2, but got %v", err)
+	}
+}
+
+type writerOmitEmptyTest struct {
+	Name       string  `csv:"header:name"`
+	MiddleName string  `csv:"header:middle_name;omitempty"`
+	Age        int     `csv:"header:age;omitempty"`
+	Score      float64 `csv:"header:score"`
+	Active     bool    `csv:"header:active;omitempty"`
+}
+
+func TestWriteOmitEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+
+	records := []writerOmitEmptyTest{
+		{Name: "Ada", MiddleName: "King", Age: 36, Score: 1, Active: true},
+		{Name: "Alan"},
+	}
+	for _, record := range records {
+		err := w.WriteRecord(&record)
+		if err != nil {
+			t.Errorf("encountered error writing csv record: %v", err)
+		}
+	}
+	w.Flush()
+
+	expected := "Ada,King,36,1,true\nAlan,,,0,\n"
+	if buf.String() != expected {
+		t.Errorf("improperly written omitempty fields. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+
+	buf.Reset()
+	w = NewWriter(&buf, WriterOptions{NullToken: "NULL"})
+	w.WriteRecord(&writerOmitEmptyTest{Name: "Alan"})
+	w.Flush()
+
+	expected = "Alan,NULL,NULL,0,NULL\n"
+	if buf.String() != expected {
+		t.Errorf("improperly written null token. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+
+	p := NewParser(strings.NewReader("name,middle_name,age,score,active\nAlan,,,0,"), ParserOptions{})
+	record := writerOmitEmptyTest{Age: 12}
+	err := p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing csv header: %v", err)
+	}
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading empty omitempty fields: %v", err)
+	}
+	if record != (writerOmitEmptyTest{Name: "Alan"}) {
+		t.Errorf("improperly read empty omitempty fields. Got '%+v'", record)
+	}
+}
+
+func TestWriteBoundTypeMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{})
+
+	err := w.WriteRecord(&writerOmitEmptyTest{Name: "Ada"})
+	if err != nil {
+		t.Errorf("encountered error writing csv record: %v", err)
+	}
+
+	err = w.WriteRecord(&writerColumnsTest{})
+	if !errors.Is(err, ErrorBoundTypeMismatch) {
+		t.Errorf("expected to encounter Bound Type Mismatch error, but got %v", err)
+	}
+
+	err = w.WriteHeader(&writerDuplicateIndex{})
+	if !errors.Is(err, ErrorBoundTypeMismatch) {
+		t.Errorf("expected to encounter Bound Type Mismatch error writing a header, but got %v", err)
+	}
+	w.Flush()
+
+	expected := "Ada,,,0,\n"
+	if buf.String() != expected {
+		t.Errorf("expected only the first record to be written. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
+
+type writerNullTokenTest struct {
+	Name  string `csv:"header:name"`
+	Notes string `csv:"header:notes;mask:redact;omitempty"`
+	SSN   string `csv:"header:ssn;encrypted;omitempty"`
+}
+
+func TestWriteNullTokenUnmasked(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{NullToken: "NULL", FieldEncryptor: base64Encryptor{}})
+
+	err := w.WriteRecord(&writerNullTokenTest{Name: "Ann"})
+	if err != nil {
+		t.Errorf("encountered error writing csv record: %v", err)
+	}
+	w.Flush()
+
+	expected := "Ann,NULL,NULL\n"
+	if buf.String() != expected {
+		t.Errorf("expected the null token to be written unmasked and unencrypted. Got '%s' but expected '%s'", buf.String(), expected)
+	}
+}
@@ -0,0 +1,347 @@
+// Package xlsx reads the rows of Excel worksheets as records, so that xlsx files can be bound to structs with the csv struct decorator tag
+// by creating a parser with csv.NewRecordParser, with the same conversion and validation as csv files.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxColumns is the number of columns of a worksheet, which limits the cell references a row is read with.
+const maxColumns = 16384
+
+var (
+	ErrorNotWorkbook   = fmt.Errorf("file is not an xlsx workbook")
+	ErrorSheetNotFound = fmt.Errorf("workbook has no sheet with the requested name")
+)
+
+// Reader reads the rows of a worksheet as records. It implements csv.RecordReader.
+// Rows with no cells are skipped, like blank lines of a csv file, and cells missing from a row are read as empty values.
+// Excel leaves out empty cells at the end of a row, so rows are padded to at least the width of the first row.
+type Reader struct {
+	sheet         io.ReadCloser
+	decoder       *xml.Decoder
+	sharedStrings []string
+	record        []string
+	width         int
+}
+
+type workbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// SheetNames returns the names of the worksheets of the workbook in file, in the order they appear in the workbook.
+func SheetNames(file io.ReaderAt, size int64) (names []string, err error) {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return nil, ErrorNotWorkbook
+	}
+
+	var book workbook
+	err = decodeFile(archive, "xl/workbook.xml", &book)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sheet := range book.Sheets {
+		names = append(names, sheet.Name)
+	}
+
+	return names, nil
+}
+
+// NewReader creates a reader of the rows of the named worksheet of the workbook in file, or of the first worksheet when sheet is empty.
+// Values are read as Excel stores them, so numbers are read without their display format, and dates are read as serial numbers, which ParseDate converts.
+// Close the reader when done with it.
+func NewReader(file io.ReaderAt, size int64, sheet string) (r *Reader, err error) {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return nil, ErrorNotWorkbook
+	}
+
+	var book workbook
+	err = decodeFile(archive, "xl/workbook.xml", &book)
+	if err != nil {
+		return nil, err
+	}
+
+	var rels relationships
+	err = decodeFile(archive, "xl/_rels/workbook.xml.rels", &rels)
+	if err != nil {
+		return nil, err
+	}
+
+	sheetID := ""
+	for _, s := range book.Sheets {
+		if sheet == "" || s.Name == sheet {
+			sheetID = s.ID
+			break
+		}
+	}
+	if sheetID == "" {
+		return nil, ErrorSheetNotFound
+	}
+
+	r = &Reader{}
+	sheetPath := ""
+	for _, rel := range rels.Relationships {
+		switch {
+		case rel.ID == sheetID:
+			sheetPath = relationshipPath(rel.Target)
+		case strings.HasSuffix(rel.Type, "/sharedStrings"):
+			r.sharedStrings, err = readSharedStrings(archive, relationshipPath(rel.Target))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sheetFile, err := archive.Open(sheetPath)
+	if err != nil {
+		return nil, ErrorNotWorkbook
+	}
+
+	r.sheet = sheetFile
+	r.decoder = xml.NewDecoder(sheetFile)
+
+	return r, nil
+}
+
+// Read returns the values of the next row of the worksheet that has any cells, or io.EOF after the last row.
+func (r *Reader) Read() (record []string, err error) {
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+
+		record, err = r.readRow()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record) == 0 {
+			continue
+		}
+
+		if r.width == 0 {
+			r.width = len(record)
+		}
+		for len(record) < r.width {
+			record = append(record, "")
+		}
+		r.record = record
+
+		return record, nil
+	}
+}
+
+// Close closes the worksheet being read.
+func (r *Reader) Close() (err error) {
+	return r.sheet.Close()
+}
+
+// readRow reads the cells of the row element the decoder has just started.
+func (r *Reader) readRow() (record []string, err error) {
+	r.record = r.record[:0]
+
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Local != "c" {
+				continue
+			}
+
+			column := len(r.record)
+			cellType := ""
+			for _, attr := range element.Attr {
+				switch attr.Name.Local {
+				case "r":
+					column = columnIndex(attr.Value, column)
+				case "t":
+					cellType = attr.Value
+				}
+			}
+
+			if column >= maxColumns {
+				return nil, ErrorNotWorkbook
+			}
+
+			value, err := r.readCell(cellType)
+			if err != nil {
+				return nil, err
+			}
+
+			for len(r.record) < column {
+				r.record = append(r.record, "")
+			}
+			r.record = append(r.record[:column], value)
+		case xml.EndElement:
+			if element.Name.Local == "row" {
+				return r.record, nil
+			}
+		}
+	}
+}
+
+// readCell reads the value of the cell element the decoder has just started, looking up shared strings by their index.
+func (r *Reader) readCell(cellType string) (value string, err error) {
+	text, err := readText(r.decoder, "c", func(name string) bool { return name == "v" || name == "t" })
+	if err != nil {
+		return "", err
+	}
+
+	if cellType != "s" {
+		return text, nil
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || index < 0 || index >= len(r.sharedStrings) {
+		return "", ErrorNotWorkbook
+	}
+
+	return r.sharedStrings[index], nil
+}
+
+// readSharedStrings reads the table of strings that cells of type s refer to by index.
+func readSharedStrings(archive *zip.Reader, name string) (sharedStrings []string, err error) {
+	file, err := archive.Open(name)
+	if err != nil {
+		return nil, ErrorNotWorkbook
+	}
+	defer file.Close()
+
+	decoder := xml.NewDecoder(file)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return sharedStrings, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "si" {
+			text, err := readText(decoder, "si", func(name string) bool { return name == "t" })
+			if err != nil {
+				return nil, err
+			}
+			sharedStrings = append(sharedStrings, text)
+		}
+	}
+}
+
+// readText concatenates the text of the elements named by isText within the element named end, up to its end.
+// The text of phonetic runs is left out, since it isn't part of the displayed value.
+func readText(decoder *xml.Decoder, end string, isText func(name string) bool) (text string, err error) {
+	var builder strings.Builder
+	inText := false
+	phonetic := 0
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Local == "rPh" {
+				phonetic++
+			}
+			inText = isText(element.Name.Local)
+		case xml.EndElement:
+			if element.Name.Local == end {
+				return builder.String(), nil
+			}
+			if element.Name.Local == "rPh" {
+				phonetic--
+			}
+			inText = false
+		case xml.CharData:
+			if inText && phonetic == 0 {
+				builder.Write(element)
+			}
+		}
+	}
+}
+
+// columnIndex returns the zero based column of a cell reference such as C3, or next when the reference has no column.
+func columnIndex(reference string, next int) int {
+	column := 0
+	for _, c := range reference {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		column = column*26 + int(c-'A') + 1
+	}
+
+	if column == 0 {
+		return next
+	}
+	return column - 1
+}
+
+// relationshipPath returns the path in the archive of a relationship target, which is relative to the xl directory unless it is absolute.
+func relationshipPath(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join("xl", target)
+}
+
+func decodeFile(archive *zip.Reader, name string, v interface{}) (err error) {
+	file, err := archive.Open(name)
+	if err != nil {
+		return ErrorNotWorkbook
+	}
+	defer file.Close()
+
+	return xml.NewDecoder(file).Decode(v)
+}
+
+// excelEpoch is day zero of the 1900 date system used by Excel, chosen so that serial numbers from 1 March 1900 on convert correctly,
+// since Excel counts 29 February 1900 as a day.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// ParseDate converts a date serial number of the 1900 date system, as dates and times are stored in xlsx cells, to a time in UTC.
+// Register it as a converter for time.Time to bind date cells to time fields.
+func ParseDate(value string) (date time.Time, err error) {
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return date, err
+	}
+
+	days := math.Floor(serial)
+	nanoseconds := math.Round((serial-days)*24*60*60*1000) * float64(time.Millisecond)
+
+	return excelEpoch.AddDate(0, 0, int(days)).Add(time.Duration(nanoseconds)), nil
+}
@@ -0,0 +1,154 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	csv "github.com/AidanJHMurphy/go-csv"
+)
+
+// newWorkbook builds an xlsx file holding the given worksheets, which are keyed by sheet name and hold the inner xml of their sheetData element.
+func newWorkbook(sheetNames []string, sheets map[string]string, sharedStrings string) []byte {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	write := func(name string, content string) {
+		file, _ := archive.Create(name)
+		file.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + content))
+	}
+
+	book := `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`
+	rels := `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`
+	for i, name := range sheetNames {
+		id := "rId" + string(rune('1'+i))
+		book += `<sheet name="` + name + `" sheetId="` + string(rune('1'+i)) + `" r:id="` + id + `"/>`
+		rels += `<Relationship Id="` + id + `" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet` + string(rune('1'+i)) + `.xml"/>`
+		write("xl/worksheets/sheet"+string(rune('1'+i))+".xml", `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`+sheets[name]+`</sheetData></worksheet>`)
+	}
+	rels += `<Relationship Id="rId9" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/></Relationships>`
+
+	write("xl/workbook.xml", book+`</sheets></workbook>`)
+	write("xl/_rels/workbook.xml.rels", rels)
+	write("xl/sharedStrings.xml", `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`+sharedStrings+`</sst>`)
+	archive.Close()
+
+	return buffer.Bytes()
+}
+
+var testSharedStrings = `<si><t>name</t></si><si><t>price</t></si><si><t>sold</t></si>` +
+	`<si><r><t>Wid</t></r><r><rPr><b/></rPr><t>get</t></r></si><si><t>Gadget</t><rPh><t>ガジェット</t></rPh></si>`
+
+var testSheet = `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>` +
+	`<row r="2"><c r="A2" t="s"><v>3</v></c><c r="B2"><v>9.5</v></c><c r="C2"><v>45000</v></c></row>` +
+	`<row r="3"></row>` +
+	`<row r="5"><c r="A5" t="s"><v>4</v></c><c r="C5"><f>TODAY()</f><v>45001.5</v></c></row>` +
+	`<row r="6"><c r="A6" t="inlineStr"><is><t>Gizmo</t></is></c><c r="B6" t="str"><f>A1&amp;"x"</f><v>3</v></c></row>`
+
+type xlsxTest struct {
+	Name  string    `csv:"header:name"`
+	Price float64   `csv:"header:price;omitempty"`
+	Sold  time.Time `csv:"header:sold;omitempty"`
+}
+
+func TestReader(t *testing.T) {
+	file := newWorkbook([]string{"Summary", "Prices"}, map[string]string{"Prices": testSheet}, testSharedStrings)
+
+	r, err := NewReader(bytes.NewReader(file), int64(len(file)), "Prices")
+	if err != nil {
+		t.Errorf("encountered error opening worksheet: %v", err)
+		return
+	}
+	defer r.Close()
+
+	expected := [][]string{
+		{"name", "price", "sold"},
+		{"Widget", "9.5", "45000"},
+		{"Gadget", "", "45001.5"},
+		{"Gizmo", "3", ""},
+	}
+
+	for i := 0; true; i++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			if i != len(expected) {
+				t.Errorf("expected %d rows, but got %d", len(expected), i)
+			}
+			break
+		}
+		if err != nil {
+			t.Errorf("encountered error reading row: %v", err)
+			break
+		}
+
+		if i >= len(expected) || !reflect.DeepEqual(record, expected[i]) {
+			t.Errorf("improperly read row %d. Got '%q'", i, record)
+		}
+	}
+}
+
+func TestReaderParser(t *testing.T) {
+	file := newWorkbook([]string{"Prices"}, map[string]string{"Prices": testSheet}, testSharedStrings)
+
+	r, err := NewReader(bytes.NewReader(file), int64(len(file)), "")
+	if err != nil {
+		t.Errorf("encountered error opening worksheet: %v", err)
+		return
+	}
+	defer r.Close()
+
+	p := csv.NewRecordParser(r, csv.ParserOptions{
+		Converters: map[reflect.Type]csv.Converter{
+			reflect.TypeOf(time.Time{}): {
+				Parse: func(value string) (interface{}, error) { return ParseDate(value) },
+			},
+		},
+	})
+
+	var record xlsxTest
+	err = p.ParseHeader(&record)
+	if err != nil {
+		t.Errorf("encountered error parsing worksheet header: %v", err)
+	}
+
+	err = p.ReadRecord(&record)
+	if err != nil {
+		t.Errorf("encountered error reading worksheet record: %v", err)
+	}
+
+	expected := xlsxTest{Name: "Widget", Price: 9.5, Sold: time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC)}
+	if record != expected {
+		t.Errorf("improperly read worksheet record. Got '%+v' but expected '%+v'", record, expected)
+	}
+}
+
+func TestSheetNames(t *testing.T) {
+	file := newWorkbook([]string{"Summary", "Prices"}, map[string]string{}, "")
+
+	names, err := SheetNames(bytes.NewReader(file), int64(len(file)))
+	if err != nil || !reflect.DeepEqual(names, []string{"Summary", "Prices"}) {
+		t.Errorf("improperly read sheet names. Got '%v' and error %v", names, err)
+	}
+
+	_, err = NewReader(bytes.NewReader(file), int64(len(file)), "Totals")
+	if !errors.Is(err, ErrorSheetNotFound) {
+		t.Errorf("expected to encounter ErrorSheetNotFound error, but got %v", err)
+	}
+
+	_, err = NewReader(bytes.NewReader([]byte("name,price\n")), 11, "")
+	if !errors.Is(err, ErrorNotWorkbook) {
+		t.Errorf("expected to encounter ErrorNotWorkbook error, but got %v", err)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	date, err := ParseDate("45001.5")
+	expected := time.Date(2023, 3, 16, 12, 0, 0, 0, time.UTC)
+	if err != nil || !date.Equal(expected) {
+		t.Errorf("improperly parsed date. Got '%v' but expected '%v'", date, expected)
+	}
+}